@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var appointmentsCmd = &cobra.Command{
+	Use:   "appointments",
+	Short: "View and manage patient appointments",
+	Long: `Commands for the appointments patients book on the website.
+
+Patients are identified by email. Dates use the YYYY-MM-DD format and times
+the HH:MM format.`,
+}
+
+var appointmentsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List appointments",
+	Long: `List appointments, filtered by date range, status, patient and time of day.
+
+--time-of-day takes morning (before 12:00), afternoon (12:00-17:00),
+evening (from 17:00) or a range such as 09:00-12:30, matched against the
+start time of the appointment.
+
+Examples:
+  fisio-data-manager appointments list --from 2026-10-19 --to 2026-10-23
+  fisio-data-manager appointments list --status scheduled,confirmed --time-of-day morning
+  fisio-data-manager appointments list --patient jane@example.com --sort date --desc
+  fisio-data-manager appointments list --from 2026-10-01 --format csv > october.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := appointmentFilterFlags(cmd)
+		if err != nil {
+			return err
+		}
+		filter.Sort, _ = cmd.Flags().GetString("sort")
+		if err := models.ValidAppointmentSort(filter.Sort); err != nil {
+			return err
+		}
+		filter.Descending, _ = cmd.Flags().GetBool("desc")
+		filter.Limit, _ = cmd.Flags().GetInt("limit")
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		appointments, err := services.NewAppointmentService(db).GetAppointments(filter)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(appointments)
+		case "csv":
+			return writeAppointmentsCSV(os.Stdout, appointments)
+		default:
+			return outputAppointmentsTable(appointments)
+		}
+	},
+}
+
+var appointmentsShowCmd = &cobra.Command{
+	Use:   "show [appointment-id|google-event-id]",
+	Short: "Show an appointment with its symptom assessment",
+	Long: `Show one appointment, found by ID or by Google Calendar event ID, with its
+end time, cancellation details, status history and the symptom assessments
+linked to it.
+
+Examples:
+  fisio-data-manager appointments show <appointment-id>
+  fisio-data-manager appointments show <google-event-id> --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		appointment, err := services.NewAppointmentService(db).GetAppointment(args[0])
+		if err != nil {
+			return err
+		}
+
+		assessments, err := services.NewAssessmentService(db).GetAssessmentsForAppointment(appointment.ID)
+		if err != nil {
+			return err
+		}
+
+		history, err := services.NewAppointmentService(db).GetStatusHistory(appointment.ID)
+		if err != nil {
+			return err
+		}
+
+		details := models.AppointmentDetails{
+			Appointment: *appointment,
+			EndTime:     appointment.End(clinicConfig.Location).Format("15:04"),
+			Assessments: assessments,
+			History:     history,
+		}
+
+		if format, _ := cmd.Flags().GetString("format"); format == "json" {
+			return outputJSON(details)
+		}
+		outputAppointmentDetails(details)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(appointmentsCmd)
+	appointmentsCmd.AddCommand(appointmentsListCmd)
+	appointmentsCmd.AddCommand(appointmentsShowCmd)
+
+	// List command flags
+	addAppointmentFilterFlags(appointmentsListCmd)
+	appointmentsListCmd.Flags().String("sort", "date", "Sort by date, patient, status or created")
+	appointmentsListCmd.Flags().Bool("desc", false, "Sort in descending order")
+	appointmentsListCmd.Flags().Int("limit", 0, "Maximum number of appointments (0 for all)")
+	appointmentsListCmd.Flags().String("format", "table", "Output format (table, json, csv)")
+
+	// Show command flags
+	appointmentsShowCmd.Flags().String("format", "table", "Output format (table, json)")
+}
+
+// addAppointmentFilterFlags adds the flags read by appointmentFilterFlags
+func addAppointmentFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("from", "", "First date (YYYY-MM-DD)")
+	cmd.Flags().String("to", "", "Last date (YYYY-MM-DD)")
+	cmd.Flags().StringSlice("status", nil, "Only these statuses (scheduled, confirmed, cancelled, completed, no_show)")
+	cmd.Flags().String("patient", "", "Patient email")
+	cmd.Flags().String("time-of-day", "", "morning, afternoon, evening or a range such as 09:00-12:30")
+}
+
+// appointmentFilterFlags reads the date range, status, patient and time of
+// day flags into a filter
+func appointmentFilterFlags(cmd *cobra.Command) (models.AppointmentFilter, error) {
+	var filter models.AppointmentFilter
+
+	from, err := dateFlag(cmd, "from")
+	if err != nil {
+		return filter, err
+	}
+	to, err := dateFlag(cmd, "to")
+	if err != nil {
+		return filter, err
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return filter, fmt.Errorf("--to must not be before --from")
+	}
+	filter.From, filter.To = from, to
+
+	values, _ := cmd.Flags().GetStringSlice("status")
+	for _, value := range values {
+		status, err := models.ParseAppointmentStatus(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	filter.PatientEmail, _ = cmd.Flags().GetString("patient")
+	if timeOfDay, _ := cmd.Flags().GetString("time-of-day"); timeOfDay != "" {
+		if filter.TimeFrom, filter.TimeTo, err = models.ParseTimeOfDay(timeOfDay); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
+
+func outputAppointmentsTable(appointments []models.Appointment) error {
+	if len(appointments) == 0 {
+		fmt.Println("No appointments found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDATE\tTIME\tDURATION\tPATIENT\tEMAIL\tPHONE\tSTATUS")
+
+	for _, a := range appointments {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%dm\t%s\t%s\t%s\t%s\n",
+			a.ID,
+			a.AppointmentDate.Format("2006-01-02"),
+			a.AppointmentTime,
+			a.Duration,
+			truncateString(a.PatientName, 25),
+			a.PatientEmail,
+			a.PatientPhone,
+			a.Status,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n📊 %d appointment(s)\n", len(appointments))
+	return nil
+}
+
+func writeAppointmentsCSV(w io.Writer, appointments []models.Appointment) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := []string{"ID", "Date", "Time", "Duration", "Patient", "Email", "Phone", "Status", "Google Event ID", "Notes", "Cancellation Reason", "Cancelled At", "Created"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, a := range appointments {
+		cancelledAt := ""
+		if a.CancelledAt != nil {
+			cancelledAt = a.CancelledAt.Format("2006-01-02 15:04:05")
+		}
+
+		record := []string{
+			a.ID,
+			a.AppointmentDate.Format("2006-01-02"),
+			a.AppointmentTime,
+			strconv.Itoa(a.Duration),
+			a.PatientName,
+			a.PatientEmail,
+			a.PatientPhone,
+			a.Status,
+			a.GoogleEventID,
+			a.Notes,
+			a.CancellationReason,
+			cancelledAt,
+			a.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func outputAppointmentDetails(details models.AppointmentDetails) {
+	a := details.Appointment
+	fmt.Printf("📅 %s, %s–%s (%d min)\n", a.AppointmentDate.Format("Monday 2006-01-02"), a.AppointmentTime, details.EndTime, a.Duration)
+	fmt.Printf("Patient: %s <%s>", a.PatientName, a.PatientEmail)
+	if a.PatientPhone != "" {
+		fmt.Printf(", %s", a.PatientPhone)
+	}
+	fmt.Println()
+	fmt.Printf("Status: %s\n", a.Status)
+	if a.GoogleEventID != "" {
+		fmt.Printf("Google event: %s\n", a.GoogleEventID)
+	}
+	if a.Notes != "" {
+		fmt.Printf("Notes: %s\n", a.Notes)
+	}
+	fmt.Printf("ID: %s (booked %s)\n", a.ID, a.CreatedAt.Local().Format("2006-01-02 15:04"))
+
+	if a.Status == models.AppointmentCancelled || a.CancelledAt != nil || a.CancellationReason != "" {
+		fmt.Println()
+		cancelled := "❌ Cancelled"
+		if a.CancelledAt != nil {
+			cancelled += " on " + a.CancelledAt.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Println(cancelled)
+		if a.CancellationReason != "" {
+			fmt.Printf("Reason: %s\n", a.CancellationReason)
+		}
+	}
+
+	if len(details.History) > 0 {
+		fmt.Println("\n📝 Status history")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, c := range details.History {
+			fmt.Fprintf(w, "%s\t%s → %s\t%s\t%s\n",
+				c.CreatedAt.Local().Format("2006-01-02 15:04"),
+				c.FromStatus,
+				c.ToStatus,
+				c.Actor,
+				c.Reason,
+			)
+		}
+		w.Flush()
+	}
+
+	if len(details.Assessments) == 0 {
+		fmt.Println("\nNo symptom assessment linked to this appointment.")
+		return
+	}
+	for _, assessment := range details.Assessments {
+		outputAssessmentDetails(assessment)
+	}
+}
+
+func outputAssessmentDetails(assessment models.SymptomAssessment) {
+	symptoms := assessment.Symptoms
+	fmt.Printf("\n🩺 Symptom assessment (%s)\n", assessment.AssessmentDate.Format("2006-01-02"))
+	fmt.Printf("Pain: %d/10", assessment.PainLevel)
+	if locations := assessment.Locations(); len(locations) > 0 {
+		fmt.Printf(" in %s", strings.Join(locations, ", "))
+	}
+	fmt.Println()
+
+	lines := []struct {
+		label string
+		value string
+	}{
+		{"Primary symptom", assessment.Symptom()},
+		{"Secondary symptoms", strings.Join(symptoms.SecondarySymptoms, ", ")},
+		{"Onset", symptoms.OnsetDate},
+		{"Duration", assessment.Duration()},
+		{"Daily impact", assessment.Impact()},
+		{"Triggers", strings.Join(symptoms.TriggerEvents, ", ")},
+		{"Worse with", strings.Join(symptoms.WorseningFactors, ", ")},
+		{"Better with", strings.Join(symptoms.RelievingFactors, ", ")},
+		{"Previous treatments", firstNonEmpty(assessment.PreviousTreatments, symptoms.PreviousTreatments)},
+		{"Current medications", firstNonEmpty(assessment.CurrentMedications, symptoms.CurrentMedications)},
+		{"Additional notes", firstNonEmpty(assessment.AdditionalNotes, symptoms.AdditionalNotes)},
+		{"Recommendations", assessment.Recommendations},
+	}
+	for _, line := range lines {
+		if line.value != "" {
+			fmt.Printf("%s: %s\n", line.label, line.value)
+		}
+	}
+}
+
+// firstNonEmpty returns the first value that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
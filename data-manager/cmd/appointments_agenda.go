@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"fisio-data-manager/internal/agenda"
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var appointmentsAgendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "Show the day's or week's appointments as a time grid",
+	Long: `Show the appointments of a day (or, with --week, of its Monday-to-Sunday
+week) as a time grid in 30-minute rows, with the patient, status and pain
+level from their symptom assessment, and the free time between them.
+
+Dates and times are those of the clinic, in its time zone (--timezone or
+CLINIC_TIMEZONE, default America/New_York). The grid covers the opening hours
+(CLINIC_OPENS_AT and CLINIC_CLOSES_AT, default 09:00-17:00) and any
+appointment outside them. Cancelled appointments are left out unless
+--include-cancelled is given.
+
+--format html renders a printable agenda for the reception desk.
+
+Examples:
+  fisio-data-manager appointments agenda
+  fisio-data-manager appointments agenda --date tomorrow
+  fisio-data-manager appointments agenda --date 2026-10-20 --week
+  fisio-data-manager appointments agenda --week --format html --output agenda.html`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+
+		value, _ := cmd.Flags().GetString("date")
+		date, err := clinicConfig.ParseDate(value, time.Now())
+		if err != nil {
+			return err
+		}
+		dates := []time.Time{date}
+		week, _ := cmd.Flags().GetBool("week")
+		if week {
+			dates = agenda.Week(date)
+		}
+		includeCancelled, _ := cmd.Flags().GetBool("include-cancelled")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		if output != "" && format != "html" {
+			return fmt.Errorf("--output is only supported with --format html")
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		entries, err := services.NewAppointmentService(db).GetAgenda(dates[0], dates[len(dates)-1], includeCancelled)
+		if err != nil {
+			return err
+		}
+
+		days := make([]agenda.Day, len(dates))
+		for i, d := range dates {
+			days[i] = agenda.BuildDay(clinicConfig, d, entries)
+		}
+		if week {
+			days = workingDays(days)
+		}
+
+		var out io.Writer = os.Stdout
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+			out = file
+		}
+
+		switch format {
+		case "json":
+			return outputJSON(days)
+		case "html":
+			if err := writeAgendaHTML(out, clinicConfig, days); err != nil {
+				return fmt.Errorf("failed to render agenda: %w", err)
+			}
+			if output != "" {
+				fmt.Printf("✅ Agenda written to %s\n", output)
+			}
+			return nil
+		default:
+			if week {
+				return outputWeekGrid(clinicConfig, days)
+			}
+			return outputDayGrid(clinicConfig, days[0])
+		}
+	},
+}
+
+func init() {
+	appointmentsCmd.AddCommand(appointmentsAgendaCmd)
+
+	appointmentsAgendaCmd.Flags().String("date", "today", "Day to show: today, tomorrow, yesterday or YYYY-MM-DD")
+	appointmentsAgendaCmd.Flags().Bool("week", false, "Show the whole week (Monday to Sunday) of the date")
+	appointmentsAgendaCmd.Flags().Bool("include-cancelled", false, "Also show cancelled appointments")
+	appointmentsAgendaCmd.Flags().String("format", "table", "Output format (table, html, json)")
+	appointmentsAgendaCmd.Flags().String("output", "", "Output file for --format html (default: stdout)")
+}
+
+// workingDays leaves out the weekend days of a week that have no
+// appointments
+func workingDays(days []agenda.Day) []agenda.Day {
+	kept := make([]agenda.Day, 0, len(days))
+	for _, day := range days {
+		weekend := day.Date.Weekday() == time.Saturday || day.Date.Weekday() == time.Sunday
+		if !weekend || len(day.Entries) > 0 {
+			kept = append(kept, day)
+		}
+	}
+	return kept
+}
+
+func outputDayGrid(c *clinic.Config, day agenda.Day) error {
+	fmt.Printf("📅 %s (%s)\n\n", day.Date.Format("Monday 2006-01-02"), c.Location)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, slot := range day.Slots {
+		clock := slot.Start.Format("15:04")
+		if slot.Free() {
+			fmt.Fprintf(w, "%s\t·\n", clock)
+			continue
+		}
+		for _, entry := range slot.Starting {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s–%s\t%s\n",
+				clock,
+				truncateString(entry.PatientName, 25),
+				entry.Status,
+				painLabel(entry.PainLevel),
+				entry.AppointmentTime,
+				entry.End(c.Location).Format("15:04"),
+				truncateString(entry.PrimarySymptom, 30),
+			)
+			clock = ""
+		}
+		for _, entry := range slot.Ongoing {
+			fmt.Fprintf(w, "%s\t│ %s\n", clock, truncateString(entry.PatientName, 23))
+			clock = ""
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("🕓 Free: %s\n", gapsLabel(day.Gaps))
+	fmt.Printf("📊 %d appointment(s), %s booked\n", len(day.Entries), minutesLabel(day.BookedMinutes))
+	return nil
+}
+
+func outputWeekGrid(c *clinic.Config, days []agenda.Day) error {
+	fmt.Printf("📅 Week of %s (%s)\n\n", days[0].Date.Format("Monday 2006-01-02"), c.Location)
+
+	// Rows from the earliest to the latest time of any day
+	var clocks []string
+	seen := make(map[string]bool)
+	cells := make([]map[string]string, len(days))
+	for i, day := range days {
+		cells[i] = make(map[string]string)
+		for _, slot := range day.Slots {
+			clock := slot.Start.Format("15:04")
+			if !seen[clock] {
+				seen[clock] = true
+				clocks = append(clocks, clock)
+			}
+			cells[i][clock] = weekCell(slot)
+		}
+	}
+	sort.Strings(clocks)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{"TIME"}
+	for _, day := range days {
+		header = append(header, strings.ToUpper(day.Date.Format("Mon 02")))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, clock := range clocks {
+		row := []string{clock}
+		for i := range days {
+			cell, ok := cells[i][clock]
+			if !ok {
+				cell = "·"
+			}
+			row = append(row, cell)
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	total, booked := 0, 0
+	for _, day := range days {
+		free := 0
+		for _, gap := range day.Gaps {
+			free += int(gap.Duration().Minutes())
+		}
+		fmt.Printf("%s: %d appointment(s), %s free\n", day.Date.Format("Mon 02"), len(day.Entries), minutesLabel(free))
+		total += len(day.Entries)
+		booked += day.BookedMinutes
+	}
+	fmt.Printf("📊 %d appointment(s), %s booked\n", total, minutesLabel(booked))
+	return nil
+}
+
+// weekCell summarizes a slot in the week grid
+func weekCell(slot agenda.Slot) string {
+	switch {
+	case len(slot.Starting) > 1:
+		return fmt.Sprintf("%d appointments", len(slot.Starting))
+	case len(slot.Starting) == 1:
+		entry := slot.Starting[0]
+		cell := truncateString(entry.PatientName, 16)
+		if entry.PainLevel != nil {
+			cell += fmt.Sprintf(" %d/10", *entry.PainLevel)
+		}
+		if entry.Status != models.AppointmentScheduled {
+			cell += " (" + strings.ReplaceAll(entry.Status, "_", "-") + ")"
+		}
+		return cell
+	case len(slot.Ongoing) > 0:
+		return "│"
+	default:
+		return "·"
+	}
+}
+
+func painLabel(level *int) string {
+	if level == nil {
+		return "pain -"
+	}
+	return fmt.Sprintf("pain %d/10", *level)
+}
+
+func gapsLabel(gaps []agenda.Gap) string {
+	if len(gaps) == 0 {
+		return "none"
+	}
+	labels := make([]string, len(gaps))
+	for i, gap := range gaps {
+		labels[i] = fmt.Sprintf("%s–%s (%s)", gap.Start.Format("15:04"), gap.End.Format("15:04"), minutesLabel(int(gap.Duration().Minutes())))
+	}
+	return strings.Join(labels, ", ")
+}
+
+// minutesLabel formats minutes as 45m, 2h or 1h30
+func minutesLabel(minutes int) string {
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dh%02d", minutes/60, minutes%60)
+	}
+}
+
+// agendaRow is an appointment or free time in the printable agenda
+type agendaRow struct {
+	Start time.Time
+	Time  string
+	Free  string // length of the free time, for free time rows
+	Entry *models.AgendaEntry
+}
+
+var agendaTemplate = template.Must(template.New("agenda").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Agenda – {{.Title}}</title>
+<style>
+  body { font-family: Arial, Helvetica, sans-serif; max-width: 1000px; margin: 2em auto; color: #222; }
+  h1 { font-size: 1.6em; margin-bottom: 0.2em; }
+  h2 { font-size: 1.2em; margin-top: 1.6em; border-bottom: 1px solid #ccc; }
+  .meta { color: #555; }
+  table { width: 100%; border-collapse: collapse; }
+  th, td { text-align: left; padding: 0.35em 0.5em; border-bottom: 1px solid #e5e5e5; vertical-align: top; }
+  th { font-size: 0.85em; color: #555; }
+  tr.free td { color: #888; font-style: italic; }
+  tr.cancelled td { color: #888; text-decoration: line-through; }
+  .time { white-space: nowrap; width: 7em; }
+  .day { page-break-inside: avoid; }
+  @media print { body { margin: 0; } .day { page-break-after: always; } .day:last-child { page-break-after: auto; } }
+</style>
+</head>
+<body>
+<h1>Agenda – {{.Title}}</h1>
+<p class="meta">Times in {{.TimeZone}} · printed {{.Printed}}</p>
+{{range .Days}}
+<div class="day">
+<h2>{{.Date}}</h2>
+{{if .Rows}}<table>
+  <tr><th class="time">Time</th><th>Patient</th><th>Phone</th><th>Status</th><th>Pain</th><th>Symptom</th><th>Notes</th></tr>
+  {{range .Rows}}{{if .Entry}}<tr class="{{.Entry.Status}}">
+    <td class="time">{{.Time}}</td>
+    <td>{{.Entry.PatientName}}</td>
+    <td>{{.Entry.PatientPhone}}</td>
+    <td>{{.Entry.Status}}</td>
+    <td>{{if .Entry.PainLevel}}{{.Entry.PainLevel}}/10{{end}}</td>
+    <td>{{.Entry.PrimarySymptom}}</td>
+    <td>{{.Entry.Notes}}</td>
+  </tr>{{else}}<tr class="free"><td class="time">{{.Time}}</td><td colspan="6">Free ({{.Free}})</td></tr>{{end}}
+  {{end}}
+</table>{{else}}<p class="meta">Closed.</p>{{end}}
+<p class="meta">{{.Summary}}</p>
+</div>
+{{end}}
+</body>
+</html>
+`))
+
+// writeAgendaHTML renders the printable agenda of the days
+func writeAgendaHTML(out io.Writer, c *clinic.Config, days []agenda.Day) error {
+	type dayView struct {
+		Date    string
+		Rows    []agendaRow
+		Summary string
+	}
+	data := struct {
+		Title    string
+		TimeZone string
+		Printed  string
+		Days     []dayView
+	}{
+		Title:    days[0].Date.Format("Monday 2006-01-02"),
+		TimeZone: c.Location.String(),
+		Printed:  time.Now().In(c.Location).Format("2006-01-02 15:04"),
+	}
+	if len(days) > 1 {
+		data.Title = "week of " + data.Title
+	}
+
+	for _, day := range days {
+		var rows []agendaRow
+		for i := range day.Entries {
+			entry := &day.Entries[i]
+			rows = append(rows, agendaRow{
+				Start: entry.Start(c.Location),
+				Time:  entry.AppointmentTime + "–" + entry.End(c.Location).Format("15:04"),
+				Entry: entry,
+			})
+		}
+		for _, gap := range day.Gaps {
+			rows = append(rows, agendaRow{
+				Start: gap.Start,
+				Time:  gap.Start.Format("15:04") + "–" + gap.End.Format("15:04"),
+				Free:  minutesLabel(int(gap.Duration().Minutes())),
+			})
+		}
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].Start.Before(rows[j].Start) })
+
+		data.Days = append(data.Days, dayView{
+			Date:    day.Date.Format("Monday 2006-01-02"),
+			Rows:    rows,
+			Summary: fmt.Sprintf("%d appointment(s), %s booked", len(day.Entries), minutesLabel(day.BookedMinutes)),
+		})
+	}
+
+	return agendaTemplate.Execute(out, data)
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"fisio-data-manager/internal/availability"
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var appointmentsAuditOverlapsCmd = &cobra.Command{
+	Use:   "audit-overlaps",
+	Short: "Find double-bookings and other scheduling problems",
+	Long: `Check the appointments that are not cancelled for scheduling problems,
+each with a suggested resolution:
+
+  overlap        appointments sharing some of their time (double-bookings)
+  outside_hours  an appointment outside the working hours, or during a break
+  no_event       an upcoming appointment without a Google Calendar event ID
+
+Overlapping appointments are grouped: the one booked first keeps its time
+and the others are suggested the first free slot in the two weeks after
+them. Two bookings of the same patient are treated as a duplicate to cancel.
+
+Working hours and breaks come from the booking rules (see appointments slots
+--rules); the defaults are the clinic's opening hours, Monday to Friday. The
+minimum notice and maximum advance only limit patients' bookings, so they do
+not apply to suggested moves.
+
+Examples:
+  fisio-data-manager appointments audit-overlaps
+  fisio-data-manager appointments audit-overlaps --from 2026-09-01 --to 2026-12-31
+  fisio-data-manager appointments audit-overlaps --rules booking-rules.json --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+
+		rules := availability.DefaultRules(clinicConfig)
+		if path, _ := cmd.Flags().GetString("rules"); path != "" {
+			rules, err = availability.LoadRules(path, rules)
+			if err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		value, _ := cmd.Flags().GetString("from")
+		from, err := clinicConfig.ParseDate(value, now)
+		if err != nil {
+			return err
+		}
+		to := from.AddDate(0, 0, 90)
+		if value, _ := cmd.Flags().GetString("to"); value != "" {
+			if to, err = clinicConfig.ParseDate(value, now); err != nil {
+				return err
+			}
+		}
+		if to.Before(from) {
+			return fmt.Errorf("--to must not be before --from")
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		// The day before, for appointments running over midnight, and the
+		// weeks after, where moves are suggested
+		dayBefore, last := from.AddDate(0, 0, -1), to.AddDate(0, 0, 14)
+		appointments, err := services.NewAppointmentService(db).GetAppointments(models.AppointmentFilter{
+			From: &dayBefore,
+			To:   &last,
+		})
+		if err != nil {
+			return err
+		}
+
+		closures, err := services.NewClosureService(db).GetClosures(&from, &last)
+		if err != nil {
+			return err
+		}
+
+		issues := rules.Audit(clinicConfig, from, to, now, appointments,
+			availability.Closed(clinicConfig, closures, from, last))
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(issues)
+		default:
+			return outputIssues(clinicConfig, from, to, issues)
+		}
+	},
+}
+
+func init() {
+	appointmentsCmd.AddCommand(appointmentsAuditOverlapsCmd)
+
+	appointmentsAuditOverlapsCmd.Flags().String("from", "today", "First date: today, tomorrow or YYYY-MM-DD")
+	appointmentsAuditOverlapsCmd.Flags().String("to", "", "Last date: today, tomorrow or YYYY-MM-DD (default: 90 days from --from)")
+	appointmentsAuditOverlapsCmd.Flags().String("rules", "", "JSON file with booking rules (default: built-in rules)")
+	appointmentsAuditOverlapsCmd.Flags().String("format", "table", "Output format (table, json)")
+}
+
+var issueTitles = map[string]string{
+	availability.IssueOverlap:      "Overlap",
+	availability.IssueOutsideHours: "Outside working hours",
+	availability.IssueNoEvent:      "No calendar event",
+}
+
+func outputIssues(c *clinic.Config, from, to time.Time, issues []availability.Issue) error {
+	if len(issues) == 0 {
+		fmt.Printf("✅ No scheduling problems between %s and %s.\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		counts[issue.Kind]++
+
+		fmt.Printf("⚠️  %s: %s %s-%s, %s\n", issueTitles[issue.Kind],
+			issue.Start.Format("Mon 2006-01-02"), issue.Start.Format("15:04"), issue.End.Format("15:04"), issue.Detail)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, a := range issue.Appointments {
+			fmt.Fprintf(w, "   %s\t%s-%s\t%s\t%s\tbooked %s\n",
+				a.ID,
+				a.AppointmentTime,
+				a.End(c.Location).Format("15:04"),
+				truncateString(a.PatientName, 25),
+				a.Status,
+				a.CreatedAt.In(c.Location).Format("2006-01-02 15:04"),
+			)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("   → %s\n\n", issue.Suggestion)
+	}
+
+	var summary []string
+	for _, kind := range []string{availability.IssueOverlap, availability.IssueOutsideHours, availability.IssueNoEvent} {
+		if counts[kind] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	fmt.Printf("🔍 %d issue(s) between %s and %s: %s\n", len(issues),
+		from.Format("2006-01-02"), to.Format("2006-01-02"), strings.Join(summary, ", "))
+	return nil
+}
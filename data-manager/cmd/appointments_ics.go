@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/ics"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// feedPath is where serve-ics serves the calendar
+const feedPath = "/appointments.ics"
+
+var appointmentsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export appointments as an iCalendar file",
+	Long: `Export appointments as an iCalendar (RFC 5545) file, to import into any
+calendar app, or as JSON or CSV.
+
+Each appointment keeps the same UID across exports, derived from its ID, so
+importing a newer export updates the events instead of duplicating them.
+Scheduled appointments are tentative events, cancelled ones are cancelled
+events. Times are written in UTC, so they show correctly whatever the time
+zone of the calendar app.
+
+--redact leaves out patient names, contact details, notes and cancellation
+reasons, for calendars shared beyond the clinical staff. It applies to every
+format: JSON and CSV keep the fields, empty.
+
+Examples:
+  fisio-data-manager appointments export --from 2026-10-01 --output appointments.ics
+  fisio-data-manager appointments export --status scheduled,confirmed --redact > shared.ics
+  fisio-data-manager appointments export --format csv --from 2026-10-01 > october.csv
+  fisio-data-manager appointments export --format json --redact > shared.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := appointmentFilterFlags(cmd)
+		if err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString("format")
+		redact, _ := cmd.Flags().GetBool("redact")
+		name, _ := cmd.Flags().GetString("name")
+		output, _ := cmd.Flags().GetString("output")
+
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		appointments, err := services.NewAppointmentService(db).GetAppointments(filter)
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+			out = file
+		}
+
+		if redact && format != "ics" {
+			appointments = redactAppointments(appointments)
+		}
+
+		switch format {
+		case "json":
+			var data []byte
+			if data, err = json.MarshalIndent(appointments, "", "  "); err == nil {
+				_, err = fmt.Fprintln(out, string(data))
+			}
+		case "csv":
+			err = writeAppointmentsCSV(out, appointments)
+		case "ics":
+			err = ics.Write(out, appointmentCalendar(clinicConfig, name, appointments, redact, 0))
+		default:
+			return fmt.Errorf("unsupported format '%s': must be 'ics', 'json' or 'csv'", format)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export appointments: %w", err)
+		}
+
+		if output != "" {
+			fmt.Printf("✅ Exported %d appointment(s) to %s\n", len(appointments), output)
+		}
+		return nil
+	},
+}
+
+var appointmentsServeICSCmd = &cobra.Command{
+	Use:   "serve-ics",
+	Short: "Serve appointments as a calendar feed staff can subscribe to",
+	Long: `Serve the appointments as an iCalendar feed, so staff can subscribe from
+any calendar app without access to the clinic's Google Calendar account.
+
+The feed is served at /appointments.ics and needs the token given with
+--token (or ICS_FEED_TOKEN) as a query parameter, since calendar apps cannot
+send other credentials:
+
+  https://calendar.example.com/appointments.ics?token=<token>
+
+The feed covers --past-days before today to --future-days after, and is
+built from the database on each request. Run it behind a TLS-terminating
+proxy when it is reachable from the internet.
+
+Examples:
+  fisio-data-manager appointments serve-ics --token "$(openssl rand -hex 24)"
+  fisio-data-manager appointments serve-ics --addr :8080 --redact --future-days 30`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = viper.GetString("ICS_FEED_TOKEN")
+		}
+		if len(token) < 16 {
+			return fmt.Errorf("a token of at least 16 characters is required (--token or ICS_FEED_TOKEN)")
+		}
+		addr, _ := cmd.Flags().GetString("addr")
+		pastDays, _ := cmd.Flags().GetInt("past-days")
+		futureDays, _ := cmd.Flags().GetInt("future-days")
+		redact, _ := cmd.Flags().GetBool("redact")
+		name, _ := cmd.Flags().GetString("name")
+
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewAppointmentService(db)
+		feed := func() ([]byte, error) {
+			today := clinicConfig.Today(time.Now())
+			from, to := today.AddDate(0, 0, -pastDays), today.AddDate(0, 0, futureDays)
+			appointments, err := service.GetAppointments(models.AppointmentFilter{From: &from, To: &to})
+			if err != nil {
+				return nil, err
+			}
+			var buf bytes.Buffer
+			cal := appointmentCalendar(clinicConfig, name, appointments, redact, 15*time.Minute)
+			if err := ics.Write(&buf, cal); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc(feedPath, func(w http.ResponseWriter, r *http.Request) {
+			serveFeed(w, r, token, feed)
+		})
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+			WriteTimeout:      30 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server.Shutdown(shutdown)
+		}()
+
+		fmt.Printf("📅 Serving appointments at http://%s%s?token=...\n", displayAddr(addr), feedPath)
+		fmt.Println("Press Ctrl+C to stop")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("failed to serve calendar feed: %w", err)
+		}
+		fmt.Println("\n✅ Stopped")
+		return nil
+	},
+}
+
+func init() {
+	appointmentsCmd.AddCommand(appointmentsExportCmd)
+	appointmentsCmd.AddCommand(appointmentsServeICSCmd)
+
+	// Export command flags
+	addAppointmentFilterFlags(appointmentsExportCmd)
+	appointmentsExportCmd.Flags().String("format", "ics", "Output format (ics, json, csv)")
+	appointmentsExportCmd.Flags().Bool("redact", false, "Leave out patient names, contact details and notes")
+	appointmentsExportCmd.Flags().String("name", "Appointments", "Calendar name (ics)")
+	appointmentsExportCmd.Flags().String("output", "", "Output file (default: stdout)")
+
+	// Serve command flags
+	appointmentsServeICSCmd.Flags().String("addr", "127.0.0.1:8080", "Address to listen on")
+	appointmentsServeICSCmd.Flags().String("token", "", "Token subscribers must pass (default: ICS_FEED_TOKEN)")
+	appointmentsServeICSCmd.Flags().Int("past-days", 30, "Days before today to include")
+	appointmentsServeICSCmd.Flags().Int("future-days", 90, "Days after today to include")
+	appointmentsServeICSCmd.Flags().Bool("redact", false, "Leave out patient names, contact details and notes")
+	appointmentsServeICSCmd.Flags().String("name", "Appointments", "Calendar name")
+}
+
+// serveFeed answers a calendar app's request for the feed
+func serveFeed(w http.ResponseWriter, r *http.Request, token string, feed func() ([]byte, error)) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := feed()
+	if err != nil {
+		log.Printf("failed to build calendar feed: %v", err)
+		http.Error(w, "failed to build calendar", http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="appointments.ics"`)
+	if r.Method == http.MethodHead {
+		return
+	}
+	io.Copy(w, bytes.NewReader(body))
+}
+
+// displayAddr returns a host:port for the listening address, for messages
+func displayAddr(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+	return addr
+}
+
+// appointmentEventStatuses maps appointment statuses to event statuses; completed
+// and no-show appointments did take place in the calendar
+var appointmentEventStatuses = map[string]string{
+	models.AppointmentScheduled: ics.StatusTentative,
+	models.AppointmentConfirmed: ics.StatusConfirmed,
+	models.AppointmentCompleted: ics.StatusConfirmed,
+	models.AppointmentNoShow:    ics.StatusConfirmed,
+	models.AppointmentCancelled: ics.StatusCancelled,
+}
+
+// appointmentCalendar builds the calendar of the appointments
+func appointmentCalendar(c *clinic.Config, name string, appointments []models.Appointment, redact bool, refresh time.Duration) ics.Calendar {
+	cal := ics.Calendar{
+		Name:            name,
+		TimeZone:        c.Location.String(),
+		RefreshInterval: refresh,
+		Events:          make([]ics.Event, 0, len(appointments)),
+	}
+	for _, a := range appointments {
+		cal.Events = append(cal.Events, appointmentEvent(c.Location, a, redact))
+	}
+	return cal
+}
+
+// redactAppointments returns the appointments without the patient's name,
+// contact details, notes and cancellation reason
+func redactAppointments(appointments []models.Appointment) []models.Appointment {
+	redacted := make([]models.Appointment, len(appointments))
+	for i, a := range appointments {
+		a.PatientName, a.PatientEmail, a.PatientPhone = "", "", ""
+		a.Notes, a.CancellationReason = "", ""
+		redacted[i] = a
+	}
+	return redacted
+}
+
+// appointmentEvent turns an appointment into a calendar event. Its UID only
+// depends on the appointment ID, so it stays the same across exports.
+func appointmentEvent(loc *time.Location, a models.Appointment, redact bool) ics.Event {
+	status := strings.ReplaceAll(a.Status, "_", "-")
+	event := ics.Event{
+		UID:        "appointment-" + a.ID + "@fisio-data-manager",
+		Start:      a.Start(loc),
+		End:        a.End(loc),
+		Status:     appointmentEventStatuses[a.Status],
+		Categories: []string{"Appointment", status},
+		Updated:    a.UpdatedAt,
+	}
+
+	if redact {
+		event.Summary = "Appointment"
+		event.Description = "Status: " + status
+		return event
+	}
+
+	event.Summary = "Appointment: " + a.PatientName
+	if a.Status == models.AppointmentCancelled {
+		event.Summary = "Cancelled: " + a.PatientName
+	}
+	lines := []string{
+		"Patient: " + a.PatientName,
+		"Email: " + a.PatientEmail,
+	}
+	if a.PatientPhone != "" {
+		lines = append(lines, "Phone: "+a.PatientPhone)
+	}
+	lines = append(lines, "Status: "+status)
+	if a.Notes != "" {
+		lines = append(lines, "Notes: "+a.Notes)
+	}
+	if a.CancellationReason != "" {
+		lines = append(lines, "Cancellation reason: "+a.CancellationReason)
+	}
+	event.Description = strings.Join(lines, "\n")
+
+	return event
+}
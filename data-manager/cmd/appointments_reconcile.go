@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"fisio-data-manager/internal/calendar"
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var appointmentsReconcileCalendarCmd = &cobra.Command{
+	Use:   "reconcile-calendar",
+	Short: "Compare appointments with the clinic's Google Calendar",
+	Long: `Compare the appointments with the events the booking flow created for them
+on the clinic's Google Calendar, and report where the two disagree.
+
+Booking and cancelling update the database and the calendar one after the
+other, so a failure halfway leaves them out of sync. The report lists:
+
+  orphan_event    an appointment event with no appointment
+  unlinked_event  an event naming an appointment that has no event ID
+  no_event        an upcoming appointment without an event
+  missing_event   an upcoming appointment whose event does not exist
+  deleted_event   an upcoming appointment whose event was deleted
+  active_event    a cancelled appointment whose event is still on the calendar
+  time_mismatch   an upcoming appointment whose event is at another time
+
+With --fix the database side is brought in line with the calendar: unlinked
+events are linked to their appointment, appointments whose event is missing
+or deleted are cancelled, and appointments are moved to the time of their
+event. The fixes are previewed first and nothing changes until --confirm is
+given; cancellations and moves are kept in the status history. Orphan and
+active events, and appointments without an event, have to be fixed on the
+calendar.
+
+The calendar is read with the Google OAuth credentials of the edge functions
+(GOOGLE_CLIENT_ID, GOOGLE_CLIENT_SECRET, GOOGLE_REFRESH_TOKEN) from
+GOOGLE_CALENDAR_ID (default: primary). --calendar-file reads the events from
+a JSON file instead, to try the report out offline; it cannot be combined
+with --fix, as every event missing from the file would cancel its
+appointment.
+
+Examples:
+  fisio-data-manager appointments reconcile-calendar
+  fisio-data-manager appointments reconcile-calendar --from 2026-10-01 --to 2026-10-31 --format json
+  fisio-data-manager appointments reconcile-calendar --fix --by "Ana Souza"
+  fisio-data-manager appointments reconcile-calendar --fix --by "Ana Souza" --confirm
+  fisio-data-manager appointments reconcile-calendar --calendar-file events.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		today := clinicConfig.Today(now)
+		from, to := today.AddDate(0, 0, -30), today.AddDate(0, 0, 90)
+		if value, _ := cmd.Flags().GetString("from"); value != "" {
+			if from, err = clinicConfig.ParseDate(value, now); err != nil {
+				return err
+			}
+		}
+		if value, _ := cmd.Flags().GetString("to"); value != "" {
+			if to, err = clinicConfig.ParseDate(value, now); err != nil {
+				return err
+			}
+		}
+		if to.Before(from) {
+			return fmt.Errorf("--to must not be before --from")
+		}
+		fix, _ := cmd.Flags().GetBool("fix")
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		by, _ := cmd.Flags().GetString("by")
+		format, _ := cmd.Flags().GetString("format")
+
+		var client calendar.Client
+		if path, _ := cmd.Flags().GetString("calendar-file"); path != "" {
+			if fix {
+				return fmt.Errorf("--fix cannot be used with --calendar-file: events missing from the file would cancel their appointments")
+			}
+			if client, err = calendar.LoadFakeClient(path); err != nil {
+				return err
+			}
+		} else {
+			calendarID, _ := cmd.Flags().GetString("calendar-id")
+			if calendarID == "" {
+				calendarID = viper.GetString("GOOGLE_CALENDAR_ID")
+			}
+			if client, err = calendar.NewGoogleClient(
+				viper.GetString("GOOGLE_CLIENT_ID"),
+				viper.GetString("GOOGLE_CLIENT_SECRET"),
+				viper.GetString("GOOGLE_REFRESH_TOKEN"),
+				calendarID,
+			); err != nil {
+				return err
+			}
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewAppointmentService(db)
+		appointments, events, err := loadCalendarAndAppointments(cmd.Context(), client, service, clinicConfig, from, to)
+		if err != nil {
+			return err
+		}
+
+		findings := calendar.Reconcile(appointments, events, clinicConfig.Location)
+
+		fixed := 0
+		if fix && confirm {
+			if by == "" {
+				by = "calendar reconciliation"
+			}
+			for _, finding := range findings {
+				if finding.Fix == "" {
+					continue
+				}
+				if err := applyCalendarFix(service, clinicConfig, finding, by); err != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  Could not fix appointment %s: %v\n", finding.Appointment.ID, err)
+					continue
+				}
+				fixed++
+			}
+		}
+
+		switch format {
+		case "json":
+			return outputJSON(findings)
+		default:
+			return outputFindings(clinicConfig, from, to, findings, fix, confirm, fixed)
+		}
+	},
+}
+
+func init() {
+	appointmentsCmd.AddCommand(appointmentsReconcileCalendarCmd)
+
+	appointmentsReconcileCalendarCmd.Flags().String("from", "", "First date: today, tomorrow or YYYY-MM-DD (default: 30 days ago)")
+	appointmentsReconcileCalendarCmd.Flags().String("to", "", "Last date: today, tomorrow or YYYY-MM-DD (default: 90 days ahead)")
+	appointmentsReconcileCalendarCmd.Flags().Bool("fix", false, "Update the appointments to match the calendar")
+	appointmentsReconcileCalendarCmd.Flags().Bool("confirm", false, "Apply the fixes (otherwise only preview)")
+	appointmentsReconcileCalendarCmd.Flags().String("by", "", "Staff member recorded for cancellations and moves (default: calendar reconciliation)")
+	appointmentsReconcileCalendarCmd.Flags().String("calendar-id", "", "Google Calendar to read (default: GOOGLE_CALENDAR_ID or primary)")
+	appointmentsReconcileCalendarCmd.Flags().String("calendar-file", "", "Read the events from a JSON file instead of Google Calendar")
+	appointmentsReconcileCalendarCmd.Flags().String("format", "table", "Output format (table, json)")
+}
+
+// loadCalendarAndAppointments returns the appointments and calendar events
+// between two dates, along with the appointments of the events and the events
+// of the appointments that lie outside them
+func loadCalendarAndAppointments(ctx context.Context, client calendar.Client, service *services.AppointmentService, c *clinic.Config, from, to time.Time) ([]models.Appointment, []calendar.Event, error) {
+	events, err := client.Events(ctx, c.At(from, 0), c.At(to.AddDate(0, 0, 1), 0))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appointments, err := service.GetAppointments(models.AppointmentFilter{From: &from, To: &to})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Appointments of the events, which may have been moved out of the range
+	var eventIDs, ids []string
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.ID)
+		if id := event.AppointmentID(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(eventIDs) > 0 {
+		linked, err := service.GetAppointmentsForEvents(eventIDs, ids)
+		if err != nil {
+			return nil, nil, err
+		}
+		seen := make(map[string]bool, len(appointments))
+		for _, a := range appointments {
+			seen[a.ID] = true
+		}
+		for _, a := range linked {
+			if !seen[a.ID] {
+				appointments = append(appointments, a)
+			}
+		}
+	}
+
+	// Events of the appointments, which may have been moved out of the range
+	found := make(map[string]bool, len(events))
+	for _, event := range events {
+		found[event.ID] = true
+	}
+	for _, a := range appointments {
+		if a.GoogleEventID == "" || found[a.GoogleEventID] {
+			continue
+		}
+		event, err := client.Event(ctx, a.GoogleEventID)
+		if errors.Is(err, calendar.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		found[event.ID] = true
+		events = append(events, *event)
+	}
+
+	return appointments, events, nil
+}
+
+// applyCalendarFix updates the appointment of a finding to match the calendar
+func applyCalendarFix(service *services.AppointmentService, c *clinic.Config, finding calendar.Finding, by string) error {
+	a := finding.Appointment
+	switch finding.Fix {
+	case calendar.FixLink:
+		return service.LinkCalendarEvent(a.ID, finding.Event.ID)
+	case calendar.FixCancel:
+		reason := "Calendar event not found"
+		if finding.Kind == calendar.FindingDeletedEvent {
+			reason = "Calendar event deleted"
+		}
+		_, err := service.ChangeStatus(a.ID, models.AppointmentStatusFormData{
+			Action: models.AppointmentCancel,
+			Actor:  by,
+			Reason: reason,
+		}, c.Location)
+		return err
+	case calendar.FixReschedule:
+		start := finding.Event.Start.In(c.Location)
+		date := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		duration := int(finding.Event.End.Sub(finding.Event.Start).Minutes())
+		return service.Reschedule(a.ID, date, start.Format("15:04"), duration, by, "Calendar event at another time")
+	default:
+		return fmt.Errorf("unknown fix '%s'", finding.Fix)
+	}
+}
+
+func outputFindings(c *clinic.Config, from, to time.Time, findings []calendar.Finding, fix, confirm bool, fixed int) error {
+	if len(findings) == 0 {
+		fmt.Printf("✅ Appointments and calendar agree between %s and %s.\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WHEN\tKIND\tPATIENT\tAPPOINTMENT\tDETAIL\tFIX")
+
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.Kind]++
+
+		var when, patient, appointmentID string
+		if f.Appointment != nil {
+			when = f.Appointment.Start(c.Location).Format("2006-01-02 15:04")
+			patient = f.Appointment.PatientName
+			appointmentID = f.Appointment.ID
+		} else {
+			when = f.Event.Start.In(c.Location).Format("2006-01-02 15:04")
+			patient = f.Event.PatientName()
+		}
+		fixName := f.Fix
+		if fixName == "" {
+			fixName = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			when,
+			f.Kind,
+			truncateString(patient, 25),
+			appointmentID,
+			f.Detail,
+			fixName,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	var summary []string
+	for _, kind := range []string{
+		calendar.FindingOrphanEvent,
+		calendar.FindingUnlinkedEvent,
+		calendar.FindingNoEvent,
+		calendar.FindingMissingEvent,
+		calendar.FindingDeletedEvent,
+		calendar.FindingActiveEvent,
+		calendar.FindingTimeMismatch,
+	} {
+		if counts[kind] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	fmt.Printf("\n🔍 %d finding(s): %s\n", len(findings), strings.Join(summary, ", "))
+
+	if fix && confirm {
+		fmt.Printf("✅ Fixed %d appointment(s)\n", fixed)
+		return nil
+	}
+
+	fixes := make(map[string]int)
+	fixable := 0
+	for _, f := range findings {
+		if f.Fix != "" {
+			fixes[f.Fix]++
+			fixable++
+		}
+	}
+	switch {
+	case fixable == 0:
+	case fix:
+		fmt.Printf("📝 %d fix(es) to apply: %d link, %d cancel, %d reschedule\n", fixable,
+			fixes[calendar.FixLink], fixes[calendar.FixCancel], fixes[calendar.FixReschedule])
+		fmt.Printf("\nTo apply these fixes, use: --confirm flag\n")
+	default:
+		fmt.Printf("📝 %d can be fixed in the database with --fix\n", fixable)
+	}
+	return nil
+}
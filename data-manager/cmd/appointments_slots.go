@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"fisio-data-manager/internal/availability"
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var appointmentsSlotsCmd = &cobra.Command{
+	Use:   "slots",
+	Short: "List the slots patients can book",
+	Long: `List the free appointment slots between two dates, as the booking page
+would offer them.
+
+Slots come from the booking rules: weekly working hours, breaks, the session
+length and the step between slot starts, a buffer kept free around booked
+appointments, a minimum notice and how many days ahead patients can book.
+The default rules match the booking page (hour-long sessions on the hour,
+Monday to Friday during the clinic's opening hours, up to 30 days ahead).
+Appointments that are not cancelled and closures (see the closures
+command) take up their time.
+
+The rules can be changed with a JSON file; run with --print-rules to get the
+defaults as a starting point. Working hours given in the file replace the
+whole default week.
+
+Examples:
+  fisio-data-manager appointments slots
+  fisio-data-manager appointments slots --from tomorrow --to 2026-10-30
+  fisio-data-manager appointments slots --rules booking-rules.json --format json
+  fisio-data-manager appointments slots --print-rules > booking-rules.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+
+		rules := availability.DefaultRules(clinicConfig)
+		if path, _ := cmd.Flags().GetString("rules"); path != "" {
+			rules, err = availability.LoadRules(path, rules)
+			if err != nil {
+				return err
+			}
+		}
+		if duration, _ := cmd.Flags().GetInt("duration"); duration > 0 {
+			rules.SessionMinutes = duration
+		}
+
+		if printRules, _ := cmd.Flags().GetBool("print-rules"); printRules {
+			return outputJSON(rules)
+		}
+
+		now := time.Now()
+		value, _ := cmd.Flags().GetString("from")
+		from, err := clinicConfig.ParseDate(value, now)
+		if err != nil {
+			return err
+		}
+		to := from.AddDate(0, 0, 6)
+		if value, _ := cmd.Flags().GetString("to"); value != "" {
+			if to, err = clinicConfig.ParseDate(value, now); err != nil {
+				return err
+			}
+		}
+		if to.Before(from) {
+			return fmt.Errorf("--to must not be before --from")
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		// The day before and after, for buffers reaching over midnight
+		dayBefore, dayAfter := from.AddDate(0, 0, -1), to.AddDate(0, 0, 1)
+		appointments, err := services.NewAppointmentService(db).GetAppointments(models.AppointmentFilter{
+			From: &dayBefore,
+			To:   &dayAfter,
+		})
+		if err != nil {
+			return err
+		}
+
+		closures, err := services.NewClosureService(db).GetClosures(&from, &to)
+		if err != nil {
+			return err
+		}
+
+		slots := rules.Slots(clinicConfig, from, to, now,
+			availability.Booked(clinicConfig, appointments),
+			availability.Closed(clinicConfig, closures, from, to))
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(slots)
+		default:
+			return outputSlots(clinicConfig, from, to, slots)
+		}
+	},
+}
+
+func init() {
+	appointmentsCmd.AddCommand(appointmentsSlotsCmd)
+
+	appointmentsSlotsCmd.Flags().String("from", "today", "First date: today, tomorrow or YYYY-MM-DD")
+	appointmentsSlotsCmd.Flags().String("to", "", "Last date: today, tomorrow or YYYY-MM-DD (default: a week from --from)")
+	appointmentsSlotsCmd.Flags().Int("duration", 0, "Session length in minutes (default: from the rules)")
+	appointmentsSlotsCmd.Flags().String("rules", "", "JSON file with booking rules (default: built-in rules)")
+	appointmentsSlotsCmd.Flags().Bool("print-rules", false, "Print the booking rules as JSON and exit")
+	appointmentsSlotsCmd.Flags().String("format", "table", "Output format (table, json)")
+}
+
+func outputSlots(c *clinic.Config, from, to time.Time, slots []availability.Slot) error {
+	if len(slots) == 0 {
+		fmt.Printf("No free slots between %s and %s.\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tSLOTS\tSTART TIMES")
+
+	for i := 0; i < len(slots); {
+		date := slots[i].Start.Format("Mon 2006-01-02")
+		var starts []string
+		for ; i < len(slots) && slots[i].Start.Format("Mon 2006-01-02") == date; i++ {
+			starts = append(starts, slots[i].Start.Format("15:04"))
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\n", date, len(starts), strings.Join(starts, " "))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n📅 %d free slot(s) (%s)\n", len(slots), c.Location)
+	return nil
+}
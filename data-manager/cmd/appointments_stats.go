@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"fisio-data-manager/internal/analytics"
+	"fisio-data-manager/internal/availability"
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var appointmentsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Report utilization, cancellations and no-shows",
+	Long: `Report on the appointments between two dates: bookings, completed
+sessions, cancellation and no-show rates, booked against available hours
+(utilization), the lead time between booking and appointment, and the top
+cancellation reasons.
+
+--by splits the report by week (named after its Monday), month, weekday or
+hour of the day, next to the totals.
+
+The cancellation rate is over all bookings; the no-show rate is over the
+sessions that were due (completed and no-shows). Available hours are the
+working hours of the booking rules (see appointments slots --rules) without
+breaks and closures; utilization can exceed 100% when appointments are
+booked outside them.
+
+CSV output holds one table per --section: the periods (default), the lead
+times (median, average and buckets) or the top cancellation reasons.
+
+Examples:
+  fisio-data-manager appointments stats
+  fisio-data-manager appointments stats --from 2026-09-01 --to 2026-09-30 --by week
+  fisio-data-manager appointments stats --from 2026-01-01 --by month --format csv > 2026.csv
+  fisio-data-manager appointments stats --format csv --section reasons --top 10 > reasons.csv
+  fisio-data-manager appointments stats --by hour --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, _ := cmd.Flags().GetString("by")
+		if err := analytics.ValidGrouping(by); err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString("format")
+		section, _ := cmd.Flags().GetString("section")
+		if _, ok := statsSections[section]; !ok {
+			return fmt.Errorf("unknown section '%s': must be 'periods', 'lead-times' or 'reasons'", section)
+		}
+		if cmd.Flags().Changed("section") && format != "csv" {
+			return fmt.Errorf("--section only applies to --format csv; table and JSON show every section")
+		}
+		top, _ := cmd.Flags().GetInt("top")
+
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+
+		rules := availability.DefaultRules(clinicConfig)
+		if path, _ := cmd.Flags().GetString("rules"); path != "" {
+			rules, err = availability.LoadRules(path, rules)
+			if err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		value, _ := cmd.Flags().GetString("to")
+		to, err := clinicConfig.ParseDate(value, now)
+		if err != nil {
+			return err
+		}
+		from := to.AddDate(0, 0, -29)
+		if value, _ := cmd.Flags().GetString("from"); value != "" {
+			if from, err = clinicConfig.ParseDate(value, now); err != nil {
+				return err
+			}
+		}
+		if to.Before(from) {
+			return fmt.Errorf("--to must not be before --from")
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		appointments, err := services.NewAppointmentService(db).GetAppointments(models.AppointmentFilter{
+			From: &from,
+			To:   &to,
+		})
+		if err != nil {
+			return err
+		}
+
+		closures, err := services.NewClosureService(db).GetClosures(&from, &to)
+		if err != nil {
+			return err
+		}
+
+		report, err := analytics.Build(clinicConfig, &rules, from, to, by, appointments,
+			availability.Closed(clinicConfig, closures, from, to), top)
+		if err != nil {
+			return err
+		}
+
+		switch format {
+		case "json":
+			return outputJSON(report)
+		case "csv":
+			return outputStatsCSV(report, section)
+		default:
+			return outputStatsTable(report)
+		}
+	},
+}
+
+func init() {
+	appointmentsCmd.AddCommand(appointmentsStatsCmd)
+
+	appointmentsStatsCmd.Flags().String("from", "", "First date: today, tomorrow or YYYY-MM-DD (default: 29 days before --to)")
+	appointmentsStatsCmd.Flags().String("to", "today", "Last date: today, tomorrow or YYYY-MM-DD")
+	appointmentsStatsCmd.Flags().String("by", "", "Split by week, month, weekday or hour")
+	appointmentsStatsCmd.Flags().String("rules", "", "JSON file with booking rules, for the available hours (default: built-in rules)")
+	appointmentsStatsCmd.Flags().Int("top", 5, "Number of cancellation reasons to show")
+	appointmentsStatsCmd.Flags().String("format", "table", "Output format (table, json, csv)")
+	appointmentsStatsCmd.Flags().String("section", "periods", "Table to write as CSV (periods, lead-times, reasons)")
+}
+
+func outputStatsTable(report *analytics.Report) error {
+	title := fmt.Sprintf("%s to %s", report.From.Format("2006-01-02"), report.To.Format("2006-01-02"))
+	if report.By != "" {
+		title += " by " + report.By
+	}
+	fmt.Printf("📊 APPOINTMENTS %s\n\n", title)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PERIOD\tBOOKINGS\tCOMPLETED\tCANCELLED\tNO-SHOWS\tUPCOMING\tCANCEL %\tNO-SHOW %\tBOOKED H\tAVAILABLE H\tUTILIZATION\tLEAD DAYS")
+	for _, row := range append(report.Rows, report.Total) {
+		period := row.Period
+		if period == "total" {
+			period = "TOTAL"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\t%.1f\t%.1f\t%s\t%.1f\n",
+			period,
+			row.Bookings,
+			row.Completed,
+			row.Cancelled,
+			row.NoShows,
+			row.Upcoming,
+			formatPercent(row.CancellationRate),
+			formatPercent(row.NoShowRate),
+			row.BookedHours,
+			row.AvailableHours,
+			formatPercent(row.Utilization),
+			row.MedianLeadDays,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	lead := report.LeadTimes
+	fmt.Printf("\n🕓 LEAD TIME: median %.1f days, average %.1f days\n", lead.MedianDays, lead.AverageDays)
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, bucket := range lead.Buckets {
+		fmt.Fprintf(w, "   %s\t%d\n", bucket.Label, bucket.Count)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(report.CancellationReasons) > 0 {
+		fmt.Printf("\n❌ TOP CANCELLATION REASONS:\n")
+		for _, reason := range report.CancellationReasons {
+			fmt.Printf("- %s (%d)\n", reason.Label, reason.Count)
+		}
+	}
+
+	return nil
+}
+
+// statsSections are the tables of the report written as CSV, by --section
+var statsSections = map[string]func(*analytics.Report) [][]string{
+	"periods":    statsPeriodRecords,
+	"lead-times": statsLeadTimeRecords,
+	"reasons":    statsReasonRecords,
+}
+
+func outputStatsCSV(report *analytics.Report, section string) error {
+	writer := csv.NewWriter(os.Stdout)
+	return writer.WriteAll(statsSections[section](report))
+}
+
+// statsPeriodRecords returns the periods of the report and the totals
+func statsPeriodRecords(report *analytics.Report) [][]string {
+	records := [][]string{{"Period", "Bookings", "Completed", "Cancelled", "No-shows", "Upcoming",
+		"Cancellation rate", "No-show rate", "Booked hours", "Available hours", "Utilization", "Median lead days"}}
+	for _, row := range append(report.Rows, report.Total) {
+		records = append(records, []string{
+			row.Period,
+			strconv.Itoa(row.Bookings),
+			strconv.Itoa(row.Completed),
+			strconv.Itoa(row.Cancelled),
+			strconv.Itoa(row.NoShows),
+			strconv.Itoa(row.Upcoming),
+			strconv.FormatFloat(row.CancellationRate, 'f', 4, 64),
+			strconv.FormatFloat(row.NoShowRate, 'f', 4, 64),
+			strconv.FormatFloat(row.BookedHours, 'f', 2, 64),
+			strconv.FormatFloat(row.AvailableHours, 'f', 2, 64),
+			strconv.FormatFloat(row.Utilization, 'f', 4, 64),
+			strconv.FormatFloat(row.MedianLeadDays, 'f', 1, 64),
+		})
+	}
+	return records
+}
+
+// statsLeadTimeRecords returns the median and average lead times, then the
+// number of appointments in each lead time bucket
+func statsLeadTimeRecords(report *analytics.Report) [][]string {
+	lead := report.LeadTimes
+	records := [][]string{
+		{"Lead time", "Value"},
+		{"median days", strconv.FormatFloat(lead.MedianDays, 'f', 1, 64)},
+		{"average days", strconv.FormatFloat(lead.AverageDays, 'f', 1, 64)},
+	}
+	for _, bucket := range lead.Buckets {
+		records = append(records, []string{bucket.Label, strconv.Itoa(bucket.Count)})
+	}
+	return records
+}
+
+// statsReasonRecords returns the top cancellation reasons
+func statsReasonRecords(report *analytics.Report) [][]string {
+	records := [][]string{{"Cancellation reason", "Cancellations"}}
+	for _, reason := range report.CancellationReasons {
+		records = append(records, []string{reason.Label, strconv.Itoa(reason.Count)})
+	}
+	return records
+}
+
+// formatPercent formats a rate as a percentage
+func formatPercent(rate float64) string {
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
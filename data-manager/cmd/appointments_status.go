@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// newAppointmentStatusCmd builds the command applying a status action to an
+// appointment
+func newAppointmentStatusCmd(action, use, short, long string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use + " [appointment-id|google-event-id]",
+		Short: short,
+		Long:  long,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			by, _ := cmd.Flags().GetString("by")
+			reason, _ := cmd.Flags().GetString("reason")
+
+			clinicConfig, err := clinic.Load()
+			if err != nil {
+				return err
+			}
+
+			db, err := database.Connect()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			appointment, err := services.NewAppointmentService(db).ChangeStatus(args[0], models.AppointmentStatusFormData{
+				Action: action,
+				Actor:  by,
+				Reason: reason,
+			}, clinicConfig.Location)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✅ Appointment of %s on %s at %s is now %s\n",
+				appointment.PatientName,
+				appointment.AppointmentDate.Format("2006-01-02"),
+				appointment.AppointmentTime,
+				appointment.Status,
+			)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("by", "", "Staff member making the change")
+	if action == models.AppointmentCancel {
+		cmd.Flags().String("reason", "", "Reason for cancelling (required)")
+		cmd.MarkFlagRequired("reason")
+	} else {
+		cmd.Flags().String("reason", "", "Note kept in the status history")
+	}
+
+	return cmd
+}
+
+var appointmentsConfirmCmd = newAppointmentStatusCmd(models.AppointmentConfirm, "confirm",
+	"Confirm a scheduled appointment",
+	`Confirm a scheduled appointment, e.g. after the patient answered the reminder.
+
+Example:
+  fisio-data-manager appointments confirm <appointment-id> --by "Front desk"`)
+
+var appointmentsCompleteCmd = newAppointmentStatusCmd(models.AppointmentComplete, "complete",
+	"Mark an appointment as completed",
+	`Mark a scheduled or confirmed appointment as completed, once it has started.
+
+Example:
+  fisio-data-manager appointments complete <appointment-id> --by "Dr. Silva"`)
+
+var appointmentsNoShowCmd = newAppointmentStatusCmd(models.AppointmentMarkNoShow, "no-show",
+	"Mark an appointment as a no-show",
+	`Mark a scheduled or confirmed appointment as a no-show, when the patient did
+not attend. The appointment must have started.
+
+Example:
+  fisio-data-manager appointments no-show <appointment-id> --by "Front desk"`)
+
+var appointmentsCancelCmd = newAppointmentStatusCmd(models.AppointmentCancel, "cancel",
+	"Cancel an appointment",
+	`Cancel a scheduled or confirmed appointment with the reason. The reason and
+time of cancellation are recorded with the status.
+
+The Google Calendar event is not changed; remove it from the calendar too.
+
+Example:
+  fisio-data-manager appointments cancel <appointment-id> --reason "Patient is unwell" --by "Front desk"`)
+
+func init() {
+	appointmentsCmd.AddCommand(appointmentsConfirmCmd)
+	appointmentsCmd.AddCommand(appointmentsCompleteCmd)
+	appointmentsCmd.AddCommand(appointmentsNoShowCmd)
+	appointmentsCmd.AddCommand(appointmentsCancelCmd)
+}
@@ -0,0 +1,508 @@
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/ics"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var closuresCmd = &cobra.Command{
+	Use:   "closures",
+	Short: "Manage clinic closures, holidays and blocked hours",
+	Long: `Commands for the days and hours the clinic is closed.
+
+A closure is one of:
+  holiday   a public holiday (whole days)
+  closure   a clinic-wide closure, such as a week off (whole days)
+  blocked   hours blocked on one or more days, such as a training afternoon
+
+Closures take their time out of the bookable slots (appointments slots).
+Dates and times are those of the clinic, in its time zone.`,
+}
+
+var closuresListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List closures",
+	Long: `List the closures overlapping a date range, from today on by default.
+
+Examples:
+  fisio-data-manager closures list
+  fisio-data-manager closures list --from 2026-01-01 --to 2026-12-31 --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+		from, to, err := closureRange(cmd, clinicConfig)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		closures, err := services.NewClosureService(db).GetClosures(from, to)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(closures)
+		default:
+			return outputClosuresTable(closures)
+		}
+	},
+}
+
+var closuresAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a closure",
+	Long: `Add a holiday, a clinic-wide closure or blocked hours, and list the
+scheduled and confirmed appointments falling within it.
+
+Without --start and --end the closure covers whole days (kind closure by
+default); with them, the hours are blocked on each day (kind blocked).
+
+Examples:
+  fisio-data-manager closures add --date 2026-12-24 --until 2026-12-31 --reason "Winter break"
+  fisio-data-manager closures add --date 2026-11-26 --kind holiday --reason "Thanksgiving"
+  fisio-data-manager closures add --date 2026-10-23 --start 13:00 --end 17:00 --reason "Staff training"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+
+		value, _ := cmd.Flags().GetString("date")
+		if value == "" {
+			return fmt.Errorf("--date is required")
+		}
+		now := time.Now()
+		data := models.ClosureFormData{}
+		if data.StartDate, err = clinicConfig.ParseDate(value, now); err != nil {
+			return err
+		}
+		if value, _ := cmd.Flags().GetString("until"); value != "" {
+			if data.EndDate, err = clinicConfig.ParseDate(value, now); err != nil {
+				return err
+			}
+		}
+		data.StartTime, _ = cmd.Flags().GetString("start")
+		data.EndTime, _ = cmd.Flags().GetString("end")
+		data.Kind, _ = cmd.Flags().GetString("kind")
+		if data.Kind == "" {
+			data.Kind = models.ClosureClinic
+			if data.StartTime != "" || data.EndTime != "" {
+				data.Kind = models.ClosureBlocked
+			}
+		}
+		data.Reason, _ = cmd.Flags().GetString("reason")
+		data.CreatedBy, _ = cmd.Flags().GetString("by")
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewClosureService(db)
+		closure, err := service.AddClosure(data)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Added %s: %s (ID: %s)\n", closure.Kind, closureSpan(closure), closure.ID)
+
+		collisions, err := service.GetCollisions([]models.Closure{*closure}, clinicConfig.Location)
+		if err != nil {
+			return err
+		}
+		return outputCollisions(collisions)
+	},
+}
+
+var closuresDeleteCmd = &cobra.Command{
+	Use:   "delete [closure-id]",
+	Short: "Delete a closure",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confirm, _ := cmd.Flags().GetBool("confirm")
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewClosureService(db)
+		closure, err := service.GetClosure(args[0])
+		if err != nil {
+			return err
+		}
+
+		if !confirm {
+			fmt.Printf("⚠️  This will delete the %s %s (%s)\n", closure.Kind, closureSpan(closure), closure.Reason)
+			fmt.Println("Use --confirm flag to proceed with deletion")
+			return nil
+		}
+
+		if err := service.DeleteClosure(closure.ID); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Deleted %s %s\n", closure.Kind, closureSpan(closure))
+		return nil
+	},
+}
+
+var closuresImportCmd = &cobra.Command{
+	Use:   "import [calendar.ics]",
+	Short: "Import holidays from an iCalendar file",
+	Long: `Import public holidays from an iCalendar (.ics) file, such as the holiday
+calendars published by calendar providers.
+
+All-day events become holidays; events with times become blocked hours.
+Events from today on are imported unless --from is given. Each closure keeps
+the UID of its event, so importing the same calendar again updates the
+closures instead of adding them twice. Cancelled events are skipped.
+
+Recurrence rules are not expanded: a recurring event is only read on its
+first date. The recurring events of the calendar are listed so their other
+dates can be added by hand (or from a calendar listing each date).
+
+The scheduled and confirmed appointments falling within the imported
+closures are listed.
+
+Examples:
+  fisio-data-manager closures import us-holidays.ics --dry-run
+  fisio-data-manager closures import us-holidays.ics --to 2027-12-31`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+		from, to, err := closureRange(cmd, clinicConfig)
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		kind, _ := cmd.Flags().GetString("kind")
+		if err := models.ValidClosureKind(kind); err != nil {
+			return err
+		}
+		createdBy, _ := cmd.Flags().GetString("by")
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open calendar: %w", err)
+		}
+		defer file.Close()
+
+		events, err := ics.Parse(file, clinicConfig.Location)
+		if err != nil {
+			return fmt.Errorf("failed to parse calendar: %w", err)
+		}
+
+		var closures []models.ClosureFormData
+		var recurring []ics.Event
+		skipped := 0
+		for _, event := range events {
+			if event.Recurring && event.Status != "CANCELLED" {
+				recurring = append(recurring, event)
+			}
+			data := closureFromEvent(event, clinicConfig.Location, kind)
+			if event.Status == "CANCELLED" ||
+				(from != nil && data.EndDate.Before(*from)) ||
+				(to != nil && data.StartDate.After(*to)) {
+				skipped++
+				continue
+			}
+			data.CreatedBy = createdBy
+			if err := data.Validate(); err != nil {
+				return fmt.Errorf("event '%s': %w", event.Summary, err)
+			}
+			closures = append(closures, data)
+		}
+
+		fmt.Printf("📅 %d event(s) in %s, %d to import, %d skipped\n", len(events), args[0], len(closures), skipped)
+		if len(recurring) > 0 {
+			fmt.Printf("⚠️  %d recurring event(s) are only read on their first date:\n", len(recurring))
+			for _, event := range recurring {
+				fmt.Printf("   - %s (first on %s)\n", event.Summary, event.Start.In(clinicConfig.Location).Format("2006-01-02"))
+			}
+		}
+
+		if dryRun {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, data := range closures {
+				closure := models.Closure{Kind: data.Kind, StartDate: data.StartDate, EndDate: data.EndDate, StartTime: data.StartTime, EndTime: data.EndTime}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", data.Kind, closureSpan(&closure), data.Reason)
+			}
+			w.Flush()
+			fmt.Println("\n🔍 Dry run: nothing was imported")
+			return nil
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewClosureService(db)
+		var imported []models.Closure
+		created, updated := 0, 0
+		for _, data := range closures {
+			closure, isNew, err := service.ImportClosure(data)
+			if err != nil {
+				return fmt.Errorf("event '%s': %w", data.Reason, err)
+			}
+			if isNew {
+				created++
+			} else {
+				updated++
+			}
+			imported = append(imported, *closure)
+		}
+
+		fmt.Printf("✅ %d closure(s) added, %d updated\n", created, updated)
+
+		collisions, err := service.GetCollisions(imported, clinicConfig.Location)
+		if err != nil {
+			return err
+		}
+		return outputCollisions(collisions)
+	},
+}
+
+var closuresConflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "List appointments falling within closures",
+	Long: `List the scheduled and confirmed appointments falling within closures,
+from today on by default.
+
+Examples:
+  fisio-data-manager closures conflicts
+  fisio-data-manager closures conflicts --from 2026-12-01 --to 2026-12-31 --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clinicConfig, err := clinic.Load()
+		if err != nil {
+			return err
+		}
+		from, to, err := closureRange(cmd, clinicConfig)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewClosureService(db)
+		closures, err := service.GetClosures(from, to)
+		if err != nil {
+			return err
+		}
+
+		collisions, err := service.GetCollisions(closures, clinicConfig.Location)
+		if err != nil {
+			return err
+		}
+
+		if format, _ := cmd.Flags().GetString("format"); format == "json" {
+			return outputJSON(collisions)
+		}
+		if len(collisions) == 0 {
+			fmt.Println("✅ No appointments fall within closures.")
+			return nil
+		}
+		return outputCollisions(collisions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(closuresCmd)
+	closuresCmd.AddCommand(closuresListCmd)
+	closuresCmd.AddCommand(closuresAddCmd)
+	closuresCmd.AddCommand(closuresDeleteCmd)
+	closuresCmd.AddCommand(closuresImportCmd)
+	closuresCmd.AddCommand(closuresConflictsCmd)
+
+	// Commands taking a date range
+	for _, c := range []*cobra.Command{closuresListCmd, closuresImportCmd, closuresConflictsCmd} {
+		c.Flags().String("from", "today", "First date: today, tomorrow or YYYY-MM-DD (empty for no limit)")
+		c.Flags().String("to", "", "Last date: today, tomorrow or YYYY-MM-DD")
+	}
+
+	// List command flags
+	closuresListCmd.Flags().String("format", "table", "Output format (table, json)")
+
+	// Add command flags
+	closuresAddCmd.Flags().String("date", "", "First day of the closure: today, tomorrow or YYYY-MM-DD (required)")
+	closuresAddCmd.Flags().String("until", "", "Last day of the closure (default: --date)")
+	closuresAddCmd.Flags().String("start", "", "Start of the blocked hours (HH:MM)")
+	closuresAddCmd.Flags().String("end", "", "End of the blocked hours (HH:MM)")
+	closuresAddCmd.Flags().String("kind", "", "holiday, closure or blocked (default: closure, or blocked with --start and --end)")
+	closuresAddCmd.Flags().String("reason", "", "Reason for the closure (required)")
+	closuresAddCmd.Flags().String("by", "", "Who added the closure")
+
+	// Delete command flags
+	closuresDeleteCmd.Flags().Bool("confirm", false, "Confirm deletion")
+
+	// Import command flags
+	closuresImportCmd.Flags().String("kind", models.ClosureHoliday, "Kind of the whole-day closures imported")
+	closuresImportCmd.Flags().Bool("dry-run", false, "Show what would be imported without importing")
+	closuresImportCmd.Flags().String("by", "", "Who imported the closures")
+
+	// Conflicts command flags
+	closuresConflictsCmd.Flags().String("format", "table", "Output format (table, json)")
+}
+
+// closureRange reads the --from and --to dates; either may be empty
+func closureRange(cmd *cobra.Command, c *clinic.Config) (*time.Time, *time.Time, error) {
+	now := time.Now()
+	var dates [2]*time.Time
+	for i, name := range []string{"from", "to"} {
+		value, _ := cmd.Flags().GetString(name)
+		if value == "" {
+			continue
+		}
+		date, err := c.ParseDate(value, now)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --%s: %w", name, err)
+		}
+		dates[i] = &date
+	}
+	if dates[0] != nil && dates[1] != nil && dates[1].Before(*dates[0]) {
+		return nil, nil, fmt.Errorf("--to must not be before --from")
+	}
+	return dates[0], dates[1], nil
+}
+
+// closureFromEvent turns a calendar event into a closure: all-day events
+// close whole days, events with times block those hours
+func closureFromEvent(event ics.Event, loc *time.Location, kind string) models.ClosureFormData {
+	data := models.ClosureFormData{Kind: kind, Reason: event.Summary, ICSUID: event.UID}
+	if data.Reason == "" {
+		data.Reason = "Holiday"
+	}
+	if data.ICSUID == "" {
+		// Events should have a UID; without one, the event itself identifies it
+		sum := sha1.Sum([]byte(event.Summary + "|" + event.Start.UTC().Format(time.RFC3339)))
+		data.ICSUID = hex.EncodeToString(sum[:]) + "@fisio-data-manager"
+	}
+
+	start, end := event.Start.In(loc), event.End.In(loc)
+	if !event.AllDay {
+		// Hours within a single day are blocked; longer events close the days
+		// they touch
+		if start.Format("2006-01-02") == end.Format("2006-01-02") && end.After(start) {
+			data.Kind = models.ClosureBlocked
+			data.StartDate = dateOf(start)
+			data.EndDate = data.StartDate
+			data.StartTime, data.EndTime = start.Format("15:04"), end.Format("15:04")
+			return data
+		}
+	}
+
+	data.StartDate = dateOf(start)
+	data.EndDate = dateOf(end.Add(-time.Nanosecond))
+	if data.EndDate.Before(data.StartDate) {
+		data.EndDate = data.StartDate
+	}
+	return data
+}
+
+// dateOf returns the date of a time at midnight UTC, like the dates stored
+// for closures and appointments
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// closureSpan describes the days and hours of a closure
+func closureSpan(c *models.Closure) string {
+	span := c.StartDate.Format("Mon 2006-01-02")
+	if !c.EndDate.Equal(c.StartDate) {
+		span += " to " + c.EndDate.Format("Mon 2006-01-02")
+	}
+	if !c.AllDay() {
+		span += fmt.Sprintf(", %s–%s", c.StartTime, c.EndTime)
+	}
+	return span
+}
+
+func outputClosuresTable(closures []models.Closure) error {
+	if len(closures) == 0 {
+		fmt.Println("No closures found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tKIND\tFROM\tTO\tHOURS\tREASON")
+
+	for _, c := range closures {
+		hours := "all day"
+		if !c.AllDay() {
+			hours = c.StartTime + "–" + c.EndTime
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.ID,
+			c.Kind,
+			c.StartDate.Format("2006-01-02"),
+			c.EndDate.Format("2006-01-02"),
+			hours,
+			truncateString(c.Reason, 40),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n📊 %d closure(s)\n", len(closures))
+	return nil
+}
+
+func outputCollisions(collisions []models.ClosureCollision) error {
+	if len(collisions) == 0 {
+		return nil
+	}
+
+	fmt.Printf("\n⚠️  %d appointment(s) fall within closures:\n\n", len(collisions))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDATE\tTIME\tPATIENT\tEMAIL\tSTATUS\tCLOSURE")
+	for _, c := range collisions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Appointment.ID,
+			c.Appointment.AppointmentDate.Format("2006-01-02"),
+			c.Appointment.AppointmentTime,
+			truncateString(c.Appointment.PatientName, 25),
+			c.Appointment.PatientEmail,
+			c.Appointment.Status,
+			truncateString(c.Closure.Reason, 30),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println("\nReschedule these patients, or cancel with: fisio-data-manager appointments cancel <id> --reason \"...\"")
+	return nil
+}
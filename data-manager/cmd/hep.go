@@ -0,0 +1,464 @@
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var hepCmd = &cobra.Command{
+	Use:   "hep",
+	Short: "Manage home exercise programs assigned to patients",
+	Long: `Commands for assigning exercise videos or programs to patients as their
+home exercise program (HEP).
+
+Patients are identified by email, as in appointments. Dates use the
+YYYY-MM-DD format.`,
+}
+
+var hepAssignCmd = &cobra.Command{
+	Use:   "assign",
+	Short: "Assign a video or program to a patient",
+	Long: `Assign an exercise video or program to a patient.
+
+Single videos take their prescription from the dosage flags. Programs keep
+the prescription of each entry; --frequency applies to the whole program.
+
+Give the patient's conditions with --safe-for (and --weeks-post-surgery after
+surgery): assigning a video contraindicated for them is refused, and videos
+calling for precautions are reported.
+
+Examples:
+  fisio-data-manager hep assign --patient jane@example.com --program "Knee rehab phase 1" --frequency "2x daily"
+  fisio-data-manager hep assign --patient jane@example.com --video <video-id> --sets 3 --reps 10 --end 2026-12-01
+  fisio-data-manager hep assign --patient jane@example.com --program "Core basics" --safe-for pregnancy`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		patient, _ := cmd.Flags().GetString("patient")
+		name, _ := cmd.Flags().GetString("name")
+		program, _ := cmd.Flags().GetString("program")
+		videoID, _ := cmd.Flags().GetString("video")
+		notes, _ := cmd.Flags().GetString("notes")
+		assignedBy, _ := cmd.Flags().GetString("by")
+
+		startDate, err := dateFlag(cmd, "start")
+		if err != nil {
+			return err
+		}
+		if startDate == nil {
+			d := today()
+			startDate = &d
+		}
+		endDate, err := dateFlag(cmd, "end")
+		if err != nil {
+			return err
+		}
+
+		data := models.HEPAssignmentFormData{
+			PatientEmail:   patient,
+			PatientName:    name,
+			VideoID:        videoID,
+			StartDate:      *startDate,
+			EndDate:        endDate,
+			Dosage:         dosageFromFlags(cmd, models.Dosage{}),
+			ClinicianNotes: notes,
+			AssignedBy:     assignedBy,
+		}
+
+		videoIDs := []string{videoID}
+		if program != "" {
+			existing, err := services.NewProgramService(db).GetProgram(program)
+			if err != nil {
+				return err
+			}
+			data.ProgramID = existing.ID
+			videoIDs = videoIDs[:0]
+			for _, item := range existing.Items {
+				videoIDs = append(videoIDs, item.VideoID)
+			}
+		}
+
+		conditions, weeksPostSurgery, err := safetyFlags(cmd)
+		if err != nil {
+			return err
+		}
+		var precautions []string
+		if len(conditions) > 0 || weeksPostSurgery != nil {
+			precautions, err = checkVideoSafety(services.NewVideoService(db), videoIDs, conditions, weeksPostSurgery)
+			if err != nil {
+				return err
+			}
+		}
+
+		service := services.NewHEPService(db)
+		assignment, err := service.Assign(data)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully assigned %s to %s (ID: %s)\n", assignment.Title(), assignment.PatientEmail, assignment.ID)
+		for _, precaution := range precautions {
+			fmt.Printf("⚠️  %s\n", precaution)
+		}
+		return nil
+	},
+}
+
+var hepListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List a patient's assignments",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		patient, _ := cmd.Flags().GetString("patient")
+		includeEnded, _ := cmd.Flags().GetBool("all")
+
+		service := services.NewHEPService(db)
+		assignments, err := service.ListAssignments(patient, includeEnded)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(assignments)
+		default:
+			return outputHEPTable(assignments)
+		}
+	},
+}
+
+var hepEndCmd = &cobra.Command{
+	Use:   "end [assignment-id]",
+	Short: "End an active assignment",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		endDate, err := dateFlag(cmd, "date")
+		if err != nil {
+			return err
+		}
+		if endDate == nil {
+			d := today()
+			endDate = &d
+		}
+		reason, _ := cmd.Flags().GetString("reason")
+
+		service := services.NewHEPService(db)
+		assignment, err := service.EndAssignment(args[0], *endDate, reason)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully ended assignment of %s for %s\n", assignment.Title(), assignment.PatientEmail)
+		return nil
+	},
+}
+
+var hepExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a printable HTML handout for a patient",
+	Long: `Render the patient's active assignments as a printable HTML handout with
+exercise titles, video links and dosage.
+
+Examples:
+  fisio-data-manager hep export --patient jane@example.com --output handout.html
+  fisio-data-manager hep export --patient jane@example.com --date 2026-11-01`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		patient, _ := cmd.Flags().GetString("patient")
+		output, _ := cmd.Flags().GetString("output")
+
+		date, err := dateFlag(cmd, "date")
+		if err != nil {
+			return err
+		}
+		if date == nil {
+			d := today()
+			date = &d
+		}
+
+		service := services.NewHEPService(db)
+		assignments, err := service.GetHandout(patient, *date)
+		if err != nil {
+			return err
+		}
+		if len(assignments) == 0 {
+			return fmt.Errorf("no active assignments for %s on %s", patient, date.Format("2006-01-02"))
+		}
+
+		locale, err := langFlag(cmd)
+		if err != nil {
+			return err
+		}
+		if err := localizeHandout(services.NewTranslationService(db), assignments, locale); err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+			out = file
+		}
+
+		if err := writeHEPHandout(out, assignments, *date, locale); err != nil {
+			return fmt.Errorf("failed to render handout: %w", err)
+		}
+
+		if output != "" {
+			fmt.Printf("✅ Handout for %s written to %s\n", patient, output)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hepCmd)
+
+	hepCmd.AddCommand(hepAssignCmd)
+	hepCmd.AddCommand(hepListCmd)
+	hepCmd.AddCommand(hepEndCmd)
+	hepCmd.AddCommand(hepExportCmd)
+
+	// Assign command flags
+	hepAssignCmd.Flags().String("patient", "", "Patient email (required)")
+	hepAssignCmd.Flags().String("name", "", "Patient name (default: from the latest appointment)")
+	hepAssignCmd.Flags().String("program", "", "Program to assign (ID or name)")
+	hepAssignCmd.Flags().String("video", "", "Video to assign (video ID)")
+	hepAssignCmd.Flags().String("start", "", "Start date (default: today)")
+	hepAssignCmd.Flags().String("end", "", "End date")
+	hepAssignCmd.Flags().String("notes", "", "Clinician notes for the patient")
+	hepAssignCmd.Flags().String("by", "", "Assigning clinician")
+	addDosageFlags(hepAssignCmd)
+	addSafetyFlags(hepAssignCmd)
+	hepAssignCmd.MarkFlagRequired("patient")
+
+	// List command flags
+	hepListCmd.Flags().String("patient", "", "Patient email (required)")
+	hepListCmd.Flags().Bool("all", false, "Include ended assignments")
+	hepListCmd.Flags().String("format", "table", "Output format (table, json)")
+	hepListCmd.MarkFlagRequired("patient")
+
+	// End command flags
+	hepEndCmd.Flags().String("date", "", "End date (default: today)")
+	hepEndCmd.Flags().String("reason", "", "Reason for ending the assignment")
+
+	// Export command flags
+	hepExportCmd.Flags().String("patient", "", "Patient email (required)")
+	hepExportCmd.Flags().String("date", "", "Include assignments active on this date (default: today)")
+	hepExportCmd.Flags().String("output", "", "Output file (default: stdout)")
+	hepExportCmd.Flags().String("lang", "", "Language of the video titles, falling back to en (e.g. pt, es)")
+	hepExportCmd.MarkFlagRequired("patient")
+}
+
+// dateFlag parses an optional YYYY-MM-DD flag
+func dateFlag(cmd *cobra.Command, name string) (*time.Time, error) {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s date '%s' (expected YYYY-MM-DD)", name, value)
+	}
+	return &date, nil
+}
+
+// today returns the current local date at midnight UTC, matching how dates
+// parsed by dateFlag are represented
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// checkVideoSafety fails when any of the videos is contraindicated for the
+// patient's conditions or not allowed yet after surgery, and returns the
+// precautions to take with the others
+func checkVideoSafety(service *services.VideoService, videoIDs []string, conditions []string, weeksPostSurgery *int) ([]string, error) {
+	videos, err := service.GetVideos(models.VideoFilter{IDs: videoIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	var precautions []string
+	for _, video := range videos {
+		if video.TooSoonAfterSurgery(weeksPostSurgery) {
+			return nil, fmt.Errorf("'%s' is not allowed before %d weeks after surgery", video.Title, *video.MinWeeksPostSurgery)
+		}
+		if found := video.ContraindicationsFor(conditions); len(found) > 0 {
+			return nil, fmt.Errorf("'%s' is contraindicated for: %s", video.Title, strings.Join(found, ", "))
+		}
+		if found := video.PrecautionsFor(conditions); len(found) > 0 {
+			precautions = append(precautions, fmt.Sprintf("'%s' calls for precautions with: %s", video.Title, strings.Join(found, ", ")))
+		}
+	}
+
+	return precautions, nil
+}
+
+func outputHEPTable(assignments []models.HEPAssignment) error {
+	if len(assignments) == 0 {
+		fmt.Println("No assignments found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tTITLE\tSTART\tEND\tDOSAGE\tSTATUS")
+
+	for _, assignment := range assignments {
+		kind := "video"
+		if assignment.ProgramID != nil {
+			kind = "program"
+		}
+		end := "-"
+		if assignment.EndDate != nil {
+			end = assignment.EndDate.Format("2006-01-02")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			assignment.ID,
+			kind,
+			truncateString(assignment.Title(), 40),
+			assignment.StartDate.Format("2006-01-02"),
+			end,
+			assignment.Summary(),
+			assignment.Status,
+		)
+	}
+
+	return w.Flush()
+}
+
+var hepHandoutTemplate = template.Must(template.New("handout").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>Home exercise program{{if .PatientName}} – {{.PatientName}}{{end}}</title>
+<style>
+  body { font-family: Arial, Helvetica, sans-serif; max-width: 800px; margin: 2em auto; color: #222; }
+  h1 { font-size: 1.6em; margin-bottom: 0.2em; }
+  h2 { font-size: 1.2em; margin-top: 1.6em; border-bottom: 1px solid #ccc; }
+  .meta { color: #555; }
+  .exercise { margin: 0.8em 0; page-break-inside: avoid; }
+  .dosage { font-weight: bold; }
+  .notes { font-style: italic; }
+  a { color: #1a5fb4; word-break: break-all; }
+  @media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>Home exercise program</h1>
+<p class="meta">{{if .PatientName}}{{.PatientName}} · {{end}}{{.PatientEmail}} · {{.Date}}</p>
+{{range .Assignments}}
+<h2>{{.Title}}</h2>
+<p class="meta">From {{.StartDate.Format "2006-01-02"}}{{if .EndDate}} until {{.EndDate.Format "2006-01-02"}}{{end}}{{if .Frequency}} · {{.Frequency}}{{end}}</p>
+{{if .ClinicianNotes}}<p class="notes">{{.ClinicianNotes}}</p>{{end}}
+{{if .Items}}{{range .Items}}
+<div class="exercise">
+  <div>{{.Position}}. {{.VideoTitle}}</div>
+  {{with .Summary}}<div class="dosage">{{.}}</div>{{end}}
+  {{if .Notes}}<div class="notes">{{.Notes}}</div>{{end}}
+  <div><a href="{{.VideoURL}}">{{.VideoURL}}</a></div>
+</div>
+{{end}}{{else}}
+<div class="exercise">
+  {{with .Summary}}<div class="dosage">{{.}}</div>{{end}}
+  {{if .VideoURL}}<div><a href="{{.VideoURL}}">{{.VideoURL}}</a></div>{{end}}
+</div>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+// writeHEPHandout renders the printable handout for a patient's assignments
+func writeHEPHandout(out io.Writer, assignments []models.HEPAssignment, date time.Time, locale string) error {
+	data := struct {
+		Lang         string
+		PatientEmail string
+		PatientName  string
+		Date         string
+		Assignments  []models.HEPAssignment
+	}{
+		Lang:         locale,
+		PatientEmail: assignments[0].PatientEmail,
+		Date:         date.Format("2006-01-02"),
+		Assignments:  assignments,
+	}
+	for _, assignment := range assignments {
+		if assignment.PatientName != "" {
+			data.PatientName = assignment.PatientName
+			break
+		}
+	}
+
+	return hepHandoutTemplate.Execute(out, data)
+}
+
+// localizeHandout translates the video titles of the assignments
+func localizeHandout(service *services.TranslationService, assignments []models.HEPAssignment, locale string) error {
+	var ids []string
+	for _, assignment := range assignments {
+		if assignment.VideoID != nil {
+			ids = append(ids, *assignment.VideoID)
+		}
+		for _, item := range assignment.Items {
+			ids = append(ids, item.VideoID)
+		}
+	}
+
+	translations, err := service.GetVideoTranslationsIn(locale, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range assignments {
+		assignment := &assignments[i]
+		if assignment.VideoID != nil {
+			if t := translations[*assignment.VideoID]; t.Title != "" {
+				title := t.Title
+				assignment.VideoTitle = &title
+			}
+		}
+		for j := range assignment.Items {
+			if t := translations[assignment.Items[j].VideoID]; t.Title != "" {
+				assignment.Items[j].VideoTitle = t.Title
+			}
+		}
+	}
+
+	return nil
+}
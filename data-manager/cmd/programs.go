@@ -0,0 +1,627 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var programsCmd = &cobra.Command{
+	Use:   "programs",
+	Short: "Manage exercise programs",
+	Long: `Commands for managing exercise programs: named, ordered sequences of
+exercise videos with a prescription (sets, reps, hold, rest and frequency)
+for each entry.
+
+Programs can be referenced by ID or by name.`,
+}
+
+var programsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List exercise programs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		programs, err := service.GetPrograms()
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(programs)
+		default:
+			return outputProgramsTable(programs)
+		}
+	},
+}
+
+var programsShowCmd = &cobra.Command{
+	Use:   "show [program]",
+	Short: "Show a program and its exercises",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		program, err := service.GetProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(program)
+		default:
+			return outputProgramDetails(program)
+		}
+	},
+}
+
+var programsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new exercise program",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+
+		name, _ := cmd.Flags().GetString("name")
+		description, _ := cmd.Flags().GetString("description")
+		weeks, _ := cmd.Flags().GetInt("weeks")
+
+		var weeksPtr *int
+		if weeks > 0 {
+			weeksPtr = &weeks
+		}
+
+		program, err := service.CreateProgram(models.ProgramFormData{
+			Name:          name,
+			Description:   description,
+			DurationWeeks: weeksPtr,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully created program: %s (ID: %s)\n", program.Name, program.ID)
+		return nil
+	},
+}
+
+var programsUpdateCmd = &cobra.Command{
+	Use:   "update [program]",
+	Short: "Update an exercise program",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		existing, err := service.GetProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		data := models.ProgramFormData{
+			Name:          existing.Name,
+			Description:   existing.Description,
+			DurationWeeks: existing.DurationWeeks,
+		}
+		if cmd.Flags().Changed("name") {
+			data.Name, _ = cmd.Flags().GetString("name")
+		}
+		if cmd.Flags().Changed("description") {
+			data.Description, _ = cmd.Flags().GetString("description")
+		}
+		if cmd.Flags().Changed("weeks") {
+			weeks, _ := cmd.Flags().GetInt("weeks")
+			data.DurationWeeks = nil
+			if weeks > 0 {
+				data.DurationWeeks = &weeks
+			}
+		}
+
+		program, err := service.UpdateProgram(existing.ID, data)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully updated program: %s (ID: %s)\n", program.Name, program.ID)
+		return nil
+	},
+}
+
+var programsDeleteCmd = &cobra.Command{
+	Use:   "delete [program]",
+	Short: "Delete an exercise program",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		program, err := service.GetProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		if !confirm {
+			fmt.Printf("⚠️  This will permanently delete program '%s' and its %d exercises.\n", program.Name, program.ItemCount)
+			fmt.Printf("To confirm deletion, use: --confirm flag\n")
+			return nil
+		}
+
+		if err := service.DeleteProgram(program.ID); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully deleted program: %s (ID: %s)\n", program.Name, program.ID)
+		return nil
+	},
+}
+
+var programsAddVideoCmd = &cobra.Command{
+	Use:   "add-video [program] [video-id]",
+	Short: "Add a video to a program",
+	Long: `Add an exercise video to a program with its prescription.
+
+Example:
+  fisio-data-manager programs add-video "Post-op knee" <video-id> \
+    --sets 3 --reps 10 --hold 5 --rest 30 --frequency "2x daily"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		program, err := service.GetProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		position, _ := cmd.Flags().GetInt("position")
+		notes, _ := cmd.Flags().GetString("notes")
+
+		item, err := service.AddItem(program.ID, models.ProgramItemFormData{
+			VideoID:  args[1],
+			Position: position,
+			Dosage:   dosageFromFlags(cmd, models.Dosage{}),
+			Notes:    notes,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Added '%s' to %s at position %d\n", item.VideoTitle, program.Name, item.Position)
+		return nil
+	},
+}
+
+var programsUpdateVideoCmd = &cobra.Command{
+	Use:   "update-video [program] [position]",
+	Short: "Update the prescription of a program entry",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		position, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid position '%s': must be a number", args[1])
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		program, err := service.GetProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		var existing *models.ProgramItem
+		for i := range program.Items {
+			if program.Items[i].Position == position {
+				existing = &program.Items[i]
+			}
+		}
+		if existing == nil {
+			return fmt.Errorf("program has no item at position %d", position)
+		}
+
+		data := models.ProgramItemFormData{
+			VideoID: existing.VideoID,
+			Dosage:  dosageFromFlags(cmd, existing.Dosage),
+			Notes:   existing.Notes,
+		}
+		if cmd.Flags().Changed("video") {
+			data.VideoID, _ = cmd.Flags().GetString("video")
+		}
+		if cmd.Flags().Changed("notes") {
+			data.Notes, _ = cmd.Flags().GetString("notes")
+		}
+
+		item, err := service.UpdateItem(program.ID, position, data)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Updated '%s' in %s (%s)\n", item.VideoTitle, program.Name, item.Summary())
+		return nil
+	},
+}
+
+var programsRemoveVideoCmd = &cobra.Command{
+	Use:   "remove-video [program] [position]",
+	Short: "Remove an entry from a program",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		position, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid position '%s': must be a number", args[1])
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		program, err := service.GetProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := service.RemoveItem(program.ID, position); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Removed position %d from %s\n", position, program.Name)
+		return nil
+	},
+}
+
+var programsMoveCmd = &cobra.Command{
+	Use:   "move [program] [from-position] [to-position]",
+	Short: "Reorder an entry within a program",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid position '%s': must be a number", args[1])
+		}
+		to, err := strconv.Atoi(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid position '%s': must be a number", args[2])
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		program, err := service.GetProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := service.MoveItem(program.ID, from, to); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Moved position %d to %d in %s\n", from, to, program.Name)
+		return nil
+	},
+}
+
+var programsDuplicateCmd = &cobra.Command{
+	Use:   "duplicate [program]",
+	Short: "Copy a program and its exercises under a new name",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		program, err := service.GetProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			name = program.Name + " (copy)"
+		}
+
+		duplicate, err := service.DuplicateProgram(program.ID, name)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully created program: %s (ID: %s) with %d exercises\n", duplicate.Name, duplicate.ID, duplicate.ItemCount)
+		return nil
+	},
+}
+
+var programsExportCmd = &cobra.Command{
+	Use:   "export [program]",
+	Short: "Export a program as JSON or CSV",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewProgramService(db)
+		program, err := service.GetProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		var out io.Writer = os.Stdout
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+			out = file
+		}
+
+		switch format {
+		case "json":
+			data, err := program.ToJSON()
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(out, data); err != nil {
+				return err
+			}
+		case "csv":
+			if err := writeProgramCSV(out, program); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported format '%s': must be 'json' or 'csv'", format)
+		}
+
+		if output != "" {
+			fmt.Printf("✅ Exported program %s to %s\n", program.Name, output)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(programsCmd)
+
+	programsCmd.AddCommand(programsListCmd)
+	programsCmd.AddCommand(programsShowCmd)
+	programsCmd.AddCommand(programsCreateCmd)
+	programsCmd.AddCommand(programsUpdateCmd)
+	programsCmd.AddCommand(programsDeleteCmd)
+	programsCmd.AddCommand(programsAddVideoCmd)
+	programsCmd.AddCommand(programsUpdateVideoCmd)
+	programsCmd.AddCommand(programsRemoveVideoCmd)
+	programsCmd.AddCommand(programsMoveCmd)
+	programsCmd.AddCommand(programsDuplicateCmd)
+	programsCmd.AddCommand(programsExportCmd)
+
+	// List and show command flags
+	programsListCmd.Flags().String("format", "table", "Output format (table, json)")
+	programsShowCmd.Flags().String("format", "table", "Output format (table, json)")
+
+	// Create command flags
+	programsCreateCmd.Flags().String("name", "", "Program name (required)")
+	programsCreateCmd.Flags().String("description", "", "Program description")
+	programsCreateCmd.Flags().Int("weeks", 0, "Program duration in weeks")
+	programsCreateCmd.MarkFlagRequired("name")
+
+	// Update command flags
+	programsUpdateCmd.Flags().String("name", "", "Program name")
+	programsUpdateCmd.Flags().String("description", "", "Program description")
+	programsUpdateCmd.Flags().Int("weeks", 0, "Program duration in weeks (0 clears it)")
+
+	// Delete command flags
+	programsDeleteCmd.Flags().Bool("confirm", false, "Confirm deletion (required)")
+
+	// Add/update video command flags
+	addDosageFlags(programsAddVideoCmd)
+	programsAddVideoCmd.Flags().Int("position", 0, "Position in the program (default: append)")
+	programsAddVideoCmd.Flags().String("notes", "", "Notes for this exercise")
+
+	addDosageFlags(programsUpdateVideoCmd)
+	programsUpdateVideoCmd.Flags().String("video", "", "Replace the video (video ID)")
+	programsUpdateVideoCmd.Flags().String("notes", "", "Notes for this exercise")
+
+	// Duplicate command flags
+	programsDuplicateCmd.Flags().String("name", "", "Name of the copy (default: \"<name> (copy)\")")
+
+	// Export command flags
+	programsExportCmd.Flags().String("format", "json", "Export format (json, csv)")
+	programsExportCmd.Flags().String("output", "", "Output file (default: stdout)")
+}
+
+// addDosageFlags registers the prescription flags on a command
+func addDosageFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("sets", 0, "Number of sets")
+	cmd.Flags().Int("reps", 0, "Repetitions per set")
+	cmd.Flags().Int("hold", 0, "Hold time in seconds")
+	cmd.Flags().Int("rest", 0, "Rest between sets in seconds")
+	cmd.Flags().String("frequency", "", "Frequency (e.g. \"2x daily\", \"3x per week\")")
+}
+
+// dosageFromFlags overrides the given dosage with the flags that were set.
+// Numeric flags set to 0 clear the value.
+func dosageFromFlags(cmd *cobra.Command, dosage models.Dosage) models.Dosage {
+	intFlag := func(name string, target **int) {
+		if !cmd.Flags().Changed(name) {
+			return
+		}
+		value, _ := cmd.Flags().GetInt(name)
+		*target = nil
+		if value > 0 {
+			*target = &value
+		}
+	}
+
+	intFlag("sets", &dosage.Sets)
+	intFlag("reps", &dosage.Reps)
+	intFlag("hold", &dosage.HoldSeconds)
+	intFlag("rest", &dosage.RestSeconds)
+	if cmd.Flags().Changed("frequency") {
+		dosage.Frequency, _ = cmd.Flags().GetString("frequency")
+	}
+
+	return dosage
+}
+
+func outputProgramsTable(programs []models.ExerciseProgram) error {
+	if len(programs) == 0 {
+		fmt.Println("No programs found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tWEEKS\tEXERCISES\tUPDATED")
+
+	for _, program := range programs {
+		weeks := "N/A"
+		if program.DurationWeeks != nil {
+			weeks = strconv.Itoa(*program.DurationWeeks)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+			program.ID,
+			truncateString(program.Name, 40),
+			weeks,
+			program.ItemCount,
+			program.UpdatedAt.Format("2006-01-02"),
+		)
+	}
+
+	return w.Flush()
+}
+
+func outputProgramDetails(program *models.ExerciseProgram) error {
+	fmt.Printf("📋 %s\n", program.Name)
+	if program.Description != "" {
+		fmt.Printf("%s\n", program.Description)
+	}
+	if program.DurationWeeks != nil {
+		fmt.Printf("Duration: %d weeks\n", *program.DurationWeeks)
+	}
+	fmt.Println()
+
+	if len(program.Items) == 0 {
+		fmt.Println("No exercises in this program yet.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tVIDEO\tDOSAGE\tNOTES")
+
+	for _, item := range program.Items {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n",
+			item.Position,
+			truncateString(item.VideoTitle, 40),
+			item.Summary(),
+			truncateString(item.Notes, 40),
+		)
+	}
+
+	return w.Flush()
+}
+
+func writeProgramCSV(out io.Writer, program *models.ExerciseProgram) error {
+	writer := csv.NewWriter(out)
+
+	header := []string{"Position", "Video ID", "Title", "URL", "Sets", "Reps", "Hold (s)", "Rest (s)", "Frequency", "Notes"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, item := range program.Items {
+		record := []string{
+			strconv.Itoa(item.Position),
+			item.VideoID,
+			item.VideoTitle,
+			item.VideoURL,
+			formatOptionalInt(item.Sets),
+			formatOptionalInt(item.Reps),
+			formatOptionalInt(item.HoldSeconds),
+			formatOptionalInt(item.RestSeconds),
+			item.Frequency,
+			item.Notes,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+// outputJSON prints any value as indented JSON
+func outputJSON(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
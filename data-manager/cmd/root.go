@@ -19,15 +19,27 @@ var rootCmd = &cobra.Command{
 	Long: `A comprehensive data management tool for the Online Physiotherapy Platform.
 	
 This tool provides utilities for:
-- Managing exercise videos and categories
+- Managing exercise videos and categories, with translations (en, pt, es)
+- Attaching captions to videos and searching their transcripts
+- Reviewing videos before publishing (draft, in review, approved, published)
+- Scheduling videos to be published and unpublished
+- Generating video sitemaps and structured data for search engines
+- Exporting the public library as static JSON for a CDN
+- Recording contraindications and precautions, and filtering out unsafe videos
+- Viewing and managing patient appointments, with daily and weekly agendas
+- Recording clinic closures and holidays
 - Exporting data for analysis
 - Database seeding and maintenance
 - Batch importing videos from CSV files
+- Maintaining controlled vocabularies for equipment, body parts and tags
+- Building exercise programs (ordered videos with sets, reps and frequency)
+- Assigning home exercise programs to patients and printing handouts
 
 Examples:
   fisio-data-manager videos list
   fisio-data-manager videos add --title "Back Stretch" --url "https://youtube.com/watch?v=abc123"
-  fisio-data-manager videos import videos.csv`,
+  fisio-data-manager videos import videos.csv
+  fisio-data-manager appointments list --from 2026-10-19 --status scheduled,confirmed`,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -44,12 +56,14 @@ func init() {
 	rootCmd.PersistentFlags().String("supabase-url", "", "Supabase project URL")
 	rootCmd.PersistentFlags().String("supabase-key", "", "Supabase service role key")
 	rootCmd.PersistentFlags().Bool("verbose", false, "Enable verbose output")
+	rootCmd.PersistentFlags().String("timezone", "", "Clinic time zone for appointments (default: CLINIC_TIMEZONE or America/New_York)")
 
 	// Bind flags to viper
 	viper.BindPFlag("db_url", rootCmd.PersistentFlags().Lookup("db-url"))
 	viper.BindPFlag("supabase_url", rootCmd.PersistentFlags().Lookup("supabase-url"))
 	viper.BindPFlag("supabase_key", rootCmd.PersistentFlags().Lookup("supabase-key"))
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	viper.BindPFlag("clinic_timezone", rootCmd.PersistentFlags().Lookup("timezone"))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var taxonomyCmd = &cobra.Command{
+	Use:   "taxonomy",
+	Short: "Manage controlled vocabularies for equipment, body parts and tags",
+	Long: `Commands for managing the canonical terms and synonyms used for video
+equipment, body parts and tags.
+
+Kinds: equipment, body_part (or body-parts), tag (or tags).`,
+}
+
+var taxonomyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List taxonomy terms and their synonyms",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewTaxonomyService(db)
+
+		kindValue, _ := cmd.Flags().GetString("kind")
+		format, _ := cmd.Flags().GetString("format")
+
+		var kind models.TaxonomyKind
+		if kindValue != "" {
+			kind, err = models.ParseTaxonomyKind(kindValue)
+			if err != nil {
+				return err
+			}
+		}
+
+		terms, err := service.ListTerms(kind)
+		if err != nil {
+			return err
+		}
+
+		switch format {
+		case "json":
+			data, err := json.MarshalIndent(terms, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		default:
+			return outputTaxonomyTable(terms)
+		}
+	},
+}
+
+var taxonomyAddCmd = &cobra.Command{
+	Use:   "add [kind] [term]",
+	Short: "Add a canonical term",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, err := models.ParseTaxonomyKind(args[0])
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewTaxonomyService(db)
+		term, err := service.AddTerm(kind, args[1])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully added %s term: %s\n", term.Kind, term.Name)
+		return nil
+	},
+}
+
+var taxonomyAliasCmd = &cobra.Command{
+	Use:   "alias [kind] [synonym] [term]",
+	Short: "Register a synonym for a canonical term",
+	Long: `Register a synonym for a canonical term. Values matching the synonym are
+normalized to the term on import, add and update.
+
+Example:
+  fisio-data-manager taxonomy alias equipment "Mat" "Yoga Mat"`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, err := models.ParseTaxonomyKind(args[0])
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewTaxonomyService(db)
+		if err := service.AddAlias(kind, args[1], args[2]); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ '%s' is now a synonym of %s term '%s'\n", args[1], kind, args[2])
+		return nil
+	},
+}
+
+var taxonomyMergeCmd = &cobra.Command{
+	Use:   "merge [kind] [from-term] [into-term]",
+	Short: "Merge a term into another one",
+	Long: `Merge a term into another one. The merged term and its synonyms become
+synonyms of the target term, and every video using them is updated.
+
+Approved or published videos that change are sent back to draft, so the
+change is reviewed before it goes live; give --by.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, err := models.ParseTaxonomyKind(args[0])
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewTaxonomyService(db)
+		by, _ := cmd.Flags().GetString("by")
+		updated, reopened, err := service.MergeTerms(kind, args[1], args[2], by)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Merged %s term '%s' into '%s' (%d videos updated)\n", kind, args[1], args[2], updated)
+		printReopened(reopened)
+		return nil
+	},
+}
+
+var taxonomyRenameCmd = &cobra.Command{
+	Use:   "rename [kind] [term] [new-name]",
+	Short: "Rename a canonical term",
+	Long: `Rename a canonical term. The old name is kept as a synonym and every
+video using it is updated.
+
+Approved or published videos that change are sent back to draft, so the
+change is reviewed before it goes live; give --by. Changing only the case of
+a term changes no video's content.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, err := models.ParseTaxonomyKind(args[0])
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewTaxonomyService(db)
+		by, _ := cmd.Flags().GetString("by")
+		updated, reopened, err := service.RenameTerm(kind, args[1], args[2], by)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Renamed %s term '%s' to '%s' (%d videos updated)\n", kind, args[1], args[2], updated)
+		printReopened(reopened)
+		return nil
+	},
+}
+
+var taxonomyNormalizeCmd = &cobra.Command{
+	Use:   "normalize",
+	Short: "Normalize existing videos to canonical terms",
+	Long: `Rewrite the equipment, body parts and tags of every video to their
+canonical terms, removing duplicates and placeholders such as "None".
+
+Values that are not in the taxonomy are reported and kept, unless
+--drop-unknown is given. Use --dry-run to preview the changes first.
+
+Approved or published videos that change are sent back to draft, so the
+changes are reviewed before they go live; give --by.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewTaxonomyService(db)
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		dropUnknown, _ := cmd.Flags().GetBool("drop-unknown")
+		by, _ := cmd.Flags().GetString("by")
+
+		result, err := service.NormalizeVideos(dryRun, dropUnknown, by)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("📊 NORMALIZATION RESULTS\n")
+		fmt.Printf("=======================\n")
+		fmt.Printf("Videos scanned: %d\n", result.VideosScanned)
+		fmt.Printf("Videos changed: %d\n", result.VideosChanged)
+		fmt.Printf("Back to draft:  %d\n", result.VideosReopened)
+
+		if len(result.Changes) > 0 {
+			fmt.Printf("\n✏️  CHANGES:\n")
+			for _, change := range result.Changes {
+				fmt.Printf("%s [%s]: %s → %s\n",
+					truncateString(change.Title, 40), change.Kind,
+					formatTermList(change.Before), formatTermList(change.After))
+			}
+		}
+
+		if len(result.Unknown) > 0 {
+			fmt.Printf("\n⚠️  UNKNOWN TERMS:\n")
+			for _, unknown := range result.Unknown {
+				fmt.Printf("%s [%s]: %s\n", truncateString(unknown.Title, 40), unknown.Kind, strings.Join(unknown.Unknown, ", "))
+			}
+		}
+
+		if dryRun {
+			fmt.Printf("\n🔍 DRY RUN MODE - No changes were made to the database\n")
+		} else if result.VideosChanged > 0 {
+			fmt.Printf("\n✅ Normalization completed successfully!\n")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(taxonomyCmd)
+
+	taxonomyCmd.AddCommand(taxonomyListCmd)
+	taxonomyCmd.AddCommand(taxonomyAddCmd)
+	taxonomyCmd.AddCommand(taxonomyAliasCmd)
+	taxonomyCmd.AddCommand(taxonomyMergeCmd)
+	taxonomyCmd.AddCommand(taxonomyRenameCmd)
+	taxonomyCmd.AddCommand(taxonomyNormalizeCmd)
+
+	// List command flags
+	taxonomyListCmd.Flags().String("kind", "", "Only list one kind (equipment, body_part, tag)")
+	taxonomyListCmd.Flags().String("format", "table", "Output format (table, json)")
+
+	// Merge and rename command flags
+	taxonomyMergeCmd.Flags().String("by", "", "Physiotherapist editing the videos (required when approved or published videos change)")
+	taxonomyRenameCmd.Flags().String("by", "", "Physiotherapist editing the videos (required when approved or published videos change)")
+
+	// Normalize command flags
+	taxonomyNormalizeCmd.Flags().Bool("dry-run", false, "Preview changes without updating videos")
+	taxonomyNormalizeCmd.Flags().Bool("drop-unknown", false, "Remove values that are not in the taxonomy")
+	taxonomyNormalizeCmd.Flags().String("by", "", "Physiotherapist editing the videos (required when approved or published videos change)")
+}
+
+func outputTaxonomyTable(terms []models.TaxonomyTerm) error {
+	if len(terms) == 0 {
+		fmt.Println("No taxonomy terms found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tTERM\tSYNONYMS")
+
+	for _, term := range terms {
+		fmt.Fprintf(w, "%s\t%s\t%s\n",
+			term.Kind,
+			term.Name,
+			strings.Join(term.Synonyms, ", "),
+		)
+	}
+
+	return w.Flush()
+}
+
+func formatTermList(values []string) string {
+	if len(values) == 0 {
+		return "(none)"
+	}
+	return strings.Join(values, "; ")
+}
+
+// printReopened tells how many reviewed videos a change sent back to draft
+func printReopened(reopened int) {
+	if reopened > 0 {
+		fmt.Printf("⚠️  %d approved or published video(s) are back to draft and need reviewing again\n", reopened)
+	}
+}
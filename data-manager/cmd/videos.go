@@ -24,7 +24,13 @@ var videosCmd = &cobra.Command{
 var videosListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List exercise videos",
-	Long:  `List all exercise videos with optional filtering by category and difficulty.`,
+	Long: `List all exercise videos with optional filtering by category and difficulty.
+
+Several categories can be given (IDs or names); by default videos in any of
+them are listed, use --all-categories to require every category.
+
+Use --safe-for to leave out videos contraindicated for a patient's
+conditions, and --weeks-post-surgery for videos not allowed yet after surgery.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		db, err := database.Connect()
 		if err != nil {
@@ -34,15 +40,51 @@ var videosListCmd = &cobra.Command{
 
 		service := services.NewVideoService(db)
 		
-		categoryID, _ := cmd.Flags().GetString("category")
+		categories, _ := cmd.Flags().GetStringSlice("category")
+		allCategories, _ := cmd.Flags().GetBool("all-categories")
 		difficulty, _ := cmd.Flags().GetString("difficulty")
 		format, _ := cmd.Flags().GetString("format")
-		
-		videos, err := service.GetVideos(categoryID, difficulty)
+
+		var statuses []string
+		if status, _ := cmd.Flags().GetString("status"); status != "" {
+			parsed, err := models.ParseVideoStatus(status)
+			if err != nil {
+				return err
+			}
+			statuses = []string{parsed}
+		}
+
+		locale, err := langFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		categoryIDs, err := resolveCategoryIDs(service, categories)
+		if err != nil {
+			return err
+		}
+
+		conditions, weeksPostSurgery, err := safetyFlags(cmd)
 		if err != nil {
 			return err
 		}
 
+		videos, err := service.GetVideos(models.VideoFilter{
+			CategoryIDs:        categoryIDs,
+			MatchAllCategories: allCategories,
+			Difficulty:         difficulty,
+			SafeFor:            conditions,
+			WeeksPostSurgery:   weeksPostSurgery,
+			Statuses:           statuses,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := services.NewTranslationService(db).LocalizeVideos(videos, locale); err != nil {
+			return err
+		}
+
 		switch format {
 		case "json":
 			return outputVideosJSON(videos)
@@ -70,27 +112,62 @@ var videosAddCmd = &cobra.Command{
 		title, _ := cmd.Flags().GetString("title")
 		description, _ := cmd.Flags().GetString("description")
 		url, _ := cmd.Flags().GetString("url")
-		categoryID, _ := cmd.Flags().GetString("category-id")
+		provider, _ := cmd.Flags().GetString("provider")
+		thumbnail, _ := cmd.Flags().GetString("thumbnail")
+		categories, _ := cmd.Flags().GetStringSlice("category-id")
 		difficulty, _ := cmd.Flags().GetString("difficulty")
 		duration, _ := cmd.Flags().GetInt("duration")
 		equipment, _ := cmd.Flags().GetStringSlice("equipment")
 		bodyParts, _ := cmd.Flags().GetStringSlice("body-parts")
 		tags, _ := cmd.Flags().GetStringSlice("tags")
+		contraindications, _ := cmd.Flags().GetStringSlice("contraindications")
+		precautions, _ := cmd.Flags().GetStringSlice("precautions")
+		strict, _ := cmd.Flags().GetBool("strict")
+		service.SetStrictTaxonomy(strict)
 		var durationPtr *int
 		if duration > 0 {
 			durationPtr = &duration
 		}
+		var minWeeksPtr *int
+		if cmd.Flags().Changed("min-weeks-post-surgery") {
+			minWeeks, _ := cmd.Flags().GetInt("min-weeks-post-surgery")
+			minWeeksPtr = &minWeeks
+		}
+		publishAt, err := scheduleFlag(cmd, "publish-at")
+		if err != nil {
+			return err
+		}
+		expireAt, err := scheduleFlag(cmd, "expire-at")
+		if err != nil {
+			return err
+		}
+
+		categoryIDs, err := resolveCategoryIDs(service, categories)
+		if err != nil {
+			return err
+		}
+		if len(categoryIDs) == 0 {
+			return fmt.Errorf("category ID is required")
+		}
 
 		videoData := models.VideoFormData{
-			Title:             title,
-			Description:       description,
-			YoutubeURL:        url,
-			CategoryID:        categoryID,
-			Duration:          durationPtr,
-			DifficultyLevel:   difficulty,
-			EquipmentRequired: equipment,
-			BodyParts:         bodyParts,
-			Tags:              tags,
+			Title:               title,
+			Description:         description,
+			VideoURL:            url,
+			Provider:            provider,
+			ThumbnailURL:        thumbnail,
+			CategoryID:          categoryIDs[0],
+			CategoryIDs:         categoryIDs[1:],
+			Duration:            durationPtr,
+			DifficultyLevel:     difficulty,
+			EquipmentRequired:   equipment,
+			BodyParts:           bodyParts,
+			Tags:                tags,
+			Contraindications:   contraindications,
+			Precautions:         precautions,
+			MinWeeksPostSurgery: minWeeksPtr,
+			PublishAt:           publishAt,
+			ExpireAt:            expireAt,
 		}
 
 		video, err := service.CreateVideo(videoData)
@@ -99,6 +176,7 @@ var videosAddCmd = &cobra.Command{
 		}
 
 		fmt.Printf("✅ Successfully created video: %s (ID: %s)\n", video.Title, video.ID)
+		fmt.Printf("   Saved as a draft; submit it for review with: videos submit %s --by <name>\n", video.ID)
 		return nil
 	},
 }
@@ -106,8 +184,12 @@ var videosAddCmd = &cobra.Command{
 var videosUpdateCmd = &cobra.Command{
 	Use:   "update [video-id]",
 	Short: "Update an existing exercise video",
-	Long:  `Update an existing exercise video in the database.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Update an existing exercise video in the database.
+
+Changing the content of an approved or published video sends it back to
+draft, to be submitted and approved again; --by records who edited it in the
+review log. Changing only the publishing window keeps its status.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		db, err := database.Connect()
 		if err != nil {
@@ -128,12 +210,22 @@ var videosUpdateCmd = &cobra.Command{
 		title, _ := cmd.Flags().GetString("title")
 		description, _ := cmd.Flags().GetString("description")
 		url, _ := cmd.Flags().GetString("url")
-		categoryID, _ := cmd.Flags().GetString("category-id")
+		provider, _ := cmd.Flags().GetString("provider")
+		thumbnail, _ := cmd.Flags().GetString("thumbnail")
+		categories, _ := cmd.Flags().GetStringSlice("category-id")
 		difficulty, _ := cmd.Flags().GetString("difficulty")
 		duration, _ := cmd.Flags().GetInt("duration")
 		equipment, _ := cmd.Flags().GetStringSlice("equipment")
 		bodyParts, _ := cmd.Flags().GetStringSlice("body-parts")
 		tags, _ := cmd.Flags().GetStringSlice("tags")
+		strict, _ := cmd.Flags().GetBool("strict")
+		service.SetStrictTaxonomy(strict)
+
+		categoryIDs, err := resolveCategoryIDs(service, categories)
+		if err != nil {
+			return err
+		}
+
 		// Use existing values if not provided
 		if title == "" {
 			title = existing.Title
@@ -142,10 +234,17 @@ var videosUpdateCmd = &cobra.Command{
 			description = existing.Description
 		}
 		if url == "" {
-			url = existing.YoutubeURL
+			// Keep the video, and its thumbnail unless a new one is given
+			url = existing.VideoURL
+			if provider == "" {
+				provider = existing.Provider
+			}
+			if thumbnail == "" && existing.ThumbnailURL != nil {
+				thumbnail = *existing.ThumbnailURL
+			}
 		}
-		if categoryID == "" {
-			categoryID = existing.CategoryID
+		if len(categoryIDs) == 0 {
+			categoryIDs = append([]string{existing.CategoryID}, existing.CategoryIDs...)
 		}
 		if difficulty == "" {
 			difficulty = existing.DifficultyLevel
@@ -168,24 +267,68 @@ var videosUpdateCmd = &cobra.Command{
 			tags = existing.Tags
 		}
 
+		// Safety information is kept unless given; an empty value clears it
+		contraindications := existing.Contraindications
+		if cmd.Flags().Changed("contraindications") {
+			contraindications, _ = cmd.Flags().GetStringSlice("contraindications")
+		}
+		precautions := existing.Precautions
+		if cmd.Flags().Changed("precautions") {
+			precautions, _ = cmd.Flags().GetStringSlice("precautions")
+		}
+		minWeeksPtr := existing.MinWeeksPostSurgery
+		if cmd.Flags().Changed("min-weeks-post-surgery") {
+			minWeeks, _ := cmd.Flags().GetInt("min-weeks-post-surgery")
+			minWeeksPtr = &minWeeks
+			if minWeeks < 0 {
+				minWeeksPtr = nil
+			}
+		}
+
+		// Publishing window is kept unless given; an empty value clears it
+		publishAt := existing.PublishAt
+		if cmd.Flags().Changed("publish-at") {
+			if publishAt, err = scheduleFlag(cmd, "publish-at"); err != nil {
+				return err
+			}
+		}
+		expireAt := existing.ExpireAt
+		if cmd.Flags().Changed("expire-at") {
+			if expireAt, err = scheduleFlag(cmd, "expire-at"); err != nil {
+				return err
+			}
+		}
+
 		videoData := models.VideoFormData{
-			Title:             title,
-			Description:       description,
-			YoutubeURL:        url,
-			CategoryID:        categoryID,
-			Duration:          durationPtr,
-			DifficultyLevel:   difficulty,
-			EquipmentRequired: equipment,
-			BodyParts:         bodyParts,
-			Tags:              tags,
+			Title:               title,
+			Description:         description,
+			VideoURL:            url,
+			Provider:            provider,
+			ThumbnailURL:        thumbnail,
+			CategoryID:          categoryIDs[0],
+			CategoryIDs:         categoryIDs[1:],
+			Duration:            durationPtr,
+			DifficultyLevel:     difficulty,
+			EquipmentRequired:   equipment,
+			BodyParts:           bodyParts,
+			Tags:                tags,
+			Contraindications:   contraindications,
+			Precautions:         precautions,
+			MinWeeksPostSurgery: minWeeksPtr,
+			PublishAt:           publishAt,
+			ExpireAt:            expireAt,
 		}
 
-		video, err := service.UpdateVideo(videoID, videoData)
+		by, _ := cmd.Flags().GetString("by")
+		video, err := service.UpdateVideo(videoID, videoData, by)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("✅ Successfully updated video: %s (ID: %s)\n", video.Title, video.ID)
+		if video.Status != existing.Status {
+			fmt.Printf("⚠️  The video was %s; it is back to %s and needs reviewing again\n", existing.Status, video.Status)
+		}
 		return nil
 	},
 }
@@ -197,7 +340,7 @@ var videosDeleteCmd = &cobra.Command{
 	
 You can delete by either:
 - Video ID: delete abc123-def456-...
-- YouTube URL: delete https://youtube.com/watch?v=abc123`,
+- Video URL: delete https://youtube.com/watch?v=abc123 (any YouTube, Vimeo or MP4 URL)`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		db, err := database.Connect()
@@ -212,7 +355,8 @@ You can delete by either:
 		byURL, _ := cmd.Flags().GetBool("by-url")
 		
 		// Auto-detect if it's a URL
-		isURL := strings.Contains(identifier, "youtube.com") || strings.Contains(identifier, "youtu.be")
+		isURL := strings.HasPrefix(identifier, "http://") || strings.HasPrefix(identifier, "https://") ||
+			strings.Contains(identifier, "youtube.com") || strings.Contains(identifier, "youtu.be") || strings.Contains(identifier, "vimeo.com")
 		
 		if byURL || isURL {
 			// Delete by URL
@@ -245,12 +389,21 @@ var categoriesListCmd = &cobra.Command{
 		}
 		defer db.Close()
 
+		locale, err := langFlag(cmd)
+		if err != nil {
+			return err
+		}
+
 		service := services.NewVideoService(db)
 		categories, err := service.GetCategories()
 		if err != nil {
 			return err
 		}
 
+		if err := services.NewTranslationService(db).LocalizeCategories(categories, locale); err != nil {
+			return err
+		}
+
 		format, _ := cmd.Flags().GetString("format")
 		
 		switch format {
@@ -434,18 +587,23 @@ CSV Format:
 The CSV file should have the following columns (with header row):
 - title: Video title (required)
 - description: Video description
-- youtube_url: YouTube URL (required)
-- category_name: Category name (will be matched to existing categories)
+- video_url: YouTube, Vimeo or MP4 URL (required; youtube_url is accepted as an alias)
+- provider: Video provider (youtube, vimeo, mp4; detected from the URL when empty)
+- category_name: Category names (semicolon-separated, matched to existing categories; the first is the primary category)
 - difficulty: Difficulty level (beginner, intermediate, advanced)
 - duration: Duration in minutes (optional)
 - equipment: Required equipment (semicolon-separated)
 - body_parts: Target body parts (semicolon-separated)
 - tags: Tags (semicolon-separated)
+- title_<lang>, description_<lang>: Translations (optional, e.g. title_pt, description_es)
+
+Equipment, body parts and tags are normalized to their canonical taxonomy
+terms (see 'taxonomy list'). With --strict, rows using unknown terms fail.
 
 Example CSV content:
-title,description,youtube_url,category_name,difficulty,duration,equipment,body_parts,tags
-"Back Stretch Routine","Gentle stretching for lower back","https://youtube.com/watch?v=abc123","Back & Spine",beginner,10,"Yoga Mat","Back;Core","stretching;back pain"
-"Shoulder Mobility","Improve shoulder range of motion","https://youtube.com/watch?v=def456","Neck & Shoulders",intermediate,15,"None","Shoulders;Arms","mobility;shoulders"`,
+title,description,video_url,category_name,difficulty,duration,equipment,body_parts,tags
+"Back Stretch Routine","Gentle stretching for lower back","https://youtube.com/watch?v=abc123","Back & Spine;Balance & Coordination",beginner,10,"Yoga Mat","Back;Core","stretching;back pain"
+"Shoulder Mobility","Improve shoulder range of motion","https://vimeo.com/76979871","Neck & Shoulders",intermediate,15,"None","Shoulders;Arms","mobility;shoulders"`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		db, err := database.Connect()
@@ -459,7 +617,9 @@ title,description,youtube_url,category_name,difficulty,duration,equipment,body_p
 		
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		skipErrors, _ := cmd.Flags().GetBool("skip-errors")
-		
+		strict, _ := cmd.Flags().GetBool("strict")
+		service.SetStrictTaxonomy(strict)
+
 		result, err := service.ImportVideosFromCSV(csvFile, dryRun, skipErrors)
 		if err != nil {
 			return err
@@ -511,6 +671,141 @@ title,description,youtube_url,category_name,difficulty,duration,equipment,body_p
 	},
 }
 
+var videosBulkUpdateCmd = &cobra.Command{
+	Use:   "bulk-update",
+	Short: "Update many exercise videos at once",
+	Long: `Apply the same changes to every video matching a filter.
+
+The matching videos are previewed first; nothing is changed until the command
+is run again with --confirm. All changes are applied in a single transaction.
+
+Filters (combined with AND):
+  --category, --difficulty, --tag, --body-part, --ids-file (one ID per line),
+  or --all to target the whole catalog
+
+Operations:
+  --add-tag, --remove-tag, --add-body-part, --remove-body-part,
+  --add-equipment, --remove-equipment, --set-difficulty, --move-to-category
+
+Approved or published videos that change are sent back to draft, so the
+changes are reviewed before they go live; give --by.
+
+Example:
+  fisio-data-manager videos bulk-update --category "Knee & Hip" --tag "knee pain" \
+    --add-tag "post-op" --set-difficulty intermediate --by "Dr. Silva" --confirm`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewVideoService(db)
+
+		// Filters
+		categories, _ := cmd.Flags().GetStringSlice("category")
+		difficulty, _ := cmd.Flags().GetString("difficulty")
+		filterTags, _ := cmd.Flags().GetStringSlice("tag")
+		filterBodyParts, _ := cmd.Flags().GetStringSlice("body-part")
+		idsFile, _ := cmd.Flags().GetString("ids-file")
+		all, _ := cmd.Flags().GetBool("all")
+
+		// Operations
+		addTags, _ := cmd.Flags().GetStringSlice("add-tag")
+		removeTags, _ := cmd.Flags().GetStringSlice("remove-tag")
+		addBodyParts, _ := cmd.Flags().GetStringSlice("add-body-part")
+		removeBodyParts, _ := cmd.Flags().GetStringSlice("remove-body-part")
+		addEquipment, _ := cmd.Flags().GetStringSlice("add-equipment")
+		removeEquipment, _ := cmd.Flags().GetStringSlice("remove-equipment")
+		setDifficulty, _ := cmd.Flags().GetString("set-difficulty")
+		moveToCategory, _ := cmd.Flags().GetString("move-to-category")
+		strict, _ := cmd.Flags().GetBool("strict")
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		service.SetStrictTaxonomy(strict)
+
+		categoryIDs, err := resolveCategoryIDs(service, categories)
+		if err != nil {
+			return err
+		}
+
+		filter := models.VideoFilter{
+			CategoryIDs: categoryIDs,
+			Difficulty:  difficulty,
+			Tags:        filterTags,
+			BodyParts:   filterBodyParts,
+		}
+		if idsFile != "" {
+			filter.IDs, err = readIDsFile(idsFile)
+			if err != nil {
+				return err
+			}
+			if len(filter.IDs) == 0 {
+				return fmt.Errorf("no video IDs found in %s", idsFile)
+			}
+		}
+		if !all && len(filter.CategoryIDs) == 0 && filter.Difficulty == "" &&
+			len(filter.Tags) == 0 && len(filter.BodyParts) == 0 && len(filter.IDs) == 0 {
+			return fmt.Errorf("a filter is required (use --all to update every video)")
+		}
+
+		update := models.BulkVideoUpdate{
+			AddTags:         addTags,
+			RemoveTags:      removeTags,
+			AddBodyParts:    addBodyParts,
+			RemoveBodyParts: removeBodyParts,
+			AddEquipment:    addEquipment,
+			RemoveEquipment: removeEquipment,
+			SetDifficulty:   setDifficulty,
+		}
+		if moveToCategory != "" {
+			ids, err := resolveCategoryIDs(service, []string{moveToCategory})
+			if err != nil {
+				return err
+			}
+			update.MoveToCategoryID = ids[0]
+		}
+		if err := update.Validate(); err != nil {
+			return err
+		}
+
+		videos, err := service.GetVideos(filter)
+		if err != nil {
+			return err
+		}
+		if len(videos) == 0 {
+			fmt.Println("No videos match the filter.")
+			return nil
+		}
+
+		fmt.Printf("📋 %d matching videos:\n\n", len(videos))
+		if err := outputVideosTable(videos); err != nil {
+			return err
+		}
+		fmt.Printf("\n✏️  Changes:\n")
+		printBulkUpdate(update, moveToCategory)
+
+		if !confirm {
+			fmt.Printf("\nTo apply these changes, use: --confirm flag\n")
+			return nil
+		}
+
+		ids := make([]string, len(videos))
+		for i, video := range videos {
+			ids[i] = video.ID
+		}
+
+		by, _ := cmd.Flags().GetString("by")
+		result, err := service.BulkUpdateVideos(ids, update, by)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n✅ Successfully updated %d of %d videos\n", result.Updated, result.Matched)
+		printReopened(result.Reopened)
+		return nil
+	},
+}
+
 var videosTemplateCmd = &cobra.Command{
 	Use:   "template",
 	Short: "Generate CSV template for video import",
@@ -538,22 +833,35 @@ func init() {
 	videosCmd.AddCommand(seedVideosCmd)
 	videosCmd.AddCommand(videosImportCmd)
 	videosCmd.AddCommand(videosTemplateCmd)
+	videosCmd.AddCommand(videosBulkUpdateCmd)
 
 	// List command flags
-	videosListCmd.Flags().String("category", "", "Filter by category ID")
+	videosListCmd.Flags().StringSlice("category", []string{}, "Filter by category IDs or names")
+	videosListCmd.Flags().Bool("all-categories", false, "Only list videos in every given category")
 	videosListCmd.Flags().String("difficulty", "", "Filter by difficulty (beginner, intermediate, advanced)")
+	videosListCmd.Flags().String("status", "", "Filter by review state (draft, in_review, approved, published)")
 	videosListCmd.Flags().String("format", "table", "Output format (table, json, csv)")
+	videosListCmd.Flags().String("lang", "", "Language of titles and descriptions, falling back to en (e.g. pt, es)")
+	addSafetyFlags(videosListCmd)
 
 	// Add command flags
 	videosAddCmd.Flags().String("title", "", "Video title (required)")
 	videosAddCmd.Flags().String("description", "", "Video description")
-	videosAddCmd.Flags().String("url", "", "YouTube URL (required)")
-	videosAddCmd.Flags().String("category-id", "", "Category ID (required)")
+	videosAddCmd.Flags().String("url", "", "Video URL: YouTube, Vimeo or MP4 file (required)")
+	videosAddCmd.Flags().String("provider", "", "Video provider (youtube, vimeo, mp4) (default: detected from the URL)")
+	videosAddCmd.Flags().String("thumbnail", "", "Thumbnail URL (default: the provider's thumbnail)")
+	videosAddCmd.Flags().StringSlice("category-id", []string{}, "Category IDs or names, the first one is the primary category (required)")
 	videosAddCmd.Flags().String("difficulty", "beginner", "Difficulty level")
 	videosAddCmd.Flags().Int("duration", 0, "Duration in minutes")
 	videosAddCmd.Flags().StringSlice("equipment", []string{}, "Required equipment")
 	videosAddCmd.Flags().StringSlice("body-parts", []string{}, "Target body parts")
 	videosAddCmd.Flags().StringSlice("tags", []string{}, "Tags")
+	videosAddCmd.Flags().StringSlice("contraindications", []string{}, "Conditions the video must not be given for (e.g. pregnancy, osteoporosis)")
+	videosAddCmd.Flags().StringSlice("precautions", []string{}, "Conditions calling for precautions with the video")
+	videosAddCmd.Flags().Int("min-weeks-post-surgery", 0, "Minimum weeks after surgery before doing the video")
+	videosAddCmd.Flags().String("publish-at", "", "Publish automatically once approved, from this time (YYYY-MM-DD [HH:MM])")
+	videosAddCmd.Flags().String("expire-at", "", "Unpublish automatically at this time (YYYY-MM-DD [HH:MM])")
+	videosAddCmd.Flags().Bool("strict", false, "Reject equipment, body parts and tags that are not in the taxonomy")
 
 	videosAddCmd.MarkFlagRequired("title")
 	videosAddCmd.MarkFlagRequired("url")
@@ -562,20 +870,30 @@ func init() {
 	// Update command flags (same as add but optional)
 	videosUpdateCmd.Flags().String("title", "", "Video title")
 	videosUpdateCmd.Flags().String("description", "", "Video description")
-	videosUpdateCmd.Flags().String("url", "", "YouTube URL")
-	videosUpdateCmd.Flags().String("category-id", "", "Category ID")
+	videosUpdateCmd.Flags().String("url", "", "Video URL: YouTube, Vimeo or MP4 file")
+	videosUpdateCmd.Flags().String("provider", "", "Video provider (youtube, vimeo, mp4) (default: detected from the URL)")
+	videosUpdateCmd.Flags().String("thumbnail", "", "Thumbnail URL")
+	videosUpdateCmd.Flags().StringSlice("category-id", []string{}, "Category IDs or names, the first one is the primary category (replaces existing)")
 	videosUpdateCmd.Flags().String("difficulty", "", "Difficulty level")
 	videosUpdateCmd.Flags().Int("duration", 0, "Duration in minutes")
 	videosUpdateCmd.Flags().StringSlice("equipment", []string{}, "Required equipment")
 	videosUpdateCmd.Flags().StringSlice("body-parts", []string{}, "Target body parts")
 	videosUpdateCmd.Flags().StringSlice("tags", []string{}, "Tags")
+	videosUpdateCmd.Flags().StringSlice("contraindications", []string{}, "Conditions the video must not be given for (replaces existing, \"\" to clear)")
+	videosUpdateCmd.Flags().StringSlice("precautions", []string{}, "Conditions calling for precautions with the video (replaces existing, \"\" to clear)")
+	videosUpdateCmd.Flags().Int("min-weeks-post-surgery", 0, "Minimum weeks after surgery before doing the video (-1 to clear)")
+	videosUpdateCmd.Flags().String("publish-at", "", "Publish automatically once approved, from this time (YYYY-MM-DD [HH:MM], \"\" to clear)")
+	videosUpdateCmd.Flags().String("expire-at", "", "Unpublish automatically at this time (YYYY-MM-DD [HH:MM], \"\" to clear)")
+	videosUpdateCmd.Flags().Bool("strict", false, "Reject equipment, body parts and tags that are not in the taxonomy")
+	videosUpdateCmd.Flags().String("by", "", "Physiotherapist editing the video (required for approved or published videos)")
 
 
 	// Delete command flags
-	videosDeleteCmd.Flags().Bool("by-url", false, "Delete by YouTube URL instead of ID")
+	videosDeleteCmd.Flags().Bool("by-url", false, "Delete by video URL instead of ID")
 
 	// Categories command flags
 	categoriesListCmd.Flags().String("format", "table", "Output format (table, json)")
+	categoriesListCmd.Flags().String("lang", "", "Language of names and descriptions, falling back to en (e.g. pt, es)")
 
 	// Add category command flags
 	categoriesAddCmd.Flags().String("name", "", "Category name (required)")
@@ -596,6 +914,26 @@ func init() {
 	// Import command flags
 	videosImportCmd.Flags().Bool("dry-run", false, "Preview import without making changes")
 	videosImportCmd.Flags().Bool("skip-errors", false, "Continue import even if some rows fail")
+	videosImportCmd.Flags().Bool("strict", false, "Reject equipment, body parts and tags that are not in the taxonomy")
+
+	// Bulk update command flags
+	videosBulkUpdateCmd.Flags().StringSlice("category", []string{}, "Filter by category IDs or names")
+	videosBulkUpdateCmd.Flags().String("difficulty", "", "Filter by difficulty (beginner, intermediate, advanced)")
+	videosBulkUpdateCmd.Flags().StringSlice("tag", []string{}, "Filter by tags")
+	videosBulkUpdateCmd.Flags().StringSlice("body-part", []string{}, "Filter by body parts")
+	videosBulkUpdateCmd.Flags().String("ids-file", "", "File with one video ID per line")
+	videosBulkUpdateCmd.Flags().Bool("all", false, "Update every video")
+	videosBulkUpdateCmd.Flags().StringSlice("add-tag", []string{}, "Tags to add")
+	videosBulkUpdateCmd.Flags().StringSlice("remove-tag", []string{}, "Tags to remove")
+	videosBulkUpdateCmd.Flags().StringSlice("add-body-part", []string{}, "Body parts to add")
+	videosBulkUpdateCmd.Flags().StringSlice("remove-body-part", []string{}, "Body parts to remove")
+	videosBulkUpdateCmd.Flags().StringSlice("add-equipment", []string{}, "Equipment to add")
+	videosBulkUpdateCmd.Flags().StringSlice("remove-equipment", []string{}, "Equipment to remove")
+	videosBulkUpdateCmd.Flags().String("set-difficulty", "", "New difficulty level")
+	videosBulkUpdateCmd.Flags().String("move-to-category", "", "Move videos to this category (ID or name), replacing all their categories")
+	videosBulkUpdateCmd.Flags().Bool("strict", false, "Reject equipment, body parts and tags that are not in the taxonomy")
+	videosBulkUpdateCmd.Flags().Bool("confirm", false, "Apply the changes (otherwise only preview)")
+	videosBulkUpdateCmd.Flags().String("by", "", "Physiotherapist editing the videos (required when approved or published videos change)")
 
 	// Template command flags
 	videosTemplateCmd.Flags().String("output", "video_import_template.csv", "Output filename for template")
@@ -610,7 +948,7 @@ func outputVideosTable(videos []models.ExerciseVideo) error {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "ID\tTITLE\tCATEGORY\tDIFFICULTY\tDURATION\tCREATED")
+	fmt.Fprintln(w, "ID\tTITLE\tCATEGORY\tDIFFICULTY\tDURATION\tSTATUS\tCREATED")
 	
 	for _, video := range videos {
 		duration := "N/A"
@@ -622,13 +960,17 @@ func outputVideosTable(videos []models.ExerciseVideo) error {
 		if video.CategoryName != nil {
 			category = *video.CategoryName
 		}
+		if len(video.CategoryNames) > 1 {
+			category = fmt.Sprintf("%s (+%d)", category, len(video.CategoryNames)-1)
+		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			video.ID,
 			truncateString(video.Title, 30),
 			category,
 			video.DifficultyLevel,
 			duration,
+			video.Status,
 			video.CreatedAt.Format("2006-01-02"),
 		)
 	}
@@ -650,7 +992,7 @@ func outputVideosCSV(videos []models.ExerciseVideo) error {
 	defer writer.Flush()
 
 	// Write header
-	header := []string{"ID", "Title", "Description", "YouTube URL", "Category", "Difficulty", "Duration", "Equipment", "Body Parts", "Tags", "Created"}
+	header := []string{"ID", "Title", "Description", "Provider", "Video URL", "Category", "Difficulty", "Duration", "Equipment", "Body Parts", "Tags", "Contraindications", "Precautions", "Min Weeks Post-Surgery", "Status", "Publish At", "Expire At", "Created"}
 	if err := writer.Write(header); err != nil {
 		return err
 	}
@@ -661,9 +1003,20 @@ func outputVideosCSV(videos []models.ExerciseVideo) error {
 		if video.Duration != nil {
 			duration = strconv.Itoa(*video.Duration)
 		}
+		minWeeks := ""
+		if video.MinWeeksPostSurgery != nil {
+			minWeeks = strconv.Itoa(*video.MinWeeksPostSurgery)
+		}
+		publishAt, expireAt := "", ""
+		if video.PublishAt != nil {
+			publishAt = video.PublishAt.Local().Format("2006-01-02 15:04")
+		}
+		if video.ExpireAt != nil {
+			expireAt = video.ExpireAt.Local().Format("2006-01-02 15:04")
+		}
 		
-		category := ""
-		if video.CategoryName != nil {
+		category := strings.Join(video.CategoryNames, "; ")
+		if category == "" && video.CategoryName != nil {
 			category = *video.CategoryName
 		}
 
@@ -671,13 +1024,20 @@ func outputVideosCSV(videos []models.ExerciseVideo) error {
 			video.ID,
 			video.Title,
 			video.Description,
-			video.YoutubeURL,
+			video.Provider,
+			video.VideoURL,
 			category,
 			video.DifficultyLevel,
 			duration,
 			strings.Join(video.EquipmentRequired, "; "),
 			strings.Join(video.BodyParts, "; "),
 			strings.Join(video.Tags, "; "),
+			strings.Join(video.Contraindications, "; "),
+			strings.Join(video.Precautions, "; "),
+			minWeeks,
+			video.Status,
+			publishAt,
+			expireAt,
 			video.CreatedAt.Format("2006-01-02 15:04:05"),
 		}
 		if err := writer.Write(record); err != nil {
@@ -718,6 +1078,65 @@ func outputCategoriesJSON(categories []models.VideoCategory) error {
 	return nil
 }
 
+// resolveCategoryIDs maps category IDs or names to category IDs, keeping order
+func resolveCategoryIDs(service *services.VideoService, values []string) ([]string, error) {
+	ids := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if category, err := service.GetCategoryByName(value); err == nil {
+			ids = append(ids, category.ID)
+			continue
+		}
+		category, err := service.GetCategoryByID(value)
+		if err != nil {
+			return nil, fmt.Errorf("category '%s' not found", value)
+		}
+		ids = append(ids, category.ID)
+	}
+	return ids, nil
+}
+
+// readIDsFile reads one ID per line, ignoring blank lines and # comments
+func readIDsFile(filename string) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read IDs file: %w", err)
+	}
+
+	var ids []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, line)
+	}
+	return ids, nil
+}
+
+func printBulkUpdate(update models.BulkVideoUpdate, categoryName string) {
+	printList := func(label string, values []string) {
+		if len(values) > 0 {
+			fmt.Printf("- %s: %s\n", label, strings.Join(values, ", "))
+		}
+	}
+	printList("Add tags", update.AddTags)
+	printList("Remove tags", update.RemoveTags)
+	printList("Add body parts", update.AddBodyParts)
+	printList("Remove body parts", update.RemoveBodyParts)
+	printList("Add equipment", update.AddEquipment)
+	printList("Remove equipment", update.RemoveEquipment)
+	if update.SetDifficulty != "" {
+		fmt.Printf("- Set difficulty: %s\n", update.SetDifficulty)
+	}
+	if update.MoveToCategoryID != "" {
+		fmt.Printf("- Move to category: %s\n", categoryName)
+	}
+}
+
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -744,13 +1163,18 @@ func generateCSVTemplate(filename string, withExamples bool) error {
 	header := []string{
 		"title",
 		"description", 
-		"youtube_url",
+		"video_url",
 		"category_name",
 		"difficulty",
 		"duration",
 		"equipment",
 		"body_parts",
 		"tags",
+		"contraindications",
+		"precautions",
+		"min_weeks_post_surgery",
+		"publish_at",
+		"expire_at",
 	}
 	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
@@ -769,6 +1193,11 @@ func generateCSVTemplate(filename string, withExamples bool) error {
 				"Yoga Mat",
 				"Back;Core",
 				"stretching;back pain;beginner",
+				"acute_disc_herniation",
+				"pregnancy",
+				"",
+				"",
+				"",
 			},
 			{
 				"Neck and Shoulder Relief",
@@ -780,6 +1209,11 @@ func generateCSVTemplate(filename string, withExamples bool) error {
 				"None",
 				"Neck;Shoulders",
 				"neck pain;shoulder tension;office workers",
+				"",
+				"",
+				"",
+				"2026-12-01 08:00",
+				"2027-03-01",
 			},
 			{
 				"Knee Strengthening Exercises",
@@ -791,6 +1225,11 @@ func generateCSVTemplate(filename string, withExamples bool) error {
 				"Resistance Bands",
 				"Legs;Glutes",
 				"knee pain;strengthening;stability",
+				"early_post_op;recent_fracture",
+				"knee_replacement;osteoporosis",
+				"6",
+				"",
+				"",
 			},
 		}
 
@@ -809,8 +1248,8 @@ func generateCSVTemplate(filename string, withExamples bool) error {
 	fmt.Println("\n📋 CSV Format Guide:")
 	fmt.Println("- title: Video title (required)")
 	fmt.Println("- description: Video description (optional)")
-	fmt.Println("- youtube_url: Full YouTube URL (required)")
-	fmt.Println("- category_name: Category name (must match existing category)")
+	fmt.Println("- video_url: YouTube, Vimeo or MP4 URL (required)")
+	fmt.Println("- category_name: Semicolon-separated category names, the first is the primary (e.g., 'Back & Spine;Balance & Coordination')")
 	fmt.Println("- difficulty: beginner, intermediate, or advanced")
 	fmt.Println("- duration: Duration in minutes (optional)")
 	fmt.Println("- equipment: Semicolon-separated list (e.g., 'Yoga Mat;Resistance Bands')")
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"fisio-data-manager/internal/bundle"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var videosBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export the public library as static JSON files",
+	Long: `Write a static JSON snapshot of the categories and public videos, so the
+website can load the library from a CDN instead of the database.
+
+The output directory gets:
+  categories.<hash>.json        the categories, with their video counts
+  videos.<hash>.json            every public video
+  category-<id>.<hash>.json     the videos of each category
+  manifest.json                 the files above, with their hashes and ETags
+
+Data files are named after their content and can be cached forever; only
+manifest.json needs a short cache. Files whose content has not changed are
+not rewritten, so a sync to the CDN only uploads what changed. Only videos
+visible to the public now are included: run the bundle again after the
+scheduler publishes or expires videos.
+
+Examples:
+  fisio-data-manager videos bundle --out dist/
+  fisio-data-manager videos bundle --out dist/ --prune`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, _ := cmd.Flags().GetString("out")
+		prune, _ := cmd.Flags().GetBool("prune")
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewVideoService(db)
+		categories, err := service.GetCategories()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		videos, err := service.GetVideos(models.VideoFilter{PublicAt: &now})
+		if err != nil {
+			return err
+		}
+
+		b, err := bundle.Build(categories, videos)
+		if err != nil {
+			return err
+		}
+		result, err := bundle.Write(out, b, prune)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range result.Written {
+			fmt.Printf("📝 %s\n", filepath.Join(out, name))
+		}
+		for _, name := range result.Removed {
+			fmt.Printf("🗑️  %s\n", filepath.Join(out, name))
+		}
+		if len(result.Written) == 0 {
+			fmt.Printf("✅ Bundle %s is up to date\n", b.Manifest.Version)
+		} else {
+			fmt.Printf("✅ Wrote bundle %s\n", b.Manifest.Version)
+		}
+		fmt.Printf("📊 %d categories, %d video(s): %d file(s) written, %d unchanged, %d removed\n",
+			b.Manifest.Categories.Count, b.Manifest.Videos.Count,
+			len(result.Written), len(result.Unchanged), len(result.Removed))
+		return nil
+	},
+}
+
+func init() {
+	videosCmd.AddCommand(videosBundleCmd)
+
+	videosBundleCmd.Flags().String("out", "dist", "Output directory")
+	videosBundleCmd.Flags().Bool("prune", false, "Remove data files of earlier bundles no longer in the manifest")
+}
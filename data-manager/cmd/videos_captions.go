@@ -0,0 +1,422 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"fisio-data-manager/internal/captions"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var videosCaptionsCmd = &cobra.Command{
+	Use:   "captions",
+	Short: "Manage video captions and transcripts",
+	Long: `Attach caption/transcript files (WebVTT or SRT) to videos, one per language.
+
+Caption files are parsed into timed cues, which are searched by
+"videos search --in-transcripts".`,
+}
+
+var captionsAddCmd = &cobra.Command{
+	Use:   "add [video-id] [caption-file]",
+	Short: "Attach a caption file to a video",
+	Long: `Parse a WebVTT (.vtt) or SRT (.srt) file and store its cues as the video's
+captions in a language, replacing any captions already stored for it.
+
+Examples:
+  fisio-data-manager videos captions add <video-id> lower-back.vtt --lang en
+  fisio-data-manager videos captions add <video-id> lombar.srt --lang pt
+  fisio-data-manager videos captions add <video-id> captions.txt --lang en --format vtt`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		locale, err := langFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		filename := args[1]
+		format, _ := cmd.Flags().GetString("format")
+		if format == "" {
+			if format, err = captions.DetectFormat(filename); err != nil {
+				return err
+			}
+		}
+
+		file, err := os.Open(filename)
+		if err != nil {
+			return fmt.Errorf("failed to open caption file: %w", err)
+		}
+		defer file.Close()
+
+		cues, err := captions.Parse(file, strings.ToLower(format))
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		video, err := services.NewVideoService(db).GetVideoByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		caption, err := services.NewCaptionService(db).AddCaptions(video.ID, locale, strings.ToLower(format), filepath.Base(filename), cues)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully added %s captions to video: %s\n", locale, video.Title)
+		fmt.Printf("   %d cues, %s\n", caption.CueCount, caption.Duration)
+		return nil
+	},
+}
+
+var captionsListCmd = &cobra.Command{
+	Use:   "list [video-id]",
+	Short: "List the captions of a video",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		video, err := services.NewVideoService(db).GetVideoByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		list, err := services.NewCaptionService(db).ListCaptions(video.ID)
+		if err != nil {
+			return err
+		}
+
+		if format, _ := cmd.Flags().GetString("format"); format == "json" {
+			return outputJSON(list)
+		}
+
+		if len(list) == 0 {
+			fmt.Printf("⚠️  No captions for video: %s\n", video.Title)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LANG\tFORMAT\tCUES\tLENGTH\tFILE\tUPDATED")
+		for _, caption := range list {
+			filename := ""
+			if caption.SourceFilename != nil {
+				filename = *caption.SourceFilename
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n",
+				caption.Locale,
+				caption.Format,
+				caption.CueCount,
+				caption.Duration,
+				filename,
+				caption.UpdatedAt.Format("2006-01-02"),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var captionsRemoveCmd = &cobra.Command{
+	Use:   "remove [video-id]",
+	Short: "Remove the captions of a video in a language",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		locale, err := langFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		video, err := services.NewVideoService(db).GetVideoByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := services.NewCaptionService(db).RemoveCaptions(video.ID, locale); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully removed %s captions of video: %s\n", locale, video.Title)
+		return nil
+	},
+}
+
+var captionsMissingCmd = &cobra.Command{
+	Use:   "missing",
+	Short: "List videos without captions",
+	Long: `List the videos that have no captions, for each language (default: the
+default language only). Videos without captions are not accessible to deaf
+and hard-of-hearing patients.
+
+Examples:
+  fisio-data-manager videos captions missing
+  fisio-data-manager videos captions missing --lang en,pt --format csv > missing_captions.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values, _ := cmd.Flags().GetStringSlice("lang")
+
+		var locales []string
+		for _, value := range values {
+			locale, err := models.ParseLocale(value)
+			if err != nil {
+				return err
+			}
+			locales = append(locales, locale)
+		}
+		if len(locales) == 0 {
+			locales = []string{models.DefaultLocale}
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		missing, err := services.NewCaptionService(db).GetVideosWithoutCaptions(locales)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(missing)
+		case "csv":
+			return outputMissingCaptionsCSV(missing)
+		default:
+			return outputMissingCaptionsTable(missing, locales)
+		}
+	},
+}
+
+var videosSearchCmd = &cobra.Command{
+	Use:   "search [phrase]",
+	Short: "Search videos, or the moments a phrase is spoken in them",
+	Long: `Search the title, description and tags of videos (case-insensitive).
+
+With --in-transcripts the video captions are searched instead, listing each
+moment the phrase is spoken with a link that starts the video there.
+
+Examples:
+  fisio-data-manager videos search "hamstring"
+  fisio-data-manager videos search "keep your back straight" --in-transcripts
+  fisio-data-manager videos search "respire fundo" --in-transcripts --lang pt --format json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		phrase := strings.Join(args, " ")
+		inTranscripts, _ := cmd.Flags().GetBool("in-transcripts")
+		limit, _ := cmd.Flags().GetInt("limit")
+		format, _ := cmd.Flags().GetString("format")
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		if inTranscripts {
+			// Transcripts are searched in every language unless one is given
+			locale := ""
+			if cmd.Flags().Changed("lang") {
+				if locale, err = langFlag(cmd); err != nil {
+					return err
+				}
+			}
+
+			matches, err := services.NewCaptionService(db).SearchTranscripts(phrase, locale, limit)
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case "json":
+				return outputJSON(matches)
+			case "csv":
+				return outputTranscriptMatchesCSV(matches)
+			default:
+				return outputTranscriptMatchesTable(matches, phrase)
+			}
+		}
+
+		locale, err := langFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		videos, err := services.NewVideoService(db).GetVideos(models.VideoFilter{Search: phrase})
+		if err != nil {
+			return err
+		}
+		if limit > 0 && len(videos) > limit {
+			videos = videos[:limit]
+		}
+
+		if err := services.NewTranslationService(db).LocalizeVideos(videos, locale); err != nil {
+			return err
+		}
+
+		switch format {
+		case "json":
+			return outputVideosJSON(videos)
+		case "csv":
+			return outputVideosCSV(videos)
+		default:
+			return outputVideosTable(videos)
+		}
+	},
+}
+
+func init() {
+	videosCmd.AddCommand(videosCaptionsCmd)
+	videosCmd.AddCommand(videosSearchCmd)
+	videosCaptionsCmd.AddCommand(captionsAddCmd)
+	videosCaptionsCmd.AddCommand(captionsListCmd)
+	videosCaptionsCmd.AddCommand(captionsRemoveCmd)
+	videosCaptionsCmd.AddCommand(captionsMissingCmd)
+
+	// Captions add command flags
+	captionsAddCmd.Flags().String("lang", "", "Language of the captions (e.g. en, pt) (required)")
+	captionsAddCmd.Flags().String("format", "", "Caption format (vtt, srt) (default: from the file extension)")
+	captionsAddCmd.MarkFlagRequired("lang")
+
+	// Captions list command flags
+	captionsListCmd.Flags().String("format", "table", "Output format (table, json)")
+
+	// Captions remove command flags
+	captionsRemoveCmd.Flags().String("lang", "", "Language of the captions to remove (required)")
+	captionsRemoveCmd.MarkFlagRequired("lang")
+
+	// Captions missing command flags
+	captionsMissingCmd.Flags().StringSlice("lang", []string{}, "Languages to check (default: en)")
+	captionsMissingCmd.Flags().String("format", "table", "Output format (table, json, csv)")
+
+	// Search command flags
+	videosSearchCmd.Flags().Bool("in-transcripts", false, "Search the video captions for the moments the phrase is spoken")
+	videosSearchCmd.Flags().String("lang", "", "Language of the results; with --in-transcripts, only search captions in this language")
+	videosSearchCmd.Flags().Int("limit", 0, "Maximum number of results (0 for no limit)")
+	videosSearchCmd.Flags().String("format", "table", "Output format (table, json, csv)")
+}
+
+func outputTranscriptMatchesTable(matches []models.TranscriptMatch, phrase string) error {
+	if len(matches) == 0 {
+		fmt.Printf("🔍 \"%s\" was not found in any transcript\n", phrase)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VIDEO\tLANG\tAT\tTEXT\tLINK")
+
+	for _, m := range matches {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			truncateString(m.VideoTitle, 30),
+			m.Locale,
+			m.Start,
+			truncateString(m.Text, 50),
+			m.DeepLink,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🔍 %d match(es) for \"%s\"\n", len(matches), phrase)
+	return nil
+}
+
+func outputTranscriptMatchesCSV(matches []models.TranscriptMatch) error {
+	writer := csv.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Video ID", "Video", "Language", "Start (s)", "End (s)", "Text", "Link"}); err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		record := []string{
+			m.VideoID,
+			m.VideoTitle,
+			m.Locale,
+			fmt.Sprintf("%d", m.Start.Seconds()),
+			fmt.Sprintf("%d", m.End.Seconds()),
+			m.Text,
+			m.DeepLink,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func outputMissingCaptionsTable(missing []models.VideoWithoutCaptions, locales []string) error {
+	if len(missing) == 0 {
+		fmt.Printf("✅ Every video has captions (%s)\n", strings.Join(locales, ", "))
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, m := range missing {
+		counts[m.Locale]++
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LANG\tID\tTITLE\tCATEGORY")
+
+	for _, m := range missing {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			m.Locale,
+			m.VideoID,
+			truncateString(m.VideoTitle, 40),
+			m.Category,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	for _, locale := range locales {
+		fmt.Printf("%s: %d video(s) without captions\n", locale, counts[locale])
+	}
+
+	return nil
+}
+
+func outputMissingCaptionsCSV(missing []models.VideoWithoutCaptions) error {
+	writer := csv.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Language", "Video ID", "Title", "Category"}); err != nil {
+		return err
+	}
+
+	for _, m := range missing {
+		if err := writer.Write([]string{m.Locale, m.VideoID, m.VideoTitle, m.Category}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/recommend"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var videosRecommendCmd = &cobra.Command{
+	Use:   "recommend",
+	Short: "Recommend videos for a symptom assessment",
+	Long: `Rank catalog videos for a patient's symptom assessment.
+
+Videos score points when their body parts match the assessment's pain
+locations and when their tags match words of the primary (or secondary)
+symptom. Pain level and daily impact cap the difficulty: harder videos are
+left out. Each suggestion lists the reasons it was picked. Only published
+videos are suggested.
+
+Videos contraindicated for the conditions given with --safe-for, or not
+allowed yet --weeks-post-surgery, are never suggested; suggestions calling
+for precautions with those conditions are flagged.
+
+The scoring rules can be tuned with a JSON file; run with --print-rules to
+get the defaults as a starting point.
+
+Examples:
+  fisio-data-manager videos recommend --assessment <assessment-id>
+  fisio-data-manager videos recommend --assessment <assessment-id> --limit 5 --rules rules.json
+  fisio-data-manager videos recommend --assessment <assessment-id> --safe-for pregnancy --weeks-post-surgery 4
+  fisio-data-manager videos recommend --print-rules > rules.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules := recommend.DefaultRules()
+		if path, _ := cmd.Flags().GetString("rules"); path != "" {
+			var err error
+			rules, err = recommend.LoadRules(path)
+			if err != nil {
+				return err
+			}
+		}
+
+		if printRules, _ := cmd.Flags().GetBool("print-rules"); printRules {
+			return outputJSON(rules)
+		}
+
+		assessmentID, _ := cmd.Flags().GetString("assessment")
+		if assessmentID == "" {
+			return fmt.Errorf("--assessment is required")
+		}
+
+		conditions, weeksPostSurgery, err := safetyFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		assessment, err := services.NewAssessmentService(db).GetAssessment(assessmentID)
+		if err != nil {
+			return err
+		}
+
+		videos, err := services.NewVideoService(db).GetVideos(models.VideoFilter{
+			Statuses:         []string{models.VideoStatusPublished},
+			SafeFor:          conditions,
+			WeeksPostSurgery: weeksPostSurgery,
+		})
+		if err != nil {
+			return err
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		recommendations := recommend.Rank(assessment, videos, rules, limit)
+		for i := range recommendations {
+			recommendations[i].Precautions = recommendations[i].Video.PrecautionsFor(conditions)
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(recommendations)
+		default:
+			return outputRecommendations(assessment, rules, recommendations)
+		}
+	},
+}
+
+func init() {
+	videosCmd.AddCommand(videosRecommendCmd)
+
+	videosRecommendCmd.Flags().String("assessment", "", "Symptom assessment ID")
+	videosRecommendCmd.Flags().Int("limit", 10, "Maximum number of suggestions (0 for all)")
+	videosRecommendCmd.Flags().String("rules", "", "JSON file with scoring rules (default: built-in rules)")
+	videosRecommendCmd.Flags().Bool("print-rules", false, "Print the scoring rules as JSON and exit")
+	videosRecommendCmd.Flags().String("format", "table", "Output format (table, json)")
+	addSafetyFlags(videosRecommendCmd)
+}
+
+func outputRecommendations(assessment *models.SymptomAssessment, rules recommend.Rules, recommendations []recommend.Recommendation) error {
+	maxDifficulty, capReason := rules.MaxDifficulty(assessment)
+
+	fmt.Printf("🩺 Assessment for %s (%s)\n", assessment.PatientName, assessment.AssessmentDate.Format("2006-01-02"))
+	fmt.Printf("Pain: %d/10", assessment.PainLevel)
+	if locations := assessment.Locations(); len(locations) > 0 {
+		fmt.Printf(" in %s", strings.Join(locations, ", "))
+	}
+	fmt.Println()
+	if symptom := assessment.Symptom(); symptom != "" {
+		fmt.Printf("Primary symptom: %s\n", symptom)
+	}
+	if capReason != "" {
+		fmt.Printf("Difficulty capped at %s (%s)\n", maxDifficulty, capReason)
+	}
+	fmt.Println()
+
+	if len(recommendations) == 0 {
+		fmt.Println("No matching videos found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tSCORE\tID\tTITLE\tDIFFICULTY\tREASONS")
+
+	for i, rec := range recommendations {
+		reasons := strings.Join(rec.Reasons, "; ")
+		if len(rec.Precautions) > 0 {
+			reasons = fmt.Sprintf("⚠️  precautions: %s; %s", strings.Join(rec.Precautions, ", "), reasons)
+		}
+		fmt.Fprintf(w, "%d\t%.1f\t%s\t%s\t%s\t%s\n",
+			i+1,
+			rec.Score,
+			rec.Video.ID,
+			truncateString(rec.Video.Title, 40),
+			rec.Video.DifficultyLevel,
+			reasons,
+		)
+	}
+
+	return w.Flush()
+}
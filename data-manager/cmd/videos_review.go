@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// newReviewCmd builds the command applying a review action to a video
+func newReviewCmd(action, short, long string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   action + " [video-id]",
+		Short: short,
+		Long:  long,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			by, _ := cmd.Flags().GetString("by")
+			comment, _ := cmd.Flags().GetString("comment")
+			if reason, _ := cmd.Flags().GetString("reason"); reason != "" {
+				comment = reason
+			}
+
+			db, err := database.Connect()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			video, err := services.NewReviewService(db).Review(args[0], models.ReviewFormData{
+				Action:  action,
+				Actor:   by,
+				Comment: comment,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✅ Successfully moved video to %s: %s\n", video.Status, video.Title)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("by", "", "Physiotherapist taking the action (required)")
+	cmd.MarkFlagRequired("by")
+	if action == models.ReviewReject || action == models.ReviewUnpublish {
+		cmd.Flags().String("reason", "", "Reason, shown to the submitter (required)")
+		cmd.MarkFlagRequired("reason")
+	} else {
+		cmd.Flags().String("comment", "", "Review comment")
+	}
+
+	return cmd
+}
+
+var videosSubmitCmd = newReviewCmd(models.ReviewSubmit,
+	"Submit a draft video for review",
+	`Submit a draft video for review by a second physiotherapist.
+
+Example:
+  fisio-data-manager videos submit <video-id> --by "Dr. Silva"`)
+
+var videosApproveCmd = newReviewCmd(models.ReviewApprove,
+	"Approve a video in review",
+	`Approve a video in review. The reviewer must be someone other than the
+physiotherapist who submitted it.
+
+Example:
+  fisio-data-manager videos approve <video-id> --by "Dr. Costa" --comment "Cues are clear"`)
+
+var videosRejectCmd = newReviewCmd(models.ReviewReject,
+	"Send a video in review back to draft",
+	`Reject a video in review (or approved, not yet published), sending it back
+to draft with the reason.
+
+Example:
+  fisio-data-manager videos reject <video-id> --by "Dr. Costa" --reason "Knee goes past the toes at 1:20"`)
+
+var videosPublishCmd = newReviewCmd(models.ReviewPublish,
+	"Publish an approved video",
+	`Publish an approved video, making it visible in the public library.
+
+Example:
+  fisio-data-manager videos publish <video-id> --by "Dr. Silva"`)
+
+var videosUnpublishCmd = newReviewCmd(models.ReviewUnpublish,
+	"Take a published video out of the public library",
+	`Take a published video out of the public library. It stays approved and can
+be published again.
+
+Example:
+  fisio-data-manager videos unpublish <video-id> --by "Dr. Silva" --reason "Outdated guidance"`)
+
+var videosQueueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "List videos waiting in the review workflow",
+	Long: `List the videos waiting for review (default), or in other review states,
+with who submitted and reviewed them and the latest review comment.
+
+Examples:
+  fisio-data-manager videos queue
+  fisio-data-manager videos queue --status draft,approved`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values, _ := cmd.Flags().GetStringSlice("status")
+		statuses := make([]string, 0, len(values))
+		for _, value := range values {
+			status, err := models.ParseVideoStatus(value)
+			if err != nil {
+				return err
+			}
+			statuses = append(statuses, status)
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		videos, err := services.NewVideoService(db).GetVideos(models.VideoFilter{Statuses: statuses})
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, len(videos))
+		for i, video := range videos {
+			ids[i] = video.ID
+		}
+		latest, err := services.NewReviewService(db).GetLatestReviews(ids)
+		if err != nil {
+			return err
+		}
+
+		if format, _ := cmd.Flags().GetString("format"); format == "json" {
+			return outputJSON(videos)
+		}
+		return outputReviewQueue(videos, latest)
+	},
+}
+
+var videosReviewsCmd = &cobra.Command{
+	Use:   "reviews [video-id]",
+	Short: "Show the review log of a video",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		video, err := services.NewVideoService(db).GetVideoByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		reviews, err := services.NewReviewService(db).GetReviews(video.ID)
+		if err != nil {
+			return err
+		}
+
+		if format, _ := cmd.Flags().GetString("format"); format == "json" {
+			return outputJSON(reviews)
+		}
+
+		fmt.Printf("📝 %s (%s)\n\n", video.Title, video.Status)
+		if len(reviews) == 0 {
+			fmt.Println("No reviews yet.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATE\tACTION\tBY\tSTATUS\tCOMMENT")
+		for _, r := range reviews {
+			comment := ""
+			if r.Comment != nil {
+				comment = *r.Comment
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s → %s\t%s\n",
+				r.CreatedAt.Format("2006-01-02 15:04"),
+				r.Action,
+				r.Actor,
+				r.FromStatus,
+				r.ToStatus,
+				comment,
+			)
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	videosCmd.AddCommand(videosSubmitCmd)
+	videosCmd.AddCommand(videosApproveCmd)
+	videosCmd.AddCommand(videosRejectCmd)
+	videosCmd.AddCommand(videosPublishCmd)
+	videosCmd.AddCommand(videosUnpublishCmd)
+	videosCmd.AddCommand(videosQueueCmd)
+	videosCmd.AddCommand(videosReviewsCmd)
+
+	// Queue command flags
+	videosQueueCmd.Flags().StringSlice("status", []string{models.VideoStatusInReview}, "Review states to list (draft, in_review, approved, published)")
+	videosQueueCmd.Flags().String("format", "table", "Output format (table, json)")
+
+	// Reviews command flags
+	videosReviewsCmd.Flags().String("format", "table", "Output format (table, json)")
+}
+
+func outputReviewQueue(videos []models.ExerciseVideo, latest map[string]models.VideoReview) error {
+	if len(videos) == 0 {
+		fmt.Println("✅ Nothing waiting for review.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tSUBMITTED BY\tSUBMITTED\tREVIEWED BY\tLAST COMMENT")
+
+	for _, video := range videos {
+		submittedBy, submitted, reviewedBy, comment := "", "", "", ""
+		if video.SubmittedBy != nil {
+			submittedBy = *video.SubmittedBy
+		}
+		if video.SubmittedAt != nil {
+			submitted = video.SubmittedAt.Format("2006-01-02")
+		}
+		if video.ReviewedBy != nil {
+			reviewedBy = *video.ReviewedBy
+		}
+		if review, ok := latest[video.ID]; ok && review.Comment != nil {
+			comment = truncateString(*review.Comment, 40)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			video.ID,
+			truncateString(video.Title, 30),
+			video.Status,
+			submittedBy,
+			submitted,
+			reviewedBy,
+			comment,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n📊 %d video(s)\n", len(videos))
+	return nil
+}
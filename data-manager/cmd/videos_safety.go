@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"fisio-data-manager/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var videosConditionsCmd = &cobra.Command{
+	Use:   "conditions",
+	Short: "List the conditions used for contraindications and precautions",
+	Long: `List the patient conditions videos can be contraindicated for or call for
+precautions with. Names, labels and aliases are all accepted by --safe-for,
+--contraindications, --precautions and the CSV import.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if format, _ := cmd.Flags().GetString("format"); format == "json" {
+			return outputJSON(models.Conditions)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tLABEL\tALIASES")
+		for _, condition := range models.Conditions {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", condition.Name, condition.Label, strings.Join(condition.Aliases, ", "))
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	videosCmd.AddCommand(videosConditionsCmd)
+
+	videosConditionsCmd.Flags().String("format", "table", "Output format (table, json)")
+}
+
+// addSafetyFlags adds the flags describing a patient's conditions, read by
+// safetyFlags
+func addSafetyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("safe-for", []string{}, "Leave out videos contraindicated for these conditions (e.g. pregnancy, osteoporosis)")
+	cmd.Flags().Int("weeks-post-surgery", -1, "Weeks since the patient's surgery; leaves out videos not allowed yet")
+}
+
+// safetyFlags returns the conditions (by name) and weeks after surgery given
+// with addSafetyFlags
+func safetyFlags(cmd *cobra.Command) ([]string, *int, error) {
+	values, _ := cmd.Flags().GetStringSlice("safe-for")
+	conditions, err := models.ParseConditions(values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var weeksPostSurgery *int
+	if weeks, _ := cmd.Flags().GetInt("weeks-post-surgery"); weeks >= 0 {
+		weeksPostSurgery = &weeks
+	}
+
+	return conditions, weeksPostSurgery, nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var videosSchedulerCmd = &cobra.Command{
+	Use:   "scheduler",
+	Short: "Apply scheduled publish and expire dates",
+	Long: `Videos can be given a publish date (--publish-at) and an expire date
+(--expire-at) with add, update or import. The scheduler publishes approved
+videos once their publish date has passed and unpublishes videos once their
+expire date has passed.`,
+}
+
+var videosSchedulerRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Publish and unpublish videos that are due",
+	Long: `Publish approved videos whose publish date has passed, and unpublish
+published videos whose expire date has passed. Each change is logged in the
+review log of the video (by "scheduler") and printed with a timestamp.
+
+Videos due to be published that are not approved yet are reported and left
+unchanged. A change that fails is reported as failed without stopping the
+others, and the command exits with an error. Meant to run from cron, e.g. every 15 minutes:
+
+  */15 * * * * fisio-data-manager videos scheduler run >> /var/log/fisio-scheduler.log 2>&1
+
+Examples:
+  fisio-data-manager videos scheduler run --dry-run
+  fisio-data-manager videos scheduler run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		now := time.Now()
+		changes, err := services.NewReviewService(db).RunScheduler(now, dryRun)
+		if changes == nil {
+			return err
+		}
+
+		// Report what was done even when some changes failed
+		stamp := now.Format("2006-01-02 15:04:05")
+		if dryRun {
+			fmt.Printf("%s 🔍 DRY RUN MODE - no changes will be made\n", stamp)
+		}
+		published, unpublished := "published", "unpublished"
+		if dryRun {
+			published, unpublished = "would publish", "would unpublish"
+		}
+		applied, failed := 0, 0
+		for _, c := range changes {
+			due := c.DueAt.Local().Format("2006-01-02 15:04")
+			switch {
+			case c.Action == models.ScheduleSkip:
+				fmt.Printf("%s ⚠️  skipped %s (%s): due %s but %s, not approved\n", stamp, c.Title, c.VideoID, due, c.Status)
+			case c.Result == models.ScheduleFailed:
+				fmt.Printf("%s ❌ failed to %s %s (%s), due %s: %s\n", stamp, c.Action, c.Title, c.VideoID, due, c.Error)
+				failed++
+			case c.Action == models.ReviewPublish:
+				fmt.Printf("%s %s %s (%s), due %s\n", stamp, published, c.Title, c.VideoID, due)
+				applied++
+			case c.Action == models.ReviewExpire:
+				fmt.Printf("%s %s %s (%s), expired %s\n", stamp, unpublished, c.Title, c.VideoID, due)
+				applied++
+			}
+		}
+
+		fmt.Printf("%s 📊 %d change(s), %d failed, %d skipped\n", stamp, applied, failed, len(changes)-applied-failed)
+		return err
+	},
+}
+
+func init() {
+	videosCmd.AddCommand(videosSchedulerCmd)
+	videosSchedulerCmd.AddCommand(videosSchedulerRunCmd)
+
+	videosSchedulerRunCmd.Flags().Bool("dry-run", false, "Show the changes that are due without making them")
+}
+
+// scheduleFlag parses an optional publish/expire time flag
+func scheduleFlag(cmd *cobra.Command, name string) (*time.Time, error) {
+	value, _ := cmd.Flags().GetString(name)
+	t, err := models.ParseScheduleTime(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", name, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/seo"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var videosSitemapCmd = &cobra.Command{
+	Use:   "sitemap",
+	Short: "Generate a Google video sitemap for the public library",
+	Long: `Generate a Google video sitemap listing the videos of the public exercise
+library (` + seo.LibraryPath + `), so search engines can find the embedded videos.
+
+Only videos visible to the public are listed: published, and inside their
+publishing window. Videos without a thumbnail are left out with a warning,
+as Google requires one. Warnings and the summary go to stderr, so the sitemap
+can be redirected to a file.
+
+Example:
+  fisio-data-manager videos sitemap --base-url https://example.com > public/video-sitemap.xml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseURL, _ := cmd.Flags().GetString("base-url")
+		pageURL, err := seo.PageURL(baseURL)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		videos, err := publicVideos(services.NewVideoService(db), nil)
+		if err != nil {
+			return err
+		}
+
+		if err := seo.WriteSitemap(os.Stdout, pageURL, videos); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "📊 %d video(s) in the sitemap\n", len(videos))
+		return nil
+	},
+}
+
+var videosJSONLDCmd = &cobra.Command{
+	Use:   "jsonld [video-id...]",
+	Short: "Generate schema.org VideoObject structured data",
+	Long: `Generate schema.org VideoObject structured data (JSON-LD) for the videos of
+the public library, from their title, description, thumbnail, duration and
+categories. Without IDs every public video is included.
+
+Only videos visible to the public are included; videos without a thumbnail
+are left out with a warning. With --html each video is wrapped in a
+<script type="application/ld+json"> tag, ready to paste into a page.
+
+Examples:
+  fisio-data-manager videos jsonld --base-url https://example.com > videos.jsonld
+  fisio-data-manager videos jsonld <video-id> --html`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pageURL := ""
+		if baseURL, _ := cmd.Flags().GetString("base-url"); baseURL != "" {
+			var err error
+			if pageURL, err = seo.PageURL(baseURL); err != nil {
+				return err
+			}
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		videos, err := publicVideos(services.NewVideoService(db), args)
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 && len(videos) < len(args) {
+			found := make(map[string]bool)
+			for _, video := range videos {
+				found[video.ID] = true
+			}
+			for _, id := range args {
+				if !found[id] {
+					fmt.Fprintf(os.Stderr, "⚠️  Skipping %s: not found or not public\n", id)
+				}
+			}
+		}
+
+		objects := make([]seo.VideoObject, len(videos))
+		for i, video := range videos {
+			objects[i] = seo.NewVideoObject(video, pageURL)
+		}
+
+		if html, _ := cmd.Flags().GetBool("html"); html {
+			for _, object := range objects {
+				// json.Marshal escapes <, > and &, so the data cannot close the tag
+				data, err := json.MarshalIndent(object, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Printf("<script type=\"application/ld+json\">\n%s\n</script>\n", data)
+			}
+			return nil
+		}
+		return outputJSON(objects)
+	},
+}
+
+func init() {
+	videosCmd.AddCommand(videosSitemapCmd)
+	videosCmd.AddCommand(videosJSONLDCmd)
+
+	videosSitemapCmd.Flags().String("base-url", "", "URL of the public site, e.g. https://example.com (required)")
+	videosSitemapCmd.MarkFlagRequired("base-url")
+
+	videosJSONLDCmd.Flags().String("base-url", "", "URL of the public site, used for the url of each video")
+	videosJSONLDCmd.Flags().Bool("html", false, "Wrap each video in a <script type=\"application/ld+json\"> tag")
+}
+
+// publicVideos returns the videos visible to the public now (only the given
+// IDs, if any), warning about and leaving out those search engines would
+// reject
+func publicVideos(service *services.VideoService, ids []string) ([]models.ExerciseVideo, error) {
+	now := time.Now()
+	videos, err := service.GetVideos(models.VideoFilter{IDs: ids, PublicAt: &now})
+	if err != nil {
+		return nil, err
+	}
+
+	eligible := make([]models.ExerciseVideo, 0, len(videos))
+	for _, video := range videos {
+		if err := seo.Eligible(video); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Skipping %s (%s): %v\n", video.Title, video.ID, err)
+			continue
+		}
+		eligible = append(eligible, video)
+	}
+	return eligible, nil
+}
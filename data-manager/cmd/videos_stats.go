@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// newTermStatsCmd builds the "<group> stats" commands for one vocabulary
+// (videos tags stats, videos body-parts stats, videos equipment stats)
+func newTermStatsCmd(use string, kind models.TaxonomyKind, label string) *cobra.Command {
+	groupCmd := &cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("Inspect video %s", label),
+	}
+
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: fmt.Sprintf("Show how many videos use each %s", strings.TrimSuffix(label, "s")),
+		Long: fmt.Sprintf(`Show how many videos use each of the %s, split by difficulty, and in
+which categories they appear.
+
+The report also lists values used by a single video (likely typos) and
+categories that have no videos, to help find gaps in the library.`, label),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := database.Connect()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			service := services.NewTaxonomyService(db)
+			stats, err := service.GetTermStats(kind)
+			if err != nil {
+				return err
+			}
+
+			format, _ := cmd.Flags().GetString("format")
+			switch format {
+			case "json":
+				data, err := json.MarshalIndent(stats, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+				return nil
+			case "csv":
+				return outputTermStatsCSV(stats)
+			default:
+				return outputTermStatsTable(stats)
+			}
+		},
+	}
+	statsCmd.Flags().String("format", "table", "Output format (table, json, csv)")
+
+	groupCmd.AddCommand(statsCmd)
+	return groupCmd
+}
+
+func init() {
+	videosCmd.AddCommand(newTermStatsCmd("tags", models.TaxonomyTag, "tags"))
+	videosCmd.AddCommand(newTermStatsCmd("body-parts", models.TaxonomyBodyPart, "body parts"))
+	videosCmd.AddCommand(newTermStatsCmd("equipment", models.TaxonomyEquipment, "equipment items"))
+}
+
+func outputTermStatsTable(stats *models.TermStats) error {
+	if len(stats.Terms) == 0 {
+		fmt.Println("No videos use any terms yet.")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TERM\tVIDEOS\tBEGINNER\tINTERMEDIATE\tADVANCED\tCATEGORIES")
+
+		for _, usage := range stats.Terms {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n",
+				usage.Term,
+				usage.VideoCount,
+				usage.Beginner,
+				usage.Intermediate,
+				usage.Advanced,
+				strings.Join(usage.Categories, ", "),
+			)
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(stats.SingleUse) > 0 {
+		fmt.Printf("\n⚠️  USED ONLY ONCE (possible typos):\n")
+		for _, term := range stats.SingleUse {
+			fmt.Printf("- %s\n", term)
+		}
+	}
+
+	if len(stats.EmptyCategories) > 0 {
+		fmt.Printf("\n📭 CATEGORIES WITH NO VIDEOS:\n")
+		for _, name := range stats.EmptyCategories {
+			fmt.Printf("- %s\n", name)
+		}
+	}
+
+	return nil
+}
+
+func outputTermStatsCSV(stats *models.TermStats) error {
+	writer := csv.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	header := []string{"Term", "Videos", "Beginner", "Intermediate", "Advanced", "Categories"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, usage := range stats.Terms {
+		record := []string{
+			usage.Term,
+			strconv.Itoa(usage.VideoCount),
+			strconv.Itoa(usage.Beginner),
+			strconv.Itoa(usage.Intermediate),
+			strconv.Itoa(usage.Advanced),
+			strings.Join(usage.Categories, "; "),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
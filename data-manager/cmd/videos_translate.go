@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var videosTranslateCmd = &cobra.Command{
+	Use:   "translate [video-id]",
+	Short: "Set or remove a video translation",
+	Long: `Set the title and/or description of a video in another language.
+
+Only the given fields are changed; the video's own title and description are
+the default (en) texts and are used wherever a translation is missing.
+
+Changing the translation of an approved or published video sends it back to
+draft, so the change is reviewed before it goes live; give --by.
+
+Examples:
+  fisio-data-manager videos translate <video-id> --lang pt --title "Alongamento lombar" --by "Dr. Silva"
+  fisio-data-manager videos translate <video-id> --lang es --description "Estiramiento suave"
+  fisio-data-manager videos translate <video-id> --lang es --remove`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		locale, err := langFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		video, err := services.NewVideoService(db).GetVideoByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		service := services.NewTranslationService(db)
+
+		if remove, _ := cmd.Flags().GetBool("remove"); remove {
+			if err := service.RemoveVideoTranslation(video.ID, locale); err != nil {
+				return err
+			}
+			fmt.Printf("✅ Successfully removed %s translation of video: %s\n", locale, video.Title)
+			return nil
+		}
+
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		by, _ := cmd.Flags().GetString("by")
+
+		reopened, err := service.SetVideoTranslation(video.ID, locale, models.Translation{
+			Title:       title,
+			Description: description,
+		}, by)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully saved %s translation of video: %s\n", locale, video.Title)
+		if reopened {
+			fmt.Printf("⚠️  The video was %s; it is back to %s and needs reviewing again\n", video.Status, models.VideoStatusDraft)
+		}
+		return nil
+	},
+}
+
+var categoriesTranslateCmd = &cobra.Command{
+	Use:   "translate-category [category-name]",
+	Short: "Set or remove a category translation",
+	Long: `Set the name and/or description of a category in another language.
+
+Examples:
+  fisio-data-manager videos translate-category "Back & Spine" --lang pt --name "Costas e coluna"
+  fisio-data-manager videos translate-category "Back & Spine" --lang pt --remove`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		locale, err := langFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		category, err := services.NewVideoService(db).GetCategoryByName(args[0])
+		if err != nil {
+			return err
+		}
+
+		service := services.NewTranslationService(db)
+
+		if remove, _ := cmd.Flags().GetBool("remove"); remove {
+			if err := service.RemoveCategoryTranslation(category.ID, locale); err != nil {
+				return err
+			}
+			fmt.Printf("✅ Successfully removed %s translation of category: %s\n", locale, category.Name)
+			return nil
+		}
+
+		name, _ := cmd.Flags().GetString("name")
+		description, _ := cmd.Flags().GetString("description")
+
+		err = service.SetCategoryTranslation(category.ID, locale, models.Translation{
+			Title:       name,
+			Description: description,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Successfully saved %s translation of category: %s\n", locale, category.Name)
+		return nil
+	},
+}
+
+var videosMissingTranslationsCmd = &cobra.Command{
+	Use:   "missing-translations",
+	Short: "List videos and categories missing translations",
+	Long: `List the videos and categories whose title/name or description has no
+translation, for each language (default: every supported language other than
+the default one).
+
+Examples:
+  fisio-data-manager videos missing-translations
+  fisio-data-manager videos missing-translations --lang pt --format csv > missing_pt.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values, _ := cmd.Flags().GetStringSlice("lang")
+
+		var locales []string
+		for _, value := range values {
+			locale, err := models.ParseLocale(value)
+			if err != nil {
+				return err
+			}
+			if locale != models.DefaultLocale {
+				locales = append(locales, locale)
+			}
+		}
+		if len(locales) == 0 {
+			for _, locale := range models.Locales {
+				if locale != models.DefaultLocale {
+					locales = append(locales, locale)
+				}
+			}
+		}
+
+		db, err := database.Connect()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := services.NewTranslationService(db)
+		missing, err := service.GetMissingTranslations(locales)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "json":
+			return outputJSON(missing)
+		case "csv":
+			return outputMissingTranslationsCSV(missing)
+		default:
+			return outputMissingTranslationsTable(missing, locales)
+		}
+	},
+}
+
+func init() {
+	videosCmd.AddCommand(videosTranslateCmd)
+	videosCmd.AddCommand(categoriesTranslateCmd)
+	videosCmd.AddCommand(videosMissingTranslationsCmd)
+
+	// Translate command flags
+	videosTranslateCmd.Flags().String("lang", "", "Language of the translation (e.g. pt, es) (required)")
+	videosTranslateCmd.Flags().String("title", "", "Translated title")
+	videosTranslateCmd.Flags().String("description", "", "Translated description")
+	videosTranslateCmd.Flags().Bool("remove", false, "Remove the translation")
+	videosTranslateCmd.Flags().String("by", "", "Physiotherapist editing the translation (required for approved or published videos)")
+	videosTranslateCmd.MarkFlagRequired("lang")
+
+	// Translate category command flags
+	categoriesTranslateCmd.Flags().String("lang", "", "Language of the translation (e.g. pt, es) (required)")
+	categoriesTranslateCmd.Flags().String("name", "", "Translated name")
+	categoriesTranslateCmd.Flags().String("description", "", "Translated description")
+	categoriesTranslateCmd.Flags().Bool("remove", false, "Remove the translation")
+	categoriesTranslateCmd.MarkFlagRequired("lang")
+
+	// Missing translations command flags
+	videosMissingTranslationsCmd.Flags().StringSlice("lang", []string{}, "Languages to check (default: all)")
+	videosMissingTranslationsCmd.Flags().String("format", "table", "Output format (table, json, csv)")
+}
+
+// langFlag returns the normalized --lang flag, defaulting to the default
+// locale when it is not set
+func langFlag(cmd *cobra.Command) (string, error) {
+	value, _ := cmd.Flags().GetString("lang")
+	if value == "" {
+		return models.DefaultLocale, nil
+	}
+	return models.ParseLocale(value)
+}
+
+func outputMissingTranslationsTable(missing []models.MissingTranslation, locales []string) error {
+	if len(missing) == 0 {
+		fmt.Printf("✅ Everything is translated (%s)\n", strings.Join(locales, ", "))
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, m := range missing {
+		counts[m.Locale]++
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LANG\tTYPE\tID\tNAME\tMISSING")
+
+	for _, m := range missing {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			m.Locale,
+			m.Kind,
+			m.ID,
+			truncateString(m.Name, 40),
+			strings.Join(m.Missing, ", "),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	for _, locale := range locales {
+		fmt.Printf("%s: %d item(s) missing translations\n", locale, counts[locale])
+	}
+
+	return nil
+}
+
+func outputMissingTranslationsCSV(missing []models.MissingTranslation) error {
+	writer := csv.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Language", "Type", "ID", "Name", "Missing"}); err != nil {
+		return err
+	}
+
+	for _, m := range missing {
+		record := []string{m.Locale, m.Kind, m.ID, m.Name, strings.Join(m.Missing, "; ")}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// Package agenda lays out a clinic's appointments as a time grid: the day
+// cut into slots, with the appointments in each slot and the free time
+// between them.
+package agenda
+
+import (
+	"time"
+
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/models"
+)
+
+// SlotLength is the length of one row of the grid
+const SlotLength = 30 * time.Minute
+
+// Slot is one row of a day's grid
+type Slot struct {
+	Start    time.Time            `json:"start"`
+	Starting []models.AgendaEntry `json:"starting"` // appointments starting in the slot
+	Ongoing  []models.AgendaEntry `json:"ongoing"`  // appointments started earlier, still going on
+}
+
+// Free reports whether no appointment takes up the slot
+func (s Slot) Free() bool {
+	return len(s.Starting) == 0 && len(s.Ongoing) == 0
+}
+
+// Gap is free time during opening hours
+type Gap struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Duration returns the length of the gap
+func (g Gap) Duration() time.Duration {
+	return g.End.Sub(g.Start)
+}
+
+// Day is the agenda of one day
+type Day struct {
+	Date          time.Time            `json:"date"`
+	Entries       []models.AgendaEntry `json:"appointments"`
+	Slots         []Slot               `json:"slots"`
+	Gaps          []Gap                `json:"gaps"`
+	BookedMinutes int                  `json:"booked_minutes"` // time taken by appointments that are not cancelled
+}
+
+// BuildDay lays out the appointments of a date (in start order). The grid
+// covers the opening hours, stretched to fit appointments outside them.
+// Cancelled appointments are shown but do not take up time.
+func BuildDay(c *clinic.Config, date time.Time, entries []models.AgendaEntry) Day {
+	day := Day{Date: date, Entries: []models.AgendaEntry{}, Slots: []Slot{}, Gaps: []Gap{}}
+
+	first, last := c.Opens(date), c.Closes(date)
+	for _, entry := range entries {
+		if !sameDate(entry.AppointmentDate, date) {
+			continue
+		}
+		day.Entries = append(day.Entries, entry)
+		if start := entry.Start(c.Location); start.Before(first) {
+			first = start
+		}
+		if end := entry.End(c.Location); end.After(last) {
+			last = end
+		}
+	}
+
+	// Align the grid on whole slots from midnight
+	midnight := c.At(date, 0)
+	first = midnight.Add(first.Sub(midnight).Truncate(SlotLength))
+	for slot := first; slot.Before(last); slot = slot.Add(SlotLength) {
+		row := Slot{Start: slot, Starting: []models.AgendaEntry{}, Ongoing: []models.AgendaEntry{}}
+		next := slot.Add(SlotLength)
+		for _, entry := range day.Entries {
+			start, end := entry.Start(c.Location), entry.End(c.Location)
+			switch {
+			case !start.Before(slot) && start.Before(next):
+				row.Starting = append(row.Starting, entry)
+			case start.Before(slot) && end.After(slot) && blocks(entry):
+				row.Ongoing = append(row.Ongoing, entry)
+			}
+		}
+		day.Slots = append(day.Slots, row)
+	}
+
+	day.Gaps = FreeTime(c.Opens(date), c.Closes(date), c.Location, day.Entries)
+	for _, entry := range day.Entries {
+		if blocks(entry) {
+			day.BookedMinutes += entry.Duration
+		}
+	}
+
+	return day
+}
+
+// FreeTime returns the free time between opens and closes, around the
+// appointments that are not cancelled
+func FreeTime(opens, closes time.Time, loc *time.Location, entries []models.AgendaEntry) []Gap {
+	gaps := []Gap{}
+	cursor := opens
+	for _, entry := range entries {
+		if !blocks(entry) {
+			continue
+		}
+		start, end := entry.Start(loc), entry.End(loc)
+		if start.After(cursor) {
+			gapEnd := start
+			if gapEnd.After(closes) {
+				gapEnd = closes
+			}
+			if gapEnd.After(cursor) {
+				gaps = append(gaps, Gap{Start: cursor, End: gapEnd})
+			}
+		}
+		if end.After(cursor) {
+			cursor = end
+		}
+	}
+	if closes.After(cursor) {
+		gaps = append(gaps, Gap{Start: cursor, End: closes})
+	}
+	return gaps
+}
+
+// Week returns the dates of the week (Monday to Sunday) of a date
+func Week(date time.Time) []time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	monday := date.AddDate(0, 0, -offset)
+	dates := make([]time.Time, 7)
+	for i := range dates {
+		dates[i] = monday.AddDate(0, 0, i)
+	}
+	return dates
+}
+
+// blocks reports whether an appointment takes up time on the agenda
+func blocks(entry models.AgendaEntry) bool {
+	return entry.Status != models.AppointmentCancelled
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
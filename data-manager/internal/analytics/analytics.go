@@ -0,0 +1,314 @@
+// Package analytics reports how the clinic's appointments went over a date
+// range: bookings, completed sessions, cancellation and no-show rates,
+// utilization of the working hours, lead times and cancellation reasons,
+// in total and by week, month, weekday or hour of the day.
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/availability"
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/models"
+)
+
+// Groupings of the report rows
+const (
+	ByWeek    = "week"    // by week, Monday to Sunday, named after its Monday
+	ByMonth   = "month"   // by calendar month
+	ByWeekday = "weekday" // by day of the week
+	ByHour    = "hour"    // by hour of the day
+)
+
+// Groupings lists the groupings
+var Groupings = []string{ByWeek, ByMonth, ByWeekday, ByHour}
+
+// noReason stands for cancellations recorded without a reason
+const noReason = "(no reason)"
+
+// Row is the activity of one period, or of the whole range
+type Row struct {
+	Period           string  `json:"period"`
+	Bookings         int     `json:"bookings"` // appointments in any status
+	Completed        int     `json:"completed"`
+	Cancelled        int     `json:"cancelled"`
+	NoShows          int     `json:"no_shows"`
+	Upcoming         int     `json:"upcoming"`          // still scheduled or confirmed
+	CancellationRate float64 `json:"cancellation_rate"` // cancelled / bookings
+	NoShowRate       float64 `json:"no_show_rate"`      // no-shows / (completed + no-shows)
+	BookedHours      float64 `json:"booked_hours"`      // appointments that are not cancelled
+	AvailableHours   float64 `json:"available_hours"`   // working hours, without breaks and closures
+	Utilization      float64 `json:"utilization"`       // booked / available hours
+	MedianLeadDays   float64 `json:"median_lead_days"`  // from booking to appointment
+
+	leadDays []float64
+}
+
+// LeadTimes summarizes how long before their appointment patients book
+type LeadTimes struct {
+	MedianDays  float64      `json:"median_days"`
+	AverageDays float64      `json:"average_days"`
+	Buckets     []CountLabel `json:"buckets"`
+}
+
+// CountLabel is a count with what it counts
+type CountLabel struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// leadBuckets are the lead time buckets, by their upper bound in days
+var leadBuckets = []struct {
+	label string
+	days  float64
+}{
+	{"under 1 day", 1},
+	{"1-2 days", 3},
+	{"3-7 days", 8},
+	{"8-14 days", 15},
+	{"15-30 days", 31},
+	{"over 30 days", -1},
+}
+
+// Report is the analytics of a date range
+type Report struct {
+	From                time.Time    `json:"from"`
+	To                  time.Time    `json:"to"`
+	By                  string       `json:"by,omitempty"`
+	Total               Row          `json:"total"`
+	Rows                []Row        `json:"rows"`
+	LeadTimes           LeadTimes    `json:"lead_times"`
+	CancellationReasons []CountLabel `json:"cancellation_reasons"`
+}
+
+// ValidGrouping checks a grouping name ("" for totals only)
+func ValidGrouping(by string) error {
+	if by == "" {
+		return nil
+	}
+	for _, valid := range Groupings {
+		if by == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown grouping '%s' (expected one of %s)", by, strings.Join(Groupings, ", "))
+}
+
+// Build reports on the appointments between two dates (inclusive), grouped
+// by the given grouping ("" for totals only). Available hours come from the
+// working hours of the rules, without the closed time. Only the top
+// cancellation reasons are kept, matched without regard to case.
+func Build(c *clinic.Config, rules *availability.Rules, from, to time.Time, by string, appointments []models.Appointment, closed []availability.Interval, top int) (*Report, error) {
+	if err := ValidGrouping(by); err != nil {
+		return nil, err
+	}
+
+	report := &Report{From: from, To: to, By: by, Rows: []Row{}, CancellationReasons: []CountLabel{}}
+	rows := make(map[string]*Row)
+	row := func(t time.Time) *Row {
+		key := periodKey(by, t.In(c.Location))
+		if rows[key] == nil {
+			rows[key] = &Row{Period: key}
+		}
+		return rows[key]
+	}
+
+	for _, interval := range rules.Open(c, from, to, closed) {
+		for _, piece := range splitHours(interval, c.Location) {
+			hours := piece.End.Sub(piece.Start).Hours()
+			row(piece.Start).AvailableHours += hours
+			report.Total.AvailableHours += hours
+		}
+	}
+
+	reasons := make(map[string]*CountLabel)
+	var reasonOrder []string
+	for _, a := range appointments {
+		if a.AppointmentDate.Before(from) || a.AppointmentDate.After(to) {
+			continue
+		}
+		start := a.Start(c.Location)
+		lead := start.Sub(a.CreatedAt).Hours() / 24
+		if lead < 0 || a.CreatedAt.IsZero() {
+			lead = 0
+		}
+
+		for _, r := range []*Row{&report.Total, row(start)} {
+			r.add(a, lead)
+		}
+		if a.Status != models.AppointmentCancelled {
+			for _, piece := range splitHours(availability.Interval{Start: start, End: a.End(c.Location)}, c.Location) {
+				hours := piece.End.Sub(piece.Start).Hours()
+				row(piece.Start).BookedHours += hours
+				report.Total.BookedHours += hours
+			}
+		} else {
+			reason := strings.TrimSpace(a.CancellationReason)
+			if reason == "" {
+				reason = noReason
+			}
+			key := strings.ToLower(reason)
+			if reasons[key] == nil {
+				reasons[key] = &CountLabel{Label: reason}
+				reasonOrder = append(reasonOrder, key)
+			}
+			reasons[key].Count++
+		}
+	}
+
+	if by != "" {
+		for _, key := range sortedKeys(by, rows) {
+			r := rows[key]
+			if r.Bookings == 0 && r.AvailableHours == 0 {
+				continue
+			}
+			r.finish()
+			report.Rows = append(report.Rows, *r)
+		}
+	}
+
+	report.LeadTimes = leadTimes(report.Total.leadDays)
+	report.Total.Period = "total"
+	report.Total.finish()
+
+	for _, key := range reasonOrder {
+		report.CancellationReasons = append(report.CancellationReasons, *reasons[key])
+	}
+	sort.SliceStable(report.CancellationReasons, func(i, j int) bool {
+		return report.CancellationReasons[i].Count > report.CancellationReasons[j].Count
+	})
+	if top > 0 && len(report.CancellationReasons) > top {
+		report.CancellationReasons = report.CancellationReasons[:top]
+	}
+
+	return report, nil
+}
+
+// add counts an appointment booked lead days ahead
+func (r *Row) add(a models.Appointment, lead float64) {
+	r.Bookings++
+	switch a.Status {
+	case models.AppointmentCompleted:
+		r.Completed++
+	case models.AppointmentCancelled:
+		r.Cancelled++
+	case models.AppointmentNoShow:
+		r.NoShows++
+	default:
+		r.Upcoming++
+	}
+	r.leadDays = append(r.leadDays, lead)
+}
+
+// finish works out the rates of the row from its counts
+func (r *Row) finish() {
+	if r.Bookings > 0 {
+		r.CancellationRate = float64(r.Cancelled) / float64(r.Bookings)
+	}
+	if due := r.Completed + r.NoShows; due > 0 {
+		r.NoShowRate = float64(r.NoShows) / float64(due)
+	}
+	if r.AvailableHours > 0 {
+		r.Utilization = r.BookedHours / r.AvailableHours
+	}
+	r.MedianLeadDays = median(r.leadDays)
+}
+
+// leadTimes summarizes lead times in days
+func leadTimes(days []float64) LeadTimes {
+	lead := LeadTimes{MedianDays: median(days), Buckets: make([]CountLabel, len(leadBuckets))}
+	for i, bucket := range leadBuckets {
+		lead.Buckets[i].Label = bucket.label
+	}
+
+	total := 0.0
+	for _, d := range days {
+		total += d
+		for i, bucket := range leadBuckets {
+			if bucket.days < 0 || d < bucket.days {
+				lead.Buckets[i].Count++
+				break
+			}
+		}
+	}
+	if len(days) > 0 {
+		lead.AverageDays = total / float64(len(days))
+	}
+	return lead
+}
+
+// median returns the middle value, or 0 for no values
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// periodKey names the period of a grouping a time falls in
+func periodKey(by string, t time.Time) string {
+	switch by {
+	case ByWeek:
+		monday := t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+		return monday.Format("2006-01-02")
+	case ByMonth:
+		return t.Format("2006-01")
+	case ByWeekday:
+		return t.Weekday().String()
+	case ByHour:
+		return t.Format("15") + ":00"
+	default:
+		return ""
+	}
+}
+
+// sortedKeys returns the period names in time order, Monday first for
+// weekdays
+func sortedKeys(by string, rows map[string]*Row) []string {
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if by == ByWeekday {
+			return weekdayIndex(keys[i]) < weekdayIndex(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// weekdayIndex returns the position of a weekday name in a Monday-first week
+func weekdayIndex(name string) int {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if day.String() == name {
+			return (int(day) + 6) % 7
+		}
+	}
+	return 7
+}
+
+// splitHours cuts an interval at the hours of the clock, so each piece falls
+// within one hour of one day
+func splitHours(interval availability.Interval, loc *time.Location) []availability.Interval {
+	pieces := []availability.Interval{}
+	for start := interval.Start; start.Before(interval.End); {
+		t := start.In(loc)
+		end := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		if end.After(interval.End) {
+			end = interval.End
+		}
+		pieces = append(pieces, availability.Interval{Start: start, End: end})
+		start = end
+	}
+	return pieces
+}
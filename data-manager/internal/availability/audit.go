@@ -0,0 +1,224 @@
+package availability
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/models"
+)
+
+// Kinds of audit issues
+const (
+	// Appointments sharing some of their time
+	IssueOverlap = "overlap"
+	// An appointment outside the working hours, or during a break
+	IssueOutsideHours = "outside_hours"
+	// An upcoming appointment without a Google Calendar event ID
+	IssueNoEvent = "no_event"
+)
+
+// suggestionDays is how many days after an appointment a new slot is looked
+// for when suggesting to move it
+const suggestionDays = 14
+
+// Issue is a problem with one appointment, or a group of overlapping ones
+type Issue struct {
+	Kind         string               `json:"kind"`
+	Start        time.Time            `json:"start"`
+	End          time.Time            `json:"end"`
+	Detail       string               `json:"detail"`
+	Suggestion   string               `json:"suggestion"`
+	Appointments []models.Appointment `json:"appointments"`
+}
+
+// Audit checks the appointments that are not cancelled between two dates
+// (inclusive) for overlaps, times outside the working hours and missing
+// calendar events, and suggests how to resolve each issue. Moves are
+// suggested to the first free slot in the two weeks after the appointment, so
+// appointments and closed must cover those weeks as well. Issues are in time
+// order.
+func (r *Rules) Audit(c *clinic.Config, from, to, now time.Time, appointments []models.Appointment, closed []Interval) []Issue {
+	active := []models.Appointment{}
+	for _, a := range appointments {
+		if a.Status != models.AppointmentCancelled {
+			active = append(active, a)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].Start(c.Location).Before(active[j].Start(c.Location))
+	})
+
+	inRange := func(a models.Appointment) bool {
+		return !a.AppointmentDate.Before(from) && !a.AppointmentDate.After(to)
+	}
+	s := suggester{rules: r, clinic: c, now: now, appointments: active, closed: closed}
+	issues := []Issue{}
+
+	// Overlaps, grouping appointments that overlap one another in a chain
+	for i := 0; i < len(active); {
+		group := []models.Appointment{active[i]}
+		end := active[i].End(c.Location)
+		for i++; i < len(active) && active[i].Start(c.Location).Before(end); i++ {
+			group = append(group, active[i])
+			if e := active[i].End(c.Location); e.After(end) {
+				end = e
+			}
+		}
+		// Groups reaching into the range from either side are reported too
+		reported := false
+		for _, a := range group {
+			if inRange(a) {
+				reported = true
+				break
+			}
+		}
+		if len(group) > 1 && reported {
+			issues = append(issues, Issue{
+				Kind:         IssueOverlap,
+				Start:        group[0].Start(c.Location),
+				End:          end,
+				Detail:       fmt.Sprintf("%d appointments overlap", len(group)),
+				Suggestion:   s.resolveOverlap(group),
+				Appointments: group,
+			})
+		}
+	}
+
+	for _, a := range active {
+		if !inRange(a) {
+			continue
+		}
+		start, end := a.Start(c.Location), a.End(c.Location)
+
+		if detail := r.outsideHours(c, a); detail != "" {
+			suggestion := "Already took place; check the time was recorded correctly"
+			if start.After(now) {
+				suggestion = "Confirm the extra hours with the therapist, or " + s.move(a)
+			}
+			issues = append(issues, Issue{
+				Kind:         IssueOutsideHours,
+				Start:        start,
+				End:          end,
+				Detail:       detail,
+				Suggestion:   suggestion,
+				Appointments: []models.Appointment{a},
+			})
+		}
+
+		upcoming := a.Status == models.AppointmentScheduled || a.Status == models.AppointmentConfirmed
+		if upcoming && a.GoogleEventID == "" {
+			issues = append(issues, Issue{
+				Kind:         IssueNoEvent,
+				Start:        start,
+				End:          end,
+				Detail:       "no Google Calendar event ID",
+				Suggestion:   "Run appointments reconcile-calendar to link its event, or create the event on the calendar",
+				Appointments: []models.Appointment{a},
+			})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Start.Before(issues[j].Start) })
+	return issues
+}
+
+// outsideHours describes how an appointment falls outside the working hours
+// or into a break, or returns "" when it does not
+func (r *Rules) outsideHours(c *clinic.Config, a models.Appointment) string {
+	date := a.AppointmentDate
+	appointment := Interval{Start: a.Start(c.Location), End: a.End(c.Location)}
+
+	hours := periodsOn(c, date, r.WorkingHours[dayName(date)])
+	if len(hours) == 0 {
+		return fmt.Sprintf("the clinic does not work on %ss", date.Weekday())
+	}
+
+	within := false
+	for _, period := range hours {
+		if !appointment.Start.Before(period.Start) && !appointment.End.After(period.End) {
+			within = true
+			break
+		}
+	}
+	if !within {
+		spans := make([]string, 0, len(hours))
+		for _, period := range r.WorkingHours[dayName(date)] {
+			spans = append(spans, period.Start+"-"+period.End)
+		}
+		return fmt.Sprintf("outside the working hours (%s)", strings.Join(spans, ", "))
+	}
+
+	for i, period := range periodsOn(c, date, r.Breaks) {
+		if appointment.Overlaps(period) {
+			return fmt.Sprintf("during the break (%s-%s)", r.Breaks[i].Start, r.Breaks[i].End)
+		}
+	}
+	return ""
+}
+
+// suggester suggests free slots to move appointments to, keeping track of
+// the slots it already suggested
+type suggester struct {
+	rules        *Rules
+	clinic       *clinic.Config
+	now          time.Time
+	appointments []models.Appointment
+	closed       []Interval
+	suggested    []Interval
+}
+
+// resolveOverlap suggests how to resolve a group of overlapping appointments
+func (s *suggester) resolveOverlap(group []models.Appointment) string {
+	// The appointment booked first keeps its time
+	byBooking := append([]models.Appointment{}, group...)
+	sort.SliceStable(byBooking, func(i, j int) bool { return byBooking[i].CreatedAt.Before(byBooking[j].CreatedAt) })
+	keep, others := byBooking[0], byBooking[1:]
+
+	samePatient := true
+	for _, a := range others {
+		if !strings.EqualFold(strings.TrimSpace(a.PatientEmail), strings.TrimSpace(keep.PatientEmail)) {
+			samePatient = false
+		}
+	}
+	if samePatient {
+		return fmt.Sprintf("Same patient booked more than once: keep %s and cancel the later booking(s)", keep.ID)
+	}
+
+	if !keep.Start(s.clinic.Location).After(s.now) {
+		return "Already took place: mark the patients who were not seen as no-show"
+	}
+
+	moves := make([]string, 0, len(others))
+	for _, a := range others {
+		moves = append(moves, fmt.Sprintf("%s: %s", a.PatientName, s.move(a)))
+	}
+	return fmt.Sprintf("Keep %s (booked first); %s", keep.PatientName, strings.Join(moves, "; "))
+}
+
+// move suggests a free slot for an appointment. The minimum notice and
+// maximum advance limit what patients can book, not where staff can move an
+// appointment, so they do not apply.
+func (s *suggester) move(a models.Appointment) string {
+	rules := *s.rules
+	rules.MinNoticeHours, rules.MaxAdvanceDays = 0, 0
+	if a.Duration > 0 {
+		rules.SessionMinutes = a.Duration
+	}
+
+	booked := append([]Interval{}, s.suggested...)
+	for _, other := range s.appointments {
+		if other.ID != a.ID {
+			booked = append(booked, Interval{Start: other.Start(s.clinic.Location), End: other.End(s.clinic.Location)})
+		}
+	}
+
+	slots := rules.Slots(s.clinic, a.AppointmentDate, a.AppointmentDate.AddDate(0, 0, suggestionDays), s.now, booked, s.closed)
+	if len(slots) == 0 {
+		return fmt.Sprintf("no free slot in the %d days after it; contact the patient", suggestionDays)
+	}
+	s.suggested = append(s.suggested, Interval(slots[0]))
+	return "move to " + slots[0].Start.Format("Mon 2006-01-02 15:04")
+}
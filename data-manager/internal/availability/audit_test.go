@@ -0,0 +1,210 @@
+package availability
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/models"
+)
+
+func TestAudit(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &clinic.Config{Location: loc, OpensAt: 9 * time.Hour, ClosesAt: 17 * time.Hour}
+
+	date := func(value string) time.Time {
+		d, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	at := func(value string) time.Time {
+		ts, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	// appointment books a patient for an hour; patients are told apart by
+	// their email, and the order of the calls is the order of booking
+	booked := at("2026-10-01 12:00")
+	appointment := func(id, patient, day, clock string) models.Appointment {
+		booked = booked.Add(time.Hour)
+		return models.Appointment{
+			ID:              id,
+			PatientName:     patient,
+			PatientEmail:    patient + "@example.com",
+			AppointmentDate: date(day),
+			AppointmentTime: clock,
+			Duration:        60,
+			Status:          models.AppointmentScheduled,
+			GoogleEventID:   "event-" + id,
+			CreatedAt:       booked,
+		}
+	}
+	allDay := func(r *Rules) {
+		r.WorkingHours = map[string][]Period{"monday": {{"00:00", "24:00"}}, "tuesday": {{"00:00", "24:00"}}}
+	}
+
+	// A Friday; the audit looks at the week after
+	now := at("2026-10-16 12:00")
+	monday, tuesday := "2026-10-19", "2026-10-20"
+
+	tests := []struct {
+		name         string
+		from, to     string
+		now          time.Time
+		rules        func(*Rules)
+		appointments []models.Appointment
+		closed       []Interval
+		want         []string
+	}{
+		{
+			name:         "overlap: the first booked keeps its time, the others move",
+			from:         monday,
+			to:           monday,
+			appointments: []models.Appointment{appointment("1", "ana", monday, "10:00"), appointment("2", "ben", monday, "10:30")},
+			want:         []string{"overlap Mon 2026-10-19 10:00: Keep ana (booked first); ben: move to Mon 2026-10-19 09:00"},
+		},
+		{
+			name:         "overlap: booking order, not time order, decides who keeps the time",
+			from:         monday,
+			to:           monday,
+			appointments: []models.Appointment{appointment("2", "ben", monday, "10:30"), appointment("1", "ana", monday, "10:00")},
+			want:         []string{"overlap Mon 2026-10-19 10:00: Keep ben (booked first); ana: move to Mon 2026-10-19 09:00"},
+		},
+		{
+			name:         "overlap: moves do not take the same slot twice",
+			from:         monday,
+			to:           monday,
+			appointments: []models.Appointment{appointment("1", "ana", monday, "10:00"), appointment("2", "ben", monday, "10:00"), appointment("3", "cat", monday, "10:30")},
+			want:         []string{"overlap Mon 2026-10-19 10:00: Keep ana (booked first); ben: move to Mon 2026-10-19 09:00; cat: move to Mon 2026-10-19 11:00"},
+		},
+		{
+			name:         "overlap: moves are not limited by the maximum advance",
+			from:         "2026-12-14",
+			to:           "2026-12-14",
+			appointments: []models.Appointment{appointment("1", "ana", "2026-12-14", "10:00"), appointment("2", "ben", "2026-12-14", "10:30")},
+			want:         []string{"overlap Mon 2026-12-14 10:00: Keep ana (booked first); ben: move to Mon 2026-12-14 09:00"},
+		},
+		{
+			name:         "overlap: moves are not limited by the minimum notice",
+			from:         monday,
+			to:           monday,
+			rules:        func(r *Rules) { r.MinNoticeHours = 72 },
+			appointments: []models.Appointment{appointment("1", "ana", monday, "10:00"), appointment("2", "ben", monday, "10:30")},
+			want:         []string{"overlap Mon 2026-10-19 10:00: Keep ana (booked first); ben: move to Mon 2026-10-19 09:00"},
+		},
+		{
+			name:         "overlap: same patient booked twice",
+			from:         monday,
+			to:           monday,
+			appointments: []models.Appointment{appointment("1", "ana", monday, "10:00"), appointment("2", "ana", monday, "10:00")},
+			want:         []string{"overlap Mon 2026-10-19 10:00: Same patient booked more than once: keep 1 and cancel the later booking(s)"},
+		},
+		{
+			name:         "overlap: already took place",
+			from:         monday,
+			to:           monday,
+			now:          at("2026-10-19 12:00"),
+			appointments: []models.Appointment{appointment("1", "ana", monday, "10:00"), appointment("2", "ben", monday, "10:30")},
+			want:         []string{"overlap Mon 2026-10-19 10:00: Already took place: mark the patients who were not seen as no-show"},
+		},
+		{
+			name:         "overlap: no free slot to move to",
+			from:         monday,
+			to:           monday,
+			appointments: []models.Appointment{appointment("1", "ana", monday, "10:00"), appointment("2", "ben", monday, "10:30")},
+			closed:       []Interval{{Start: at("2026-10-19 11:00"), End: at("2026-11-03 00:00")}, {Start: at("2026-10-19 09:00"), End: at("2026-10-19 10:00")}},
+			want:         []string{"overlap Mon 2026-10-19 10:00: Keep ana (booked first); ben: no free slot in the 14 days after it; contact the patient"},
+		},
+		{
+			name:         "overlap: reported when only a later appointment is in range",
+			from:         tuesday,
+			to:           tuesday,
+			rules:        allDay,
+			appointments: []models.Appointment{appointment("1", "ana", monday, "23:30"), appointment("2", "ben", tuesday, "00:00")},
+			want:         []string{"overlap Mon 2026-10-19 23:30: Keep ana (booked first); ben: move to Tue 2026-10-20 01:00"},
+		},
+		{
+			name:         "overlap: not reported when no appointment is in range",
+			from:         "2026-10-21",
+			to:           "2026-10-21",
+			rules:        allDay,
+			appointments: []models.Appointment{appointment("1", "ana", monday, "23:30"), appointment("2", "ben", tuesday, "00:00")},
+			want:         []string{},
+		},
+		{
+			name:         "outside hours: upcoming appointments are moved",
+			from:         "2026-10-24",
+			to:           "2026-10-24",
+			appointments: []models.Appointment{appointment("1", "ana", "2026-10-24", "10:00")},
+			want:         []string{"outside_hours Sat 2026-10-24 10:00: Confirm the extra hours with the therapist, or move to Mon 2026-10-26 09:00"},
+		},
+		{
+			name:         "outside hours: during a break",
+			from:         monday,
+			to:           monday,
+			rules:        func(r *Rules) { r.Breaks = []Period{{"12:00", "13:00"}} },
+			appointments: []models.Appointment{appointment("1", "ana", monday, "12:30")},
+			want:         []string{"outside_hours Mon 2026-10-19 12:30: Confirm the extra hours with the therapist, or move to Mon 2026-10-19 09:00"},
+		},
+		{
+			name:         "outside hours: past appointments are checked, not moved",
+			from:         monday,
+			to:           monday,
+			now:          at("2026-10-20 12:00"),
+			appointments: []models.Appointment{appointment("1", "ana", monday, "18:00")},
+			want:         []string{"outside_hours Mon 2026-10-19 18:00: Already took place; check the time was recorded correctly"},
+		},
+		{
+			name: "no event",
+			from: monday,
+			to:   monday,
+			appointments: func() []models.Appointment {
+				a := appointment("1", "ana", monday, "10:00")
+				a.GoogleEventID = ""
+				return []models.Appointment{a}
+			}(),
+			want: []string{"no_event Mon 2026-10-19 10:00: Run appointments reconcile-calendar to link its event, or create the event on the calendar"},
+		},
+		{
+			name: "cancelled appointments are left out",
+			from: monday,
+			to:   monday,
+			appointments: func() []models.Appointment {
+				a := appointment("2", "ben", monday, "10:30")
+				a.Status = models.AppointmentCancelled
+				return []models.Appointment{appointment("1", "ana", monday, "10:00"), a}
+			}(),
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules(c)
+			if tt.rules != nil {
+				tt.rules(&rules)
+			}
+			current := tt.now
+			if current.IsZero() {
+				current = now
+			}
+
+			got := []string{}
+			for _, issue := range rules.Audit(c, date(tt.from), date(tt.to), current, tt.appointments, tt.closed) {
+				got = append(got, fmt.Sprintf("%s %s: %s", issue.Kind, issue.Start.In(loc).Format("Mon 2006-01-02 15:04"), issue.Suggestion))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Audit() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
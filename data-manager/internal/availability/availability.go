@@ -0,0 +1,294 @@
+// Package availability computes the bookable appointment slots from the
+// clinic's booking rules: weekly working hours, breaks, buffers between
+// sessions, minimum notice, closures and the appointments already booked.
+//
+// Slots are a pure function of the rules, the date range, the current time,
+// the closures and the booked appointments, so the same inputs always give
+// the same slots.
+package availability
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/models"
+)
+
+// Weekdays are the keys of Rules.WorkingHours, Monday first
+var Weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// Rules configures when appointments can be booked
+type Rules struct {
+	// Working hours by weekday (monday ... sunday); days missing or set to []
+	// are closed
+	WorkingHours map[string][]Period `json:"working_hours"`
+	// Breaks taken every working day, such as lunch
+	Breaks []Period `json:"breaks"`
+	// Length of a session, and so of a slot
+	SessionMinutes int `json:"session_minutes"`
+	// Minutes between the starts of two slots (0 for the session length)
+	StepMinutes int `json:"step_minutes"`
+	// Free time kept before and after every booked appointment
+	BufferMinutes int `json:"buffer_minutes"`
+	// How long before it starts a slot can still be booked
+	MinNoticeHours int `json:"min_notice_hours"`
+	// How many days ahead slots are offered (0 for no limit)
+	MaxAdvanceDays int `json:"max_advance_days"`
+}
+
+// Period is a part of the day, from Start (inclusive) to End (exclusive),
+// as HH:MM
+type Period struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Interval is a span of time
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Slot is a bookable slot
+type Slot Interval
+
+// DefaultRules returns the booking website's rules: sessions of an hour,
+// on the hour, Monday to Friday during the clinic's opening hours, up to
+// 30 days ahead
+func DefaultRules(c *clinic.Config) Rules {
+	hours := []Period{{Start: clinic.FormatClock(c.OpensAt), End: clinic.FormatClock(c.ClosesAt)}}
+	return Rules{
+		WorkingHours: map[string][]Period{
+			"monday":    hours,
+			"tuesday":   hours,
+			"wednesday": hours,
+			"thursday":  hours,
+			"friday":    hours,
+		},
+		Breaks:         []Period{},
+		SessionMinutes: 60,
+		MaxAdvanceDays: 30,
+	}
+}
+
+// LoadRules reads rules from a JSON file. Fields missing from the file keep
+// their default values; working hours given in the file replace the default
+// week as a whole.
+func LoadRules(path string, defaults Rules) (Rules, error) {
+	rules := defaults
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return rules, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+	if _, ok := fields["working_hours"]; ok {
+		rules.WorkingHours = nil
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return rules, err
+	}
+
+	return rules, nil
+}
+
+// Validate checks the weekdays, times and lengths of the rules
+func (r *Rules) Validate() error {
+	for day, periods := range r.WorkingHours {
+		if weekday(day) < 0 {
+			return fmt.Errorf("unknown weekday '%s' in working hours (expected one of %s)", day, strings.Join(Weekdays, ", "))
+		}
+		for _, period := range periods {
+			if _, _, err := period.parse(); err != nil {
+				return fmt.Errorf("invalid working hours on %s: %w", day, err)
+			}
+		}
+	}
+	for _, period := range r.Breaks {
+		if _, _, err := period.parse(); err != nil {
+			return fmt.Errorf("invalid break: %w", err)
+		}
+	}
+	if r.SessionMinutes <= 0 {
+		return fmt.Errorf("session_minutes must be positive")
+	}
+	if r.StepMinutes < 0 || r.BufferMinutes < 0 || r.MinNoticeHours < 0 || r.MaxAdvanceDays < 0 {
+		return fmt.Errorf("step_minutes, buffer_minutes, min_notice_hours and max_advance_days must not be negative")
+	}
+	return nil
+}
+
+// Slots returns the bookable slots between two dates (inclusive) at the
+// clinic, in order. A slot is bookable when it falls within the working
+// hours, misses the breaks, starts at least the minimum notice after now,
+// is no more than the maximum advance ahead, misses the closures and keeps
+// the buffer clear of every booked appointment. The rules must be valid.
+func (r *Rules) Slots(c *clinic.Config, from, to, now time.Time, booked, closed []Interval) []Slot {
+	session := time.Duration(r.SessionMinutes) * time.Minute
+	step := time.Duration(r.StepMinutes) * time.Minute
+	if step == 0 {
+		step = session
+	}
+	buffer := time.Duration(r.BufferMinutes) * time.Minute
+	earliest := now.Add(time.Duration(r.MinNoticeHours) * time.Hour)
+
+	last := to
+	if r.MaxAdvanceDays > 0 {
+		if limit := c.Today(now).AddDate(0, 0, r.MaxAdvanceDays); limit.Before(last) {
+			last = limit
+		}
+	}
+
+	slots := []Slot{}
+	for date := from; !date.After(last); date = date.AddDate(0, 0, 1) {
+		breaks := periodsOn(c, date, r.Breaks)
+		for _, hours := range periodsOn(c, date, r.WorkingHours[dayName(date)]) {
+			for start := hours.Start; !start.Add(session).After(hours.End); start = start.Add(step) {
+				slot := Interval{Start: start, End: start.Add(session)}
+				if start.Before(earliest) || overlapsAny(slot, breaks, 0) ||
+					overlapsAny(slot, closed, 0) || overlapsAny(slot, booked, buffer) {
+					continue
+				}
+				slots = append(slots, Slot(slot))
+			}
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+	return slots
+}
+
+// Open returns the working time between two dates (inclusive) at the clinic,
+// without the breaks and the closed time, in order
+func (r *Rules) Open(c *clinic.Config, from, to time.Time, closed []Interval) []Interval {
+	open := []Interval{}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		cuts := append(periodsOn(c, date, r.Breaks), closed...)
+		for _, hours := range periodsOn(c, date, r.WorkingHours[dayName(date)]) {
+			open = append(open, subtract(hours, cuts)...)
+		}
+	}
+
+	sort.SliceStable(open, func(i, j int) bool { return open[i].Start.Before(open[j].Start) })
+	return open
+}
+
+// Booked returns the time taken by the appointments that are not cancelled
+func Booked(c *clinic.Config, appointments []models.Appointment) []Interval {
+	busy := []Interval{}
+	for _, a := range appointments {
+		if a.Status == models.AppointmentCancelled {
+			continue
+		}
+		busy = append(busy, Interval{Start: a.Start(c.Location), End: a.End(c.Location)})
+	}
+	return busy
+}
+
+// Closed returns the time the clinic is closed between two dates (inclusive)
+func Closed(c *clinic.Config, closures []models.Closure, from, to time.Time) []Interval {
+	closed := []Interval{}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		for _, closure := range closures {
+			if start, end, ok := closure.Period(date, c.Location); ok {
+				closed = append(closed, Interval{Start: start, End: end})
+			}
+		}
+	}
+	return closed
+}
+
+// Overlaps reports whether two intervals share any time
+func (i Interval) Overlaps(other Interval) bool {
+	return i.Start.Before(other.End) && other.Start.Before(i.End)
+}
+
+// overlapsAny reports whether the interval comes within buffer of any of the
+// others
+func overlapsAny(interval Interval, others []Interval, buffer time.Duration) bool {
+	for _, other := range others {
+		widened := Interval{Start: other.Start.Add(-buffer), End: other.End.Add(buffer)}
+		if interval.Overlaps(widened) {
+			return true
+		}
+	}
+	return false
+}
+
+// subtract returns what is left of the interval outside the cuts
+func subtract(interval Interval, cuts []Interval) []Interval {
+	pieces := []Interval{interval}
+	for _, cut := range cuts {
+		var left []Interval
+		for _, piece := range pieces {
+			if !piece.Overlaps(cut) {
+				left = append(left, piece)
+				continue
+			}
+			if piece.Start.Before(cut.Start) {
+				left = append(left, Interval{Start: piece.Start, End: cut.Start})
+			}
+			if cut.End.Before(piece.End) {
+				left = append(left, Interval{Start: cut.End, End: piece.End})
+			}
+		}
+		pieces = left
+	}
+	return pieces
+}
+
+// periodsOn returns the periods on a date at the clinic
+func periodsOn(c *clinic.Config, date time.Time, periods []Period) []Interval {
+	intervals := make([]Interval, 0, len(periods))
+	for _, period := range periods {
+		start, end, err := period.parse()
+		if err != nil {
+			continue
+		}
+		intervals = append(intervals, Interval{Start: c.At(date, start), End: c.At(date, end)})
+	}
+	return intervals
+}
+
+// parse returns the start and end of the period as times since midnight
+func (p Period) parse() (time.Duration, time.Duration, error) {
+	start, err := clinic.ParseClock(p.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := clinic.ParseClock(p.End)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end <= start {
+		return 0, 0, fmt.Errorf("%s-%s ends before it starts", p.Start, p.End)
+	}
+	return start, end, nil
+}
+
+// dayName returns the key of a date's weekday in Rules.WorkingHours
+func dayName(date time.Time) string {
+	return Weekdays[(int(date.Weekday())+6)%7]
+}
+
+// weekday returns the index of a weekday name in Weekdays, or -1
+func weekday(name string) int {
+	for i, day := range Weekdays {
+		if day == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,215 @@
+package availability
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"fisio-data-manager/internal/clinic"
+	"fisio-data-manager/internal/models"
+)
+
+func TestSlots(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &clinic.Config{Location: loc, OpensAt: 9 * time.Hour, ClosesAt: 13 * time.Hour}
+
+	date := func(value string) time.Time {
+		d, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	at := func(value string) time.Time {
+		ts, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	appointment := func(day, clock, status string) models.Appointment {
+		return models.Appointment{AppointmentDate: date(day), AppointmentTime: clock, Duration: 60, Status: status}
+	}
+
+	// A Friday; the slots are looked for from the Monday after
+	now := at("2026-10-16 12:00")
+	monday := "2026-10-19"
+
+	tests := []struct {
+		name         string
+		rules        func(*Rules)
+		from, to     string
+		now          time.Time
+		appointments []models.Appointment
+		closures     []models.Closure
+		want         []string
+	}{
+		{
+			name: "working hours by weekday",
+			from: monday,
+			to:   "2026-10-25",
+			rules: func(r *Rules) {
+				r.WorkingHours = map[string][]Period{"monday": {{"09:00", "13:00"}}, "wednesday": {{"14:00", "16:00"}}}
+			},
+			want: []string{
+				"2026-10-19 09:00-10:00", "2026-10-19 10:00-11:00", "2026-10-19 11:00-12:00", "2026-10-19 12:00-13:00",
+				"2026-10-21 14:00-15:00", "2026-10-21 15:00-16:00",
+			},
+		},
+		{
+			name:  "sessions that do not fit before closing are left out",
+			from:  monday,
+			to:    monday,
+			rules: func(r *Rules) { r.SessionMinutes = 90 },
+			want:  []string{"2026-10-19 09:00-10:30", "2026-10-19 10:30-12:00"},
+		},
+		{
+			name:  "breaks",
+			from:  monday,
+			to:    monday,
+			rules: func(r *Rules) { r.Breaks = []Period{{"10:00", "10:30"}} },
+			want:  []string{"2026-10-19 09:00-10:00", "2026-10-19 11:00-12:00", "2026-10-19 12:00-13:00"},
+		},
+		{
+			name:  "slot step",
+			from:  monday,
+			to:    monday,
+			rules: func(r *Rules) { r.StepMinutes = 30 },
+			want: []string{
+				"2026-10-19 09:00-10:00", "2026-10-19 09:30-10:30", "2026-10-19 10:00-11:00",
+				"2026-10-19 10:30-11:30", "2026-10-19 11:00-12:00", "2026-10-19 11:30-12:30", "2026-10-19 12:00-13:00",
+			},
+		},
+		{
+			name:  "slot step around a break",
+			from:  monday,
+			to:    monday,
+			rules: func(r *Rules) { r.StepMinutes = 30; r.Breaks = []Period{{"10:00", "10:30"}} },
+			want: []string{
+				"2026-10-19 09:00-10:00", "2026-10-19 10:30-11:30", "2026-10-19 11:00-12:00",
+				"2026-10-19 11:30-12:30", "2026-10-19 12:00-13:00",
+			},
+		},
+		{
+			name:         "booked appointments",
+			from:         monday,
+			to:           monday,
+			appointments: []models.Appointment{appointment(monday, "10:00", models.AppointmentScheduled)},
+			want:         []string{"2026-10-19 09:00-10:00", "2026-10-19 11:00-12:00", "2026-10-19 12:00-13:00"},
+		},
+		{
+			name:         "buffer around booked appointments",
+			from:         monday,
+			to:           monday,
+			rules:        func(r *Rules) { r.BufferMinutes = 15 },
+			appointments: []models.Appointment{appointment(monday, "10:00", models.AppointmentConfirmed)},
+			want:         []string{"2026-10-19 12:00-13:00"},
+		},
+		{
+			name:  "cancelled appointments do not block slots",
+			from:  monday,
+			to:    monday,
+			rules: func(r *Rules) { r.BufferMinutes = 15 },
+			appointments: []models.Appointment{
+				appointment(monday, "10:00", models.AppointmentCancelled),
+				appointment(monday, "12:00", models.AppointmentCompleted),
+			},
+			want: []string{"2026-10-19 09:00-10:00", "2026-10-19 10:00-11:00"},
+		},
+		{
+			name:  "minimum notice",
+			from:  monday,
+			to:    monday,
+			now:   at("2026-10-19 08:30"),
+			rules: func(r *Rules) { r.MinNoticeHours = 2 },
+			want:  []string{"2026-10-19 11:00-12:00", "2026-10-19 12:00-13:00"},
+		},
+		{
+			name: "slots in the past are left out",
+			from: monday,
+			to:   monday,
+			now:  at("2026-10-19 10:30"),
+			want: []string{"2026-10-19 11:00-12:00", "2026-10-19 12:00-13:00"},
+		},
+		{
+			name: "maximum advance",
+			from: monday,
+			to:   "2026-10-26",
+			rules: func(r *Rules) {
+				r.MaxAdvanceDays = 3
+				r.WorkingHours = map[string][]Period{"monday": {{"09:00", "10:00"}}}
+			},
+			want: []string{"2026-10-19 09:00-10:00"},
+		},
+		{
+			name: "no maximum advance",
+			from: monday,
+			to:   "2026-10-26",
+			rules: func(r *Rules) {
+				r.MaxAdvanceDays = 0
+				r.WorkingHours = map[string][]Period{"monday": {{"09:00", "10:00"}}}
+			},
+			want: []string{"2026-10-19 09:00-10:00", "2026-10-26 09:00-10:00"},
+		},
+		{
+			name:     "whole day closures",
+			from:     monday,
+			to:       "2026-10-20",
+			closures: []models.Closure{{Kind: models.ClosureHoliday, StartDate: date(monday), EndDate: date(monday)}},
+			want: []string{
+				"2026-10-20 09:00-10:00", "2026-10-20 10:00-11:00", "2026-10-20 11:00-12:00", "2026-10-20 12:00-13:00",
+			},
+		},
+		{
+			name:     "blocked hours",
+			from:     monday,
+			to:       monday,
+			closures: []models.Closure{{Kind: models.ClosureBlocked, StartDate: date(monday), EndDate: date(monday), StartTime: "10:30", EndTime: "12:00"}},
+			want:     []string{"2026-10-19 09:00-10:00", "2026-10-19 12:00-13:00"},
+		},
+		{
+			name:     "clocks going back keep the working hours",
+			from:     "2026-11-01",
+			to:       "2026-11-01",
+			rules:    func(r *Rules) { r.WorkingHours = map[string][]Period{"sunday": {{"09:00", "11:00"}}} },
+			closures: []models.Closure{{Kind: models.ClosureBlocked, StartDate: date("2026-11-01"), EndDate: date("2026-11-01"), StartTime: "10:00", EndTime: "10:30"}},
+			want:     []string{"2026-11-01 09:00-10:00"},
+		},
+		{
+			name:  "clocks going forward keep the working hours",
+			from:  "2026-03-08",
+			to:    "2026-03-08",
+			now:   at("2026-03-01 12:00"),
+			rules: func(r *Rules) { r.WorkingHours = map[string][]Period{"sunday": {{"09:00", "11:00"}}} },
+			want:  []string{"2026-03-08 09:00-10:00", "2026-03-08 10:00-11:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules(c)
+			if tt.rules != nil {
+				tt.rules(&rules)
+			}
+			if err := rules.Validate(); err != nil {
+				t.Fatalf("Validate() = %v", err)
+			}
+			current := tt.now
+			if current.IsZero() {
+				current = now
+			}
+			from, to := date(tt.from), date(tt.to)
+
+			got := []string{}
+			for _, slot := range rules.Slots(c, from, to, current, Booked(c, tt.appointments), Closed(c, tt.closures, from, to)) {
+				got = append(got, slot.Start.In(loc).Format("2006-01-02 15:04")+"-"+slot.End.In(loc).Format("15:04"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Slots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
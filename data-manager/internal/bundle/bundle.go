@@ -0,0 +1,334 @@
+// Package bundle builds a static JSON snapshot of the public video library
+// that the website can load from a CDN instead of querying the database on
+// every page view.
+//
+// Every data file is named after a hash of its content
+// ("videos.3f9a1c2b7d4e.json"), so it can be cached forever. The manifest
+// (manifest.json) is the only file with a fixed name: it lists the data
+// files with their hashes, and its version changes whenever any of them does.
+package bundle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"fisio-data-manager/internal/models"
+)
+
+// SchemaVersion is the version of the bundle layout, raised when the
+// frontend must change to read it
+const SchemaVersion = 1
+
+// ManifestFile is the name of the manifest in the output directory
+const ManifestFile = "manifest.json"
+
+// hashLength is how many hex digits of the content hash go in file names
+const hashLength = 12
+
+// dataFilePattern matches the names of data files written by the bundle
+var dataFilePattern = regexp.MustCompile(`^(categories|videos|category-[A-Za-z0-9_-]+)\.[0-9a-f]{12}\.json$`)
+
+// Category is a category as published in the bundle
+type Category struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Icon        *string `json:"icon,omitempty"`
+	SortOrder   int     `json:"sortOrder"`
+	VideoCount  int     `json:"videoCount"`
+}
+
+// Video is a video as published in the bundle, named like the frontend's
+// ExerciseVideo type
+type Video struct {
+	ID                string     `json:"id"`
+	Title             string     `json:"title"`
+	Description       string     `json:"description"`
+	Provider          string     `json:"provider"`
+	VideoURL          string     `json:"videoUrl"`
+	EmbedURL          string     `json:"embedUrl"`
+	YoutubeID         string     `json:"youtubeId,omitempty"`
+	YoutubeURL        string     `json:"youtubeUrl,omitempty"`
+	CategoryID        string     `json:"categoryId"`
+	CategoryIDs       []string   `json:"categoryIds"`
+	Difficulty        string     `json:"difficulty"`
+	Duration          *int       `json:"duration,omitempty"` // in minutes
+	EquipmentRequired []string   `json:"equipmentRequired"`
+	BodyParts         []string   `json:"bodyParts"`
+	Tags              []string   `json:"tags"`
+	Contraindications []string   `json:"contraindications"`
+	Precautions       []string   `json:"precautions"`
+	ThumbnailURL      *string    `json:"thumbnailUrl,omitempty"`
+	PublishedAt       *time.Time `json:"publishedAt,omitempty"`
+	ExpireAt          *time.Time `json:"expireAt,omitempty"` // hide the video from then on
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+// FileEntry describes a data file in the manifest. ETag is the quoted hash,
+// matching what a CDN serving the file can send.
+type FileEntry struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	ETag   string `json:"etag"`
+	Bytes  int    `json:"bytes"`
+	Count  int    `json:"count"` // categories or videos in the file
+}
+
+// Manifest lists the data files of a bundle. It only changes when the
+// content does, so it can be served with its own ETag and a short cache.
+type Manifest struct {
+	SchemaVersion int                  `json:"schemaVersion"`
+	Version       string               `json:"version"` // hash of every data file
+	ETag          string               `json:"etag"`
+	UpdatedAt     time.Time            `json:"updatedAt"` // latest change to a category or video
+	Categories    FileEntry            `json:"categories"`
+	Videos        FileEntry            `json:"videos"`
+	ByCategory    map[string]FileEntry `json:"byCategory"` // videos of each category, by category ID
+}
+
+// Bundle is a built snapshot: the manifest and the content of every file,
+// by file name
+type Bundle struct {
+	Manifest Manifest
+	Files    map[string][]byte
+}
+
+// Result reports what Write did with each file
+type Result struct {
+	Written   []string `json:"written"`
+	Unchanged []string `json:"unchanged"`
+	Removed   []string `json:"removed"`
+}
+
+// Build builds the bundle of the given categories and public videos. Videos
+// are listed in the file of each of their categories.
+func Build(categories []models.VideoCategory, videos []models.ExerciseVideo) (*Bundle, error) {
+	b := &Bundle{
+		Manifest: Manifest{SchemaVersion: SchemaVersion, ByCategory: make(map[string]FileEntry)},
+		Files:    make(map[string][]byte),
+	}
+
+	all := make([]Video, 0, len(videos))
+	byCategory := make(map[string][]Video)
+	for _, video := range videos {
+		v := newVideo(video)
+		all = append(all, v)
+		for _, id := range v.CategoryIDs {
+			byCategory[id] = append(byCategory[id], v)
+		}
+		b.touch(video.UpdatedAt)
+	}
+
+	published := make([]Category, 0, len(categories))
+	for _, category := range categories {
+		inCategory := byCategory[category.ID]
+		if inCategory == nil {
+			inCategory = []Video{}
+		}
+		published = append(published, Category{
+			ID:          category.ID,
+			Name:        category.Name,
+			Description: category.Description,
+			Icon:        category.Icon,
+			SortOrder:   category.SortOrder,
+			VideoCount:  len(inCategory),
+		})
+		b.touch(category.UpdatedAt)
+
+		entry, err := b.add("category-"+category.ID, inCategory, len(inCategory))
+		if err != nil {
+			return nil, err
+		}
+		b.Manifest.ByCategory[category.ID] = entry
+	}
+
+	var err error
+	if b.Manifest.Categories, err = b.add("categories", published, len(published)); err != nil {
+		return nil, err
+	}
+	if b.Manifest.Videos, err = b.add("videos", all, len(all)); err != nil {
+		return nil, err
+	}
+
+	// The version covers every data file, in a stable order
+	names := make([]string, 0, len(b.Files))
+	for name := range b.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	version := sha256.New()
+	for _, name := range names {
+		fmt.Fprintln(version, name)
+	}
+	b.Manifest.Version = hex.EncodeToString(version.Sum(nil))[:hashLength]
+	b.Manifest.ETag = fmt.Sprintf("%q", b.Manifest.Version)
+
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	b.Files[ManifestFile] = append(manifest, '\n')
+
+	return b, nil
+}
+
+// Write writes the bundle to dir, creating it if needed. Files whose content
+// is already on disk are left untouched. With prune, data files from earlier
+// bundles that the manifest no longer lists are removed.
+func Write(dir string, b *Bundle, prune bool) (*Result, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	names := make([]string, 0, len(b.Files))
+	for name := range b.Files {
+		if name != ManifestFile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	// The manifest goes last, so it never points at files not written yet
+	names = append(names, ManifestFile)
+
+	result := &Result{Written: []string{}, Unchanged: []string{}, Removed: []string{}}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, b.Files[name]) {
+			result.Unchanged = append(result.Unchanged, name)
+			continue
+		}
+		if err := writeFile(path, b.Files[name]); err != nil {
+			return result, err
+		}
+		result.Written = append(result.Written, name)
+	}
+
+	if prune {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return result, fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !dataFilePattern.MatchString(name) {
+				continue
+			}
+			if _, ok := b.Files[name]; ok {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				return result, fmt.Errorf("failed to remove %s: %w", name, err)
+			}
+			result.Removed = append(result.Removed, name)
+		}
+	}
+
+	return result, nil
+}
+
+// add encodes a data file and names it after its content
+func (b *Bundle) add(prefix string, value interface{}, count int) (FileEntry, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return FileEntry{}, fmt.Errorf("failed to encode %s: %w", prefix, err)
+	}
+	data = append(data, '\n')
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	name := fmt.Sprintf("%s.%s.json", prefix, hash[:hashLength])
+	b.Files[name] = data
+
+	return FileEntry{
+		Path:   name,
+		SHA256: hash,
+		ETag:   fmt.Sprintf("%q", hash[:hashLength]),
+		Bytes:  len(data),
+		Count:  count,
+	}, nil
+}
+
+// touch keeps the latest change time for the manifest
+func (b *Bundle) touch(t time.Time) {
+	if t.After(b.Manifest.UpdatedAt) {
+		b.Manifest.UpdatedAt = t.UTC()
+	}
+}
+
+func newVideo(video models.ExerciseVideo) Video {
+	return Video{
+		ID:                video.ID,
+		Title:             video.Title,
+		Description:       video.Description,
+		Provider:          video.Provider,
+		VideoURL:          video.VideoURL,
+		EmbedURL:          video.EmbedURL,
+		YoutubeID:         video.YoutubeID,
+		YoutubeURL:        video.YoutubeURL,
+		CategoryID:        video.CategoryID,
+		CategoryIDs:       nonNil(video.CategoryIDs),
+		Difficulty:        video.DifficultyLevel,
+		Duration:          video.Duration,
+		EquipmentRequired: nonNil(video.EquipmentRequired),
+		BodyParts:         nonNil(video.BodyParts),
+		Tags:              nonNil(video.Tags),
+		Contraindications: nonNil(video.Contraindications),
+		Precautions:       nonNil(video.Precautions),
+		ThumbnailURL:      video.ThumbnailURL,
+		PublishedAt:       utc(video.PublishedAt),
+		ExpireAt:          utc(video.ExpireAt),
+		CreatedAt:         video.CreatedAt.UTC(),
+		UpdatedAt:         video.UpdatedAt.UTC(),
+	}
+}
+
+// nonNil keeps empty lists as [] rather than null in the JSON
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// utc keeps times in UTC so the files do not depend on the machine's zone
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// writeFile replaces a file through a temporary file, so a CDN syncing the
+// directory never picks up a half-written file
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".bundle-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
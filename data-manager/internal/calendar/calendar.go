@@ -0,0 +1,80 @@
+// Package calendar reads the events of the clinic's Google Calendar, where
+// the booking edge functions create an event for every appointment, and
+// reconciles them with the appointments in the database.
+//
+// The calendar is reached through the Client interface: GoogleClient talks
+// to the Google Calendar API, FakeClient serves events from memory or a JSON
+// file so reconciliation can be tried out offline.
+package calendar
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Event statuses, as reported by Google Calendar
+const (
+	StatusConfirmed = "confirmed"
+	StatusTentative = "tentative"
+	StatusCancelled = "cancelled" // deleted events
+)
+
+// ErrNotFound is returned for events that do not exist (any more)
+var ErrNotFound = errors.New("calendar event not found")
+
+// Client reads the events of a calendar
+type Client interface {
+	// Events returns the events overlapping a time range, including deleted
+	// ones (with the cancelled status)
+	Events(ctx context.Context, from, to time.Time) ([]Event, error)
+	// Event returns one event by ID, or ErrNotFound
+	Event(ctx context.Context, id string) (*Event, error)
+}
+
+// Event is an event on the calendar
+type Event struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description,omitempty"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+}
+
+// summaryPrefix starts the summary of the events the booking flow creates
+const summaryPrefix = "Physiotherapy - "
+
+// appointmentIDPattern finds the appointment ID the booking flow writes in
+// the event description
+var appointmentIDPattern = regexp.MustCompile(`(?i)Appointment ID:\s*([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})`)
+
+// Deleted reports whether the event was deleted
+func (e *Event) Deleted() bool {
+	return e.Status == StatusCancelled
+}
+
+// AppointmentID returns the appointment ID written in the event description,
+// or ""
+func (e *Event) AppointmentID() string {
+	if match := appointmentIDPattern.FindStringSubmatch(e.Description); match != nil {
+		return strings.ToLower(match[1])
+	}
+	return ""
+}
+
+// IsAppointment reports whether the event was created for an appointment, as
+// opposed to the other events on the calendar
+func (e *Event) IsAppointment() bool {
+	return strings.HasPrefix(e.Summary, summaryPrefix) || e.AppointmentID() != ""
+}
+
+// PatientName returns the patient name from the event summary, or ""
+func (e *Event) PatientName() string {
+	if strings.HasPrefix(e.Summary, summaryPrefix) {
+		return strings.TrimPrefix(e.Summary, summaryPrefix)
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// FakeClient is a calendar held in memory, for trying out reconciliation
+// without a Google account
+type FakeClient struct {
+	events map[string]Event
+}
+
+// NewFakeClient returns a calendar holding the events
+func NewFakeClient(events []Event) *FakeClient {
+	f := &FakeClient{events: make(map[string]Event, len(events))}
+	for _, event := range events {
+		f.events[event.ID] = event
+	}
+	return f
+}
+
+// LoadFakeClient returns a calendar holding the events of a JSON file (an
+// array of events, as printed by Event's JSON encoding)
+func LoadFakeClient(path string) (*FakeClient, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calendar file: %w", err)
+	}
+	var events []Event
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse calendar file: %w", err)
+	}
+	for i, event := range events {
+		if event.ID == "" {
+			return nil, fmt.Errorf("event %d of the calendar file has no id", i+1)
+		}
+		if event.Status == "" {
+			events[i].Status = StatusConfirmed
+		}
+	}
+	return NewFakeClient(events), nil
+}
+
+// Events returns the events overlapping a time range, in start order
+func (f *FakeClient) Events(ctx context.Context, from, to time.Time) ([]Event, error) {
+	events := []Event{}
+	for _, event := range f.events {
+		if event.Start.Before(to) && event.End.After(from) {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Start.Equal(events[j].Start) {
+			return events[i].Start.Before(events[j].Start)
+		}
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
+
+// Event returns one event by ID, or ErrNotFound
+func (f *FakeClient) Event(ctx context.Context, id string) (*Event, error) {
+	event, ok := f.events[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &event, nil
+}
@@ -0,0 +1,236 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	googleTokenURL = "https://oauth2.googleapis.com/token"
+	googleAPIURL   = "https://www.googleapis.com/calendar/v3"
+)
+
+// GoogleClient reads a Google Calendar with the OAuth credentials the edge
+// functions use (GOOGLE_CLIENT_ID, GOOGLE_CLIENT_SECRET, GOOGLE_REFRESH_TOKEN)
+type GoogleClient struct {
+	CalendarID string
+
+	clientID     string
+	clientSecret string
+	refreshToken string
+	http         *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expires     time.Time
+}
+
+// NewGoogleClient returns a client for a calendar ("primary" for the
+// account's main calendar)
+func NewGoogleClient(clientID, clientSecret, refreshToken, calendarID string) (*GoogleClient, error) {
+	if clientID == "" || clientSecret == "" || refreshToken == "" {
+		return nil, fmt.Errorf("missing Google OAuth credentials: set GOOGLE_CLIENT_ID, GOOGLE_CLIENT_SECRET and GOOGLE_REFRESH_TOKEN")
+	}
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+	return &GoogleClient{
+		CalendarID:   calendarID,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		refreshToken: refreshToken,
+		http:         &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// googleEvent is an event as returned by the Calendar API
+type googleEvent struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	Summary     string     `json:"summary"`
+	Description string     `json:"description"`
+	Start       googleTime `json:"start"`
+	End         googleTime `json:"end"`
+}
+
+// googleTime is the start or end of an event: a time, or a date for all-day
+// events
+type googleTime struct {
+	DateTime string `json:"dateTime"`
+	Date     string `json:"date"`
+	TimeZone string `json:"timeZone"`
+}
+
+func (t googleTime) parse() (time.Time, error) {
+	if t.DateTime != "" {
+		return time.Parse(time.RFC3339, t.DateTime)
+	}
+	if t.Date != "" {
+		loc := time.UTC
+		if t.TimeZone != "" {
+			if named, err := time.LoadLocation(t.TimeZone); err == nil {
+				loc = named
+			}
+		}
+		return time.ParseInLocation("2006-01-02", t.Date, loc)
+	}
+	// Deleted events may come without times
+	return time.Time{}, nil
+}
+
+func (e googleEvent) toEvent() (Event, error) {
+	start, err := e.Start.parse()
+	if err != nil {
+		return Event{}, fmt.Errorf("invalid start of event %s: %w", e.ID, err)
+	}
+	end, err := e.End.parse()
+	if err != nil {
+		return Event{}, fmt.Errorf("invalid end of event %s: %w", e.ID, err)
+	}
+	return Event{
+		ID:          e.ID,
+		Status:      e.Status,
+		Summary:     e.Summary,
+		Description: e.Description,
+		Start:       start,
+		End:         end,
+	}, nil
+}
+
+// Events returns the events overlapping a time range, including deleted ones
+func (g *GoogleClient) Events(ctx context.Context, from, to time.Time) ([]Event, error) {
+	events := []Event{}
+	pageToken := ""
+	for {
+		query := url.Values{
+			"timeMin":      {from.Format(time.RFC3339)},
+			"timeMax":      {to.Format(time.RFC3339)},
+			"singleEvents": {"true"},
+			"showDeleted":  {"true"},
+			"orderBy":      {"startTime"},
+			"maxResults":   {"2500"},
+		}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		var page struct {
+			Items         []googleEvent `json:"items"`
+			NextPageToken string        `json:"nextPageToken"`
+		}
+		if err := g.get(ctx, "/calendars/"+url.PathEscape(g.CalendarID)+"/events?"+query.Encode(), &page); err != nil {
+			return nil, fmt.Errorf("failed to list calendar events: %w", err)
+		}
+		for _, item := range page.Items {
+			event, err := item.toEvent()
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+
+		if page.NextPageToken == "" {
+			return events, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// Event returns one event by ID, or ErrNotFound
+func (g *GoogleClient) Event(ctx context.Context, id string) (*Event, error) {
+	var item googleEvent
+	path := "/calendars/" + url.PathEscape(g.CalendarID) + "/events/" + url.PathEscape(id)
+	if err := g.get(ctx, path, &item); err != nil {
+		if err == ErrNotFound {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get calendar event: %w", err)
+	}
+	event, err := item.toEvent()
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// get calls the Calendar API and decodes the response into value
+func (g *GoogleClient) get(ctx context.Context, path string, value interface{}) error {
+	token, err := g.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleAPIURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := g.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("calendar API returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(value)
+}
+
+// token returns an access token, refreshing it when it is about to expire
+func (g *GoogleClient) token(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.accessToken != "" && time.Now().Before(g.expires.Add(-time.Minute)) {
+		return g.accessToken, nil
+	}
+
+	form := url.Values{
+		"client_id":     {g.clientID},
+		"client_secret": {g.clientSecret},
+		"refresh_token": {g.refreshToken},
+		"grant_type":    {"refresh_token"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, googleTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := g.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh Google token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("failed to refresh Google token: %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to refresh Google token: %w", err)
+	}
+
+	g.accessToken = result.AccessToken
+	g.expires = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	return g.accessToken, nil
+}
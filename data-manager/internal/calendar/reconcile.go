@@ -0,0 +1,185 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"fisio-data-manager/internal/models"
+)
+
+// Kinds of findings
+const (
+	// An appointment event with no appointment in the database
+	FindingOrphanEvent = "orphan_event"
+	// An event naming an appointment that has no event ID: the booking
+	// stopped before the event ID was saved
+	FindingUnlinkedEvent = "unlinked_event"
+	// An upcoming appointment without an event
+	FindingNoEvent = "no_event"
+	// An upcoming appointment whose event does not exist
+	FindingMissingEvent = "missing_event"
+	// An upcoming appointment whose event was deleted
+	FindingDeletedEvent = "deleted_event"
+	// A cancelled appointment whose event was not deleted: the cancellation
+	// stopped before the event was deleted
+	FindingActiveEvent = "active_event"
+	// An upcoming appointment whose event is at another time
+	FindingTimeMismatch = "time_mismatch"
+)
+
+// Fixes the database side of a finding can get
+const (
+	FixLink       = "link"       // save the event ID on the appointment
+	FixCancel     = "cancel"     // cancel the appointment
+	FixReschedule = "reschedule" // move the appointment to the event's time
+)
+
+// Finding is a difference between the appointments and the calendar
+type Finding struct {
+	Kind        string              `json:"kind"`
+	Detail      string              `json:"detail"`
+	Fix         string              `json:"fix,omitempty"` // empty when the database side cannot fix it
+	Appointment *models.Appointment `json:"appointment,omitempty"`
+	Event       *Event              `json:"event,omitempty"`
+}
+
+// Reconcile compares appointments with the calendar events created for them.
+// events must include the events of the appointments with an event ID that
+// still exist, even outside the time range looked at. Only scheduled and
+// confirmed appointments are expected to have a live event at their time.
+// Appointment times are wall-clock times of the clinic, whose time zone is
+// loc. Findings are in time order.
+func Reconcile(appointments []models.Appointment, events []Event, loc *time.Location) []Finding {
+	findings := []Finding{}
+
+	byEventID := make(map[string]*models.Appointment, len(appointments))
+	byID := make(map[string]*models.Appointment, len(appointments))
+	for i := range appointments {
+		a := &appointments[i]
+		byID[a.ID] = a
+		if a.GoogleEventID != "" {
+			byEventID[a.GoogleEventID] = a
+		}
+	}
+	eventsByID := make(map[string]*Event, len(events))
+	for i := range events {
+		eventsByID[events[i].ID] = &events[i]
+	}
+
+	// Events without their appointment
+	linked := make(map[string]string) // appointment ID to the event to link
+	for i := range events {
+		event := &events[i]
+		if byEventID[event.ID] != nil || event.Deleted() || !event.IsAppointment() {
+			continue
+		}
+		if a := byID[event.AppointmentID()]; a != nil && a.GoogleEventID == "" && linked[a.ID] == "" {
+			linked[a.ID] = event.ID
+			findings = append(findings, Finding{
+				Kind:        FindingUnlinkedEvent,
+				Detail:      fmt.Sprintf("event %s names appointment %s, which has no event ID", event.ID, a.ID),
+				Fix:         FixLink,
+				Appointment: a,
+				Event:       event,
+			})
+			continue
+		}
+		detail := fmt.Sprintf("event %s (%s) has no appointment", event.ID, event.Summary)
+		if a := byID[event.AppointmentID()]; a != nil {
+			original := a.GoogleEventID
+			if original == "" {
+				original = linked[a.ID]
+			}
+			detail = fmt.Sprintf("event %s duplicates event %s of appointment %s", event.ID, original, a.ID)
+		}
+		findings = append(findings, Finding{
+			Kind:   FindingOrphanEvent,
+			Detail: detail,
+			Event:  event,
+		})
+	}
+
+	// Appointments without their event
+	for i := range appointments {
+		a := &appointments[i]
+		upcoming := a.Status == models.AppointmentScheduled || a.Status == models.AppointmentConfirmed
+
+		if a.GoogleEventID == "" {
+			if upcoming && linked[a.ID] == "" {
+				findings = append(findings, Finding{
+					Kind:        FindingNoEvent,
+					Detail:      "appointment has no calendar event",
+					Appointment: a,
+				})
+			}
+			continue
+		}
+
+		event := eventsByID[a.GoogleEventID]
+		switch {
+		case event == nil && upcoming:
+			findings = append(findings, Finding{
+				Kind:        FindingMissingEvent,
+				Detail:      fmt.Sprintf("event %s does not exist", a.GoogleEventID),
+				Fix:         FixCancel,
+				Appointment: a,
+			})
+		case event == nil:
+			// Cancelled or past appointments no longer need their event
+		case event.Deleted() && upcoming:
+			findings = append(findings, Finding{
+				Kind:        FindingDeletedEvent,
+				Detail:      fmt.Sprintf("event %s was deleted but the appointment is %s", event.ID, a.Status),
+				Fix:         FixCancel,
+				Appointment: a,
+				Event:       event,
+			})
+		case !event.Deleted() && a.Status == models.AppointmentCancelled:
+			findings = append(findings, Finding{
+				Kind:        FindingActiveEvent,
+				Detail:      fmt.Sprintf("appointment is cancelled but event %s is still on the calendar", event.ID),
+				Appointment: a,
+				Event:       event,
+			})
+		case !event.Deleted() && upcoming:
+			start, end := a.Start(loc), a.End(loc)
+			if !event.Start.Equal(start) || !event.End.Equal(end) {
+				finding := Finding{
+					Kind: FindingTimeMismatch,
+					Detail: fmt.Sprintf("appointment is %s, event is %s",
+						formatSpan(start, end, loc), formatSpan(event.Start, event.End, loc)),
+					Appointment: a,
+					Event:       event,
+				}
+				if minutes := int(event.End.Sub(event.Start).Minutes()); minutes > 0 && minutes <= 480 {
+					finding.Fix = FixReschedule
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findingTime(findings[i], loc).Before(findingTime(findings[j], loc))
+	})
+	return findings
+}
+
+// findingTime is when the appointment or event of a finding starts
+func findingTime(f Finding, loc *time.Location) time.Time {
+	if f.Appointment != nil {
+		return f.Appointment.Start(loc)
+	}
+	return f.Event.Start
+}
+
+// formatSpan formats a span of time at the clinic
+func formatSpan(start, end time.Time, loc *time.Location) string {
+	start, end = start.In(loc), end.In(loc)
+	span := start.Format("2006-01-02 15:04") + "–"
+	if start.Format("2006-01-02") == end.Format("2006-01-02") {
+		return span + end.Format("15:04")
+	}
+	return span + end.Format("2006-01-02 15:04")
+}
@@ -0,0 +1,228 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"fisio-data-manager/internal/models"
+)
+
+func TestReconcile(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		first  = "6f1c2a9e-8d4b-4e2f-9a7c-1b2d3e4f5a01"
+		second = "6f1c2a9e-8d4b-4e2f-9a7c-1b2d3e4f5a02"
+	)
+	at := func(value string) time.Time {
+		ts, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	appointment := func(id, eventID, status, start string) models.Appointment {
+		ts := at(start)
+		return models.Appointment{
+			ID:              id,
+			GoogleEventID:   eventID,
+			Status:          status,
+			AppointmentDate: time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC),
+			AppointmentTime: ts.Format("15:04"),
+			Duration:        60,
+		}
+	}
+	event := func(id, status, appointmentID string, start, end time.Time) Event {
+		e := Event{ID: id, Status: status, Summary: "Physiotherapy - Maria Silva", Start: start, End: end}
+		if appointmentID != "" {
+			e.Description = "Appointment ID: " + appointmentID
+		}
+		return e
+	}
+
+	// The week looked at; events of the appointments outside it are fetched
+	// one by one, as reconcile-calendar does
+	from, to := at("2026-10-26 00:00"), at("2026-11-02 00:00")
+
+	tests := []struct {
+		name         string
+		appointments []models.Appointment
+		events       []Event
+		want         []string
+	}{
+		{
+			name:         "in sync",
+			appointments: []models.Appointment{appointment(first, "ev1", models.AppointmentConfirmed, "2026-10-27 10:00")},
+			events: []Event{
+				event("ev1", StatusConfirmed, first, at("2026-10-27 10:00"), at("2026-10-27 11:00")),
+				{ID: "meeting", Status: StatusConfirmed, Summary: "Team meeting", Start: at("2026-10-27 12:00"), End: at("2026-10-27 13:00")},
+			},
+		},
+		{
+			name:         "unlinked event",
+			appointments: []models.Appointment{appointment(first, "", models.AppointmentScheduled, "2026-10-27 10:00")},
+			events:       []Event{event("ev1", StatusConfirmed, first, at("2026-10-27 10:00"), at("2026-10-27 11:00"))},
+			want: []string{
+				"unlinked_event (link): event ev1 names appointment " + first + ", which has no event ID",
+			},
+		},
+		{
+			name:         "two events for an unlinked appointment",
+			appointments: []models.Appointment{appointment(first, "", models.AppointmentScheduled, "2026-10-27 10:00")},
+			events: []Event{
+				event("ev1", StatusConfirmed, first, at("2026-10-27 10:00"), at("2026-10-27 11:00")),
+				event("ev2", StatusConfirmed, first, at("2026-10-27 10:00"), at("2026-10-27 11:00")),
+			},
+			want: []string{
+				"unlinked_event (link): event ev1 names appointment " + first + ", which has no event ID",
+				"orphan_event: event ev2 duplicates event ev1 of appointment " + first,
+			},
+		},
+		{
+			name:         "duplicate event",
+			appointments: []models.Appointment{appointment(first, "ev1", models.AppointmentConfirmed, "2026-10-27 10:00")},
+			events: []Event{
+				event("ev1", StatusConfirmed, first, at("2026-10-27 10:00"), at("2026-10-27 11:00")),
+				event("ev2", StatusConfirmed, first, at("2026-10-28 10:00"), at("2026-10-28 11:00")),
+				event("ev3", StatusCancelled, first, at("2026-10-29 10:00"), at("2026-10-29 11:00")),
+			},
+			want: []string{"orphan_event: event ev2 duplicates event ev1 of appointment " + first},
+		},
+		{
+			name:   "event without appointment",
+			events: []Event{event("ev1", StatusConfirmed, "", at("2026-10-27 10:00"), at("2026-10-27 11:00"))},
+			want:   []string{"orphan_event: event ev1 (Physiotherapy - Maria Silva) has no appointment"},
+		},
+		{
+			name: "appointments without event",
+			appointments: []models.Appointment{
+				appointment(first, "", models.AppointmentScheduled, "2026-10-27 10:00"),
+				appointment(second, "", models.AppointmentCompleted, "2026-10-26 10:00"),
+			},
+			want: []string{"no_event: appointment has no calendar event"},
+		},
+		{
+			name: "missing and deleted events",
+			appointments: []models.Appointment{
+				appointment(first, "gone", models.AppointmentConfirmed, "2026-10-27 10:00"),
+				appointment(second, "ev2", models.AppointmentScheduled, "2026-10-28 10:00"),
+			},
+			events: []Event{event("ev2", StatusCancelled, second, at("2026-10-28 10:00"), at("2026-10-28 11:00"))},
+			want: []string{
+				"missing_event (cancel): event gone does not exist",
+				"deleted_event (cancel): event ev2 was deleted but the appointment is scheduled",
+			},
+		},
+		{
+			name: "cancelled appointments",
+			appointments: []models.Appointment{
+				appointment(first, "ev1", models.AppointmentCancelled, "2026-10-27 10:00"),
+				appointment(second, "ev2", models.AppointmentCancelled, "2026-10-28 10:00"),
+				appointment("6f1c2a9e-8d4b-4e2f-9a7c-1b2d3e4f5a03", "gone", models.AppointmentCancelled, "2026-10-29 10:00"),
+			},
+			events: []Event{
+				event("ev1", StatusConfirmed, first, at("2026-10-27 10:00"), at("2026-10-27 11:00")),
+				event("ev2", StatusCancelled, second, at("2026-10-28 10:00"), at("2026-10-28 11:00")),
+			},
+			want: []string{"active_event: appointment is cancelled but event ev1 is still on the calendar"},
+		},
+		{
+			name: "time mismatch",
+			appointments: []models.Appointment{
+				appointment(first, "ev1", models.AppointmentConfirmed, "2026-10-27 10:00"),
+				appointment(second, "ev2", models.AppointmentConfirmed, "2026-10-28 10:00"),
+			},
+			events: []Event{
+				event("ev1", StatusConfirmed, first, at("2026-10-27 11:00"), at("2026-10-27 12:30")),
+				event("ev2", StatusConfirmed, second, at("2026-10-28 10:00"), at("2026-10-29 10:00")),
+			},
+			want: []string{
+				"time_mismatch (reschedule): appointment is 2026-10-27 10:00–11:00, event is 2026-10-27 11:00–12:30",
+				"time_mismatch: appointment is 2026-10-28 10:00–11:00, event is 2026-10-28 10:00–2026-10-29 10:00",
+			},
+		},
+		{
+			name:         "event moved out of the range",
+			appointments: []models.Appointment{appointment(first, "ev1", models.AppointmentScheduled, "2026-10-27 10:00")},
+			events:       []Event{event("ev1", StatusConfirmed, first, at("2026-11-10 10:00"), at("2026-11-10 11:00"))},
+			want: []string{
+				"time_mismatch (reschedule): appointment is 2026-10-27 10:00–11:00, event is 2026-11-10 10:00–11:00",
+			},
+		},
+		{
+			name: "clocks going back",
+			appointments: []models.Appointment{
+				appointment(first, "ev1", models.AppointmentConfirmed, "2026-11-01 09:00"),
+				appointment(second, "ev2", models.AppointmentConfirmed, "2026-11-01 11:00"),
+			},
+			events: func() []Event {
+				// Placed at 11:00 with the summer offset of the day before
+				summer := time.Date(2026, 11, 1, 15, 0, 0, 0, time.UTC)
+				return []Event{
+					event("ev1", StatusConfirmed, first, at("2026-11-01 09:00"), at("2026-11-01 10:00")),
+					event("ev2", StatusConfirmed, second, summer, summer.Add(time.Hour)),
+				}
+			}(),
+			want: []string{
+				"time_mismatch (reschedule): appointment is 2026-11-01 11:00–12:00, event is 2026-11-01 10:00–11:00",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := fetchEvents(t, NewFakeClient(tt.events), tt.appointments, from, to)
+
+			got := []string{}
+			for _, f := range Reconcile(tt.appointments, events, loc) {
+				kind := f.Kind
+				if f.Fix != "" {
+					kind += " (" + f.Fix + ")"
+				}
+				got = append(got, fmt.Sprintf("%s: %s", kind, f.Detail))
+			}
+			if len(tt.want) == 0 {
+				tt.want = []string{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// fetchEvents returns the events of a time range along with the events of the
+// appointments outside it
+func fetchEvents(t *testing.T, client Client, appointments []models.Appointment, from, to time.Time) []Event {
+	ctx := context.Background()
+	events, err := client.Events(ctx, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := make(map[string]bool, len(events))
+	for _, event := range events {
+		found[event.ID] = true
+	}
+	for _, a := range appointments {
+		if a.GoogleEventID == "" || found[a.GoogleEventID] {
+			continue
+		}
+		event, err := client.Event(ctx, a.GoogleEventID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		found[event.ID] = true
+		events = append(events, *event)
+	}
+	return events
+}
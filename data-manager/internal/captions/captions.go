@@ -0,0 +1,216 @@
+// Package captions parses WebVTT and SRT caption files into timed cues.
+package captions
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported caption file formats
+const (
+	FormatVTT = "vtt"
+	FormatSRT = "srt"
+)
+
+// Cue is a piece of caption text shown between two times
+type Cue struct {
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+	Text  string        `json:"text"`
+}
+
+// timingPattern matches a cue timing line in both formats:
+// "00:01:02.500 --> 00:01:05.000" (VTT, hours optional) and
+// "00:01:02,500 --> 00:01:05,000" (SRT)
+var timingPattern = regexp.MustCompile(`^\s*((?:\d+:)?\d{1,2}:\d{2}[.,]\d{1,3})\s+-->\s+((?:\d+:)?\d{1,2}:\d{2}[.,]\d{1,3})`)
+
+// tagPattern matches markup inside cue text (<v Speaker>, <i>, <00:01.000>...)
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// DetectFormat returns the format of a caption file from its extension
+func DetectFormat(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".vtt":
+		return FormatVTT, nil
+	case ".srt":
+		return FormatSRT, nil
+	default:
+		return "", fmt.Errorf("unknown caption format for '%s' (expected .vtt or .srt)", filename)
+	}
+}
+
+// Parse reads the cues of a caption file in the given format
+func Parse(r io.Reader, format string) ([]Cue, error) {
+	switch format {
+	case FormatVTT:
+		return ParseVTT(r)
+	case FormatSRT:
+		return ParseSRT(r)
+	default:
+		return nil, fmt.Errorf("unsupported caption format '%s' (expected vtt or srt)", format)
+	}
+}
+
+// ParseVTT reads the cues of a WebVTT file. NOTE, STYLE and REGION blocks
+// are skipped.
+func ParseVTT(r io.Reader) ([]Cue, error) {
+	blocks, err := readBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 || !strings.HasPrefix(strings.TrimPrefix(blocks[0].lines[0], "\ufeff"), "WEBVTT") {
+		return nil, fmt.Errorf("not a WebVTT file: missing WEBVTT header")
+	}
+
+	var cues []Cue
+	for _, b := range blocks[1:] {
+		first := b.lines[0]
+		if strings.HasPrefix(first, "NOTE") || strings.HasPrefix(first, "STYLE") || strings.HasPrefix(first, "REGION") {
+			continue
+		}
+		cue, ok, err := parseCue(b)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cues = append(cues, cue)
+		}
+	}
+
+	return cues, nil
+}
+
+// ParseSRT reads the cues of a SubRip (SRT) file
+func ParseSRT(r io.Reader) ([]Cue, error) {
+	blocks, err := readBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var cues []Cue
+	for _, b := range blocks {
+		cue, ok, err := parseCue(b)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected a cue timing line", b.line)
+		}
+		cues = append(cues, cue)
+	}
+
+	return cues, nil
+}
+
+// FormatTimestamp renders a cue time as HH:MM:SS.mmm
+func FormatTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// block is a group of non-blank lines and the line number it starts on
+type block struct {
+	line  int
+	lines []string
+}
+
+func readBlocks(r io.Reader) ([]block, error) {
+	var blocks []block
+	var current *block
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			blocks = append(blocks, block{line: lineNum})
+			current = &blocks[len(blocks)-1]
+		}
+		current.lines = append(current.lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read captions: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// parseCue parses a block made of an optional identifier, a timing line and
+// the cue text. ok is false when the block has no timing line.
+func parseCue(b block) (Cue, bool, error) {
+	for i, line := range b.lines {
+		if i > 1 {
+			break
+		}
+		matches := timingPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		start, err := parseTimestamp(matches[1])
+		if err != nil {
+			return Cue{}, false, fmt.Errorf("line %d: %w", b.line+i, err)
+		}
+		end, err := parseTimestamp(matches[2])
+		if err != nil {
+			return Cue{}, false, fmt.Errorf("line %d: %w", b.line+i, err)
+		}
+		if end < start {
+			return Cue{}, false, fmt.Errorf("line %d: cue ends before it starts", b.line+i)
+		}
+
+		text := make([]string, 0, len(b.lines)-i-1)
+		for _, textLine := range b.lines[i+1:] {
+			if cleaned := strings.TrimSpace(tagPattern.ReplaceAllString(textLine, "")); cleaned != "" {
+				text = append(text, cleaned)
+			}
+		}
+
+		return Cue{Start: start, End: end, Text: strings.Join(text, " ")}, true, nil
+	}
+
+	return Cue{}, false, nil
+}
+
+// parseTimestamp parses "HH:MM:SS.mmm", "MM:SS.mmm" or "HH:MM:SS,mmm"
+func parseTimestamp(value string) (time.Duration, error) {
+	value = strings.Replace(value, ",", ".", 1)
+	parts := strings.Split(value, ":")
+
+	var hours, minutes int
+	var err error
+	switch len(parts) {
+	case 3:
+		if hours, err = strconv.Atoi(parts[0]); err != nil {
+			return 0, fmt.Errorf("invalid timestamp '%s'", value)
+		}
+		parts = parts[1:]
+		fallthrough
+	case 2:
+		if minutes, err = strconv.Atoi(parts[0]); err != nil || minutes > 59 {
+			return 0, fmt.Errorf("invalid timestamp '%s'", value)
+		}
+	default:
+		return 0, fmt.Errorf("invalid timestamp '%s'", value)
+	}
+
+	seconds, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || seconds >= 60 {
+		return 0, fmt.Errorf("invalid timestamp '%s'", value)
+	}
+
+	total := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	return total + time.Duration(seconds*1000+0.5)*time.Millisecond, nil
+}
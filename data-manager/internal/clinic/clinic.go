@@ -0,0 +1,123 @@
+// Package clinic holds the clinic settings the appointment commands depend
+// on: its time zone and opening hours. Appointment dates and times are stored
+// without a time zone, as wall-clock times of the clinic.
+package clinic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Defaults, matching the booking website's business hours
+const (
+	DefaultTimeZone = "America/New_York"
+	DefaultOpensAt  = "09:00"
+	DefaultClosesAt = "17:00"
+)
+
+// Config is the clinic's time zone and opening hours
+type Config struct {
+	Location *time.Location
+	OpensAt  time.Duration // since midnight
+	ClosesAt time.Duration // since midnight
+}
+
+// Load reads the clinic settings from the environment (CLINIC_TIMEZONE,
+// CLINIC_OPENS_AT, CLINIC_CLOSES_AT) or the --timezone flag, falling back to
+// the defaults
+func Load() (*Config, error) {
+	name := setting([]string{"clinic_timezone", "CLINIC_TIMEZONE"}, DefaultTimeZone)
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic time zone '%s': %w", name, err)
+	}
+
+	opens, err := ParseClock(setting([]string{"CLINIC_OPENS_AT"}, DefaultOpensAt))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CLINIC_OPENS_AT: %w", err)
+	}
+	closes, err := ParseClock(setting([]string{"CLINIC_CLOSES_AT"}, DefaultClosesAt))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CLINIC_CLOSES_AT: %w", err)
+	}
+	if closes <= opens {
+		return nil, fmt.Errorf("the clinic must close (%s) after it opens (%s)", FormatClock(closes), FormatClock(opens))
+	}
+
+	return &Config{Location: loc, OpensAt: opens, ClosesAt: closes}, nil
+}
+
+// Today returns the current date at the clinic, at midnight UTC like the
+// dates stored for appointments
+func (c *Config) Today(now time.Time) time.Time {
+	local := now.In(c.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// At returns the time of day on a date, at the clinic. The clock is read as
+// a wall-clock time, so days when the clocks change keep their hours.
+func (c *Config) At(date time.Time, clock time.Duration) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(),
+		int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, c.Location)
+}
+
+// Opens returns when the clinic opens on a date
+func (c *Config) Opens(date time.Time) time.Time {
+	return c.At(date, c.OpensAt)
+}
+
+// Closes returns when the clinic closes on a date
+func (c *Config) Closes(date time.Time) time.Time {
+	return c.At(date, c.ClosesAt)
+}
+
+// ParseDate parses a date as typed on the command line: today, tomorrow,
+// yesterday or YYYY-MM-DD. Dates are at midnight UTC like the dates stored
+// for appointments.
+func (c *Config) ParseDate(value string, now time.Time) (time.Time, error) {
+	today := c.Today(now)
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date '%s' (expected today, tomorrow, yesterday or YYYY-MM-DD)", value)
+	}
+	return date, nil
+}
+
+// ParseClock parses a time of day (HH:MM, or 24:00 for the end of the day)
+// into the time since midnight
+func ParseClock(value string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s' (expected HH:MM)", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// FormatClock formats the time since midnight as HH:MM
+func FormatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// setting returns the first of the keys that is set, or the default
+func setting(keys []string, def string) string {
+	for _, key := range keys {
+		if value := strings.TrimSpace(viper.GetString(key)); value != "" {
+			return value
+		}
+	}
+	return def
+}
@@ -0,0 +1,250 @@
+// Package ics reads and writes iCalendar (RFC 5545) files: it reads public
+// holiday calendars, such as those published by calendar providers, and
+// writes appointment calendars staff can import or subscribe to.
+//
+// Only the parts of VEVENT components needed to import closures are read:
+// UID, SUMMARY, DESCRIPTION, STATUS, DTSTART, DTEND and DURATION. Recurrence
+// rules are not expanded; a recurring event counts once, on its first date.
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a calendar event
+type Event struct {
+	UID         string    `json:"uid"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description,omitempty"`
+	Status      string    `json:"status,omitempty"` // TENTATIVE, CONFIRMED or CANCELLED
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"` // exclusive
+	AllDay      bool      `json:"all_day"`
+	Recurring   bool      `json:"recurring,omitempty"`
+	Location    string    `json:"location,omitempty"`
+	Categories  []string  `json:"categories,omitempty"`
+	Updated     time.Time `json:"updated"` // when the event last changed
+}
+
+// property is a content line: NAME;PARAM=VALUE:value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse reads the events of a calendar. Times without a time zone ("floating"
+// times) are read in loc, as are all-day dates.
+func Parse(r io.Reader, loc *time.Location) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []Event{}
+	var current []property
+	inEvent := false
+	for n, line := range lines {
+		if line == "" {
+			continue
+		}
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			inEvent, current = true, nil
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if !inEvent {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", n+1)
+			}
+			event, err := newEvent(current, loc)
+			if err != nil {
+				return nil, fmt.Errorf("event ending on line %d: %w", n+1, err)
+			}
+			events = append(events, event)
+			inEvent = false
+		case inEvent:
+			current = append(current, prop)
+		}
+	}
+	if inEvent {
+		return nil, fmt.Errorf("unterminated VEVENT")
+	}
+
+	return events, nil
+}
+
+// unfold joins the continuation lines (starting with a space or a tab) of a
+// calendar to the lines they continue
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseLine splits a content line into its name, parameters and value
+func parseLine(line string) (property, error) {
+	// The value starts at the first colon outside a quoted parameter value
+	colon := -1
+	quoted := false
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, fmt.Errorf("invalid content line '%s'", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	prop := property{name: strings.ToUpper(parts[0]), params: map[string]string{}, value: line[colon+1:]}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prop, nil
+}
+
+// newEvent builds an event from its properties
+func newEvent(props []property, loc *time.Location) (Event, error) {
+	var event Event
+	var end *time.Time
+	var duration time.Duration
+	hasStart := false
+
+	for _, prop := range props {
+		switch prop.name {
+		case "UID":
+			event.UID = prop.value
+		case "SUMMARY":
+			event.Summary = unescape(prop.value)
+		case "DESCRIPTION":
+			event.Description = unescape(prop.value)
+		case "STATUS":
+			event.Status = strings.ToUpper(prop.value)
+		case "RRULE", "RDATE":
+			event.Recurring = true
+		case "DTSTART":
+			start, allDay, err := parseTime(prop, loc)
+			if err != nil {
+				return event, fmt.Errorf("invalid DTSTART: %w", err)
+			}
+			event.Start, event.AllDay, hasStart = start, allDay, true
+		case "DTEND":
+			t, _, err := parseTime(prop, loc)
+			if err != nil {
+				return event, fmt.Errorf("invalid DTEND: %w", err)
+			}
+			end = &t
+		case "DURATION":
+			d, err := parseDuration(prop.value)
+			if err != nil {
+				return event, fmt.Errorf("invalid DURATION: %w", err)
+			}
+			duration = d
+		}
+	}
+
+	if !hasStart {
+		return event, fmt.Errorf("missing DTSTART")
+	}
+	switch {
+	case end != nil:
+		event.End = *end
+	case duration > 0:
+		event.End = event.Start.Add(duration)
+	case event.AllDay:
+		// An all-day event without an end lasts the day
+		event.End = event.Start.AddDate(0, 0, 1)
+	default:
+		event.End = event.Start
+	}
+	if event.End.Before(event.Start) {
+		return event, fmt.Errorf("event '%s' ends before it starts", event.Summary)
+	}
+
+	return event, nil
+}
+
+// parseTime reads a DATE or DATE-TIME value: 20261225, 20261225T090000Z,
+// or 20261225T090000 with an optional TZID parameter
+func parseTime(prop property, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	zone := loc
+	if tzid := prop.params["TZID"]; tzid != "" {
+		named, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown time zone '%s'", tzid)
+		}
+		zone = named
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, zone)
+	return t, false, err
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration reads a DURATION value such as P1D or PT1H30M
+func parseDuration(value string) (time.Duration, error) {
+	match := durationPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("invalid duration '%s'", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(match[i+2])
+		d += time.Duration(n) * unit
+	}
+	if match[1] == "-" {
+		return 0, fmt.Errorf("negative duration '%s'", value)
+	}
+	return d, nil
+}
+
+// unescape decodes a TEXT value
+func unescape(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(value)
+}
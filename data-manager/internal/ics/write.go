@@ -0,0 +1,156 @@
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProductID identifies the data manager in the calendars it writes
+const ProductID = "-//Fisio Data Manager//Appointments//EN"
+
+// Event statuses
+const (
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Calendar is a calendar to write
+type Calendar struct {
+	Name string // shown by calendar apps
+	// Time zone calendar apps should display the events in; event times are
+	// written in UTC, so they are right whatever the reader's time zone
+	TimeZone string
+	// How often subscribed calendar apps should fetch the calendar again (0
+	// to leave it to them)
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+// maxLineLength is the longest a content line may be, in octets, before it
+// is folded
+const maxLineLength = 75
+
+// Write writes a calendar as an iCalendar file. The output only depends on
+// the calendar, so writing the same calendar twice gives the same file.
+func Write(w io.Writer, cal Calendar) error {
+	out := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", ProductID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		line("X-WR-CALNAME", escape(cal.Name))
+	}
+	if cal.TimeZone != "" {
+		line("X-WR-TIMEZONE", cal.TimeZone)
+	}
+	if cal.RefreshInterval > 0 {
+		interval := formatDuration(cal.RefreshInterval)
+		line("REFRESH-INTERVAL;VALUE=DURATION", interval)
+		line("X-PUBLISHED-TTL", interval)
+	}
+
+	for _, event := range cal.Events {
+		stamp := event.Updated
+		if stamp.IsZero() {
+			stamp = event.Start
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("DTSTAMP", formatTime(stamp))
+		if event.AllDay {
+			line("DTSTART;VALUE=DATE", event.Start.Format("20060102"))
+			line("DTEND;VALUE=DATE", event.End.Format("20060102"))
+		} else {
+			line("DTSTART", formatTime(event.Start))
+			line("DTEND", formatTime(event.End))
+		}
+		if !event.Updated.IsZero() {
+			line("LAST-MODIFIED", formatTime(event.Updated))
+		}
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION", escape(event.Location))
+		}
+		if len(event.Categories) > 0 {
+			categories := make([]string, len(event.Categories))
+			for i, category := range event.Categories {
+				categories[i] = escape(category)
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if event.Status != "" {
+			line("STATUS", event.Status)
+		}
+		// Cancelled events no longer take up time in the reader's calendar
+		if event.Status == StatusCancelled {
+			line("TRANSP", "TRANSPARENT")
+		} else {
+			line("TRANSP", "OPAQUE")
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return out.Flush()
+}
+
+// writeFolded writes a content line, folded into lines of at most 75 octets
+// without splitting a UTF-8 character, ending with CRLF
+func writeFolded(w *bufio.Writer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts
+		limit = maxLineLength - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+// formatTime formats a time as a UTC DATE-TIME value
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// formatDuration formats a duration as a DURATION value such as PT15M
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	value := "PT"
+	if hours := int(d.Hours()); hours > 0 {
+		value += fmt.Sprintf("%dH", hours)
+	}
+	if minutes := int(d.Minutes()) % 60; minutes > 0 {
+		value += fmt.Sprintf("%dM", minutes)
+	}
+	if seconds := int(d.Seconds()) % 60; seconds > 0 || value == "PT" {
+		value += fmt.Sprintf("%dS", seconds)
+	}
+	return value
+}
+
+// escape encodes a TEXT value
+func escape(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return replacer.Replace(value)
+}
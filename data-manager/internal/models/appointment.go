@@ -0,0 +1,231 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/clinic"
+)
+
+// Appointment statuses
+const (
+	AppointmentScheduled = "scheduled"
+	AppointmentConfirmed = "confirmed"
+	AppointmentCancelled = "cancelled"
+	AppointmentCompleted = "completed"
+	AppointmentNoShow    = "no_show"
+)
+
+// AppointmentStatuses lists the appointment statuses
+var AppointmentStatuses = []string{AppointmentScheduled, AppointmentConfirmed, AppointmentCancelled, AppointmentCompleted, AppointmentNoShow}
+
+// Appointment actions, each moving an appointment from one status to another
+const (
+	AppointmentConfirm    = "confirm"
+	AppointmentComplete   = "complete"
+	AppointmentMarkNoShow = "no_show"
+	AppointmentCancel     = "cancel"
+	AppointmentReschedule = "reschedule" // taken when an appointment moves to the time of its calendar event
+)
+
+// AppointmentTransition describes the statuses an appointment action moves an
+// appointment from and to
+type AppointmentTransition struct {
+	From    []string
+	To      string
+	Started bool // only once the appointment has started
+}
+
+// AppointmentTransitions lists the allowed appointment actions. Cancelled,
+// completed and no-show appointments are final; appointments can only be
+// completed or marked as no-show once they have started.
+var AppointmentTransitions = map[string]AppointmentTransition{
+	AppointmentConfirm:    {From: []string{AppointmentScheduled}, To: AppointmentConfirmed},
+	AppointmentComplete:   {From: []string{AppointmentScheduled, AppointmentConfirmed}, To: AppointmentCompleted, Started: true},
+	AppointmentMarkNoShow: {From: []string{AppointmentScheduled, AppointmentConfirmed}, To: AppointmentNoShow, Started: true},
+	AppointmentCancel:     {From: []string{AppointmentScheduled, AppointmentConfirmed}, To: AppointmentCancelled},
+}
+
+// AppointmentStatusChange represents one step of an appointment's status
+// history
+type AppointmentStatusChange struct {
+	ID            string    `json:"id"`
+	AppointmentID string    `json:"appointment_id"`
+	Action        string    `json:"action"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	Actor         string    `json:"actor,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// AppointmentStatusFormData represents an action taken on an appointment
+type AppointmentStatusFormData struct {
+	Action string `json:"action"`
+	Actor  string `json:"actor,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Validate validates the appointment action
+func (d *AppointmentStatusFormData) Validate() error {
+	if _, ok := AppointmentTransitions[d.Action]; !ok {
+		return fmt.Errorf("unknown appointment action '%s'", d.Action)
+	}
+	if d.Action == AppointmentCancel && strings.TrimSpace(d.Reason) == "" {
+		return fmt.Errorf("a reason is required to cancel an appointment")
+	}
+	return nil
+}
+
+// Check reports whether the action can move an appointment in the given
+// status, starting at start
+func (t AppointmentTransition) Check(action, status string, start, now time.Time) error {
+	verb := strings.ReplaceAll(action, "_", "-")
+	if action == AppointmentMarkNoShow {
+		verb = "mark as no-show"
+	}
+
+	allowed := false
+	for _, from := range t.From {
+		if status == from {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("cannot %s an appointment that is %s (must be %s)", verb, status, strings.Join(t.From, " or "))
+	}
+	if t.Started && start.After(now) {
+		return fmt.Errorf("cannot %s an appointment that has not started (starts %s)", verb, start.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+// Appointment represents a patient's appointment, booked on the website
+type Appointment struct {
+	ID                 string     `json:"id"`
+	PatientName        string     `json:"patient_name"`
+	PatientEmail       string     `json:"patient_email"`
+	PatientPhone       string     `json:"patient_phone,omitempty"`
+	AppointmentDate    time.Time  `json:"appointment_date"`
+	AppointmentTime    string     `json:"appointment_time"` // HH:MM
+	Duration           int        `json:"duration"`         // in minutes
+	Status             string     `json:"status"`
+	GoogleEventID      string     `json:"google_event_id,omitempty"`
+	Notes              string     `json:"notes,omitempty"`
+	CancellationReason string     `json:"cancellation_reason,omitempty"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// Start returns when the appointment starts. The date and time are stored as
+// wall-clock times of the clinic, whose time zone is loc.
+func (a *Appointment) Start(loc *time.Location) time.Time {
+	clock, err := time.Parse("15:04", a.AppointmentTime)
+	if err != nil {
+		return time.Date(a.AppointmentDate.Year(), a.AppointmentDate.Month(), a.AppointmentDate.Day(), 0, 0, 0, 0, loc)
+	}
+	return time.Date(a.AppointmentDate.Year(), a.AppointmentDate.Month(), a.AppointmentDate.Day(),
+		clock.Hour(), clock.Minute(), 0, 0, loc)
+}
+
+// End returns when the appointment ends, from its duration
+func (a *Appointment) End(loc *time.Location) time.Time {
+	return a.Start(loc).Add(time.Duration(a.Duration) * time.Minute)
+}
+
+// AppointmentDetails is an appointment with its end time and linked symptom
+// assessments
+type AppointmentDetails struct {
+	Appointment
+	EndTime     string                    `json:"end_time"` // HH:MM
+	Assessments []SymptomAssessment       `json:"assessments"`
+	History     []AppointmentStatusChange `json:"history"`
+}
+
+// AgendaEntry is an appointment on the agenda, with the pain level and
+// symptom from its latest linked assessment
+type AgendaEntry struct {
+	Appointment
+	PainLevel      *int   `json:"pain_level,omitempty"`
+	PrimarySymptom string `json:"primary_symptom,omitempty"`
+}
+
+// AppointmentFilter represents the filters available when listing
+// appointments
+type AppointmentFilter struct {
+	From         *time.Time // appointments on or after this date
+	To           *time.Time // appointments on or before this date
+	Statuses     []string   // appointments in any of these statuses
+	PatientEmail string     // case-insensitive
+	TimeFrom     string     // appointments starting at or after this time (HH:MM)
+	TimeTo       string     // appointments starting before this time (HH:MM)
+	Sort         string     // one of AppointmentSorts (default: date)
+	Descending   bool
+	Limit        int // 0 for no limit
+}
+
+// AppointmentSorts lists the orders appointments can be listed in
+var AppointmentSorts = []string{"date", "patient", "status", "created"}
+
+// TimesOfDay are the named parts of the day accepted by ParseTimeOfDay, as
+// start (inclusive) and end (exclusive) times
+var TimesOfDay = map[string][2]string{
+	"morning":   {"00:00", "12:00"},
+	"afternoon": {"12:00", "17:00"},
+	"evening":   {"17:00", "24:00"},
+}
+
+// ParseAppointmentStatus checks a status as typed on the command line
+// ("no-show", "Confirmed", ...)
+func ParseAppointmentStatus(value string) (string, error) {
+	status := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), "-", "_"))
+	for _, valid := range AppointmentStatuses {
+		if status == valid {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("unknown status '%s' (expected one of %s)", value, strings.Join(AppointmentStatuses, ", "))
+}
+
+// ParseTimeOfDay parses a part of the day: morning, afternoon, evening, or a
+// range such as 09:00-12:30. It returns the start (inclusive) and end
+// (exclusive) times.
+func ParseTimeOfDay(value string) (string, string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if period, ok := TimesOfDay[value]; ok {
+		return period[0], period[1], nil
+	}
+
+	parts := strings.Split(value, "-")
+	if len(parts) == 2 {
+		from, errFrom := parseClock(parts[0])
+		to, errTo := parseClock(parts[1])
+		if errFrom == nil && errTo == nil && from < to {
+			return from, to, nil
+		}
+	}
+	return "", "", fmt.Errorf("invalid time of day '%s' (expected morning, afternoon, evening or a range such as 09:00-12:30)", value)
+}
+
+// parseClock normalizes a time of day to HH:MM, allowing 24:00 as the end
+// of the day
+func parseClock(value string) (string, error) {
+	clock, err := clinic.ParseClock(value)
+	if err != nil {
+		return "", err
+	}
+	return clinic.FormatClock(clock), nil
+}
+
+// ValidAppointmentSort reports whether the sort order is known
+func ValidAppointmentSort(sort string) error {
+	for _, valid := range AppointmentSorts {
+		if sort == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown sort '%s' (expected one of %s)", sort, strings.Join(AppointmentSorts, ", "))
+}
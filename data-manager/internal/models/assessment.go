@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SymptomAssessment represents a patient's symptom assessment, usually
+// submitted when booking an appointment
+type SymptomAssessment struct {
+	ID                 string          `json:"id"`
+	AppointmentID      *string         `json:"appointment_id,omitempty"`
+	PatientName        string          `json:"patient_name"`
+	PatientEmail       string          `json:"patient_email"`
+	PainLevel          int             `json:"pain_level"`
+	AssessmentDate     time.Time       `json:"assessment_date"`
+	Symptoms           SymptomDetails  `json:"symptoms"`
+	PrimarySymptom     string          `json:"primary_symptom"`
+	PainLocations      []string        `json:"pain_locations"`
+	SymptomDuration    string          `json:"symptom_duration"`
+	DailyImpact        string          `json:"daily_impact"`
+	PreviousTreatments string          `json:"previous_treatments,omitempty"`
+	CurrentMedications string          `json:"current_medications,omitempty"`
+	AdditionalNotes    string          `json:"additional_notes,omitempty"`
+	Recommendations    string          `json:"recommendations,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+	RawSymptoms        json.RawMessage `json:"-"`
+}
+
+// SymptomDetails mirrors the symptoms JSONB document written by the booking
+// form (camelCase keys), which follows SymptomAssessmentData in the edge
+// functions' shared types. The pain level is only kept in its own column.
+type SymptomDetails struct {
+	PainLocation       []string `json:"painLocation,omitempty"`
+	SymptomDuration    string   `json:"symptomDuration,omitempty"`
+	PreviousTreatments string   `json:"previousTreatments,omitempty"`
+	CurrentMedications string   `json:"currentMedications,omitempty"`
+	AdditionalNotes    string   `json:"additionalNotes,omitempty"`
+	PrimarySymptom     string   `json:"primarySymptom,omitempty"`
+	SecondarySymptoms  []string `json:"secondarySymptoms,omitempty"`
+	OnsetDate          string   `json:"onsetDate,omitempty"`
+	TriggerEvents      []string `json:"triggerEvents,omitempty"`
+	WorseningFactors   []string `json:"worseningFactors,omitempty"`
+	RelievingFactors   []string `json:"relievingFactors,omitempty"`
+	DailyImpact        string   `json:"dailyImpact,omitempty"`
+}
+
+// Symptom returns the primary symptom, falling back to the JSONB document
+func (a *SymptomAssessment) Symptom() string {
+	if a.PrimarySymptom != "" {
+		return a.PrimarySymptom
+	}
+	return a.Symptoms.PrimarySymptom
+}
+
+// Locations returns the pain locations, falling back to the JSONB document
+func (a *SymptomAssessment) Locations() []string {
+	if len(a.PainLocations) > 0 {
+		return a.PainLocations
+	}
+	return a.Symptoms.PainLocation
+}
+
+// Duration returns how long the symptoms have lasted, falling back to the
+// JSONB document
+func (a *SymptomAssessment) Duration() string {
+	if a.SymptomDuration != "" {
+		return a.SymptomDuration
+	}
+	return a.Symptoms.SymptomDuration
+}
+
+// Impact returns the daily impact, falling back to the JSONB document
+func (a *SymptomAssessment) Impact() string {
+	if a.DailyImpact != "" {
+		return a.DailyImpact
+	}
+	return a.Symptoms.DailyImpact
+}
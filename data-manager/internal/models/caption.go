@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// VideoCaption represents a caption/transcript file attached to a video in
+// one language
+type VideoCaption struct {
+	ID             string    `json:"id"`
+	VideoID        string    `json:"video_id"`
+	Locale         string    `json:"locale"`
+	Format         string    `json:"format"` // vtt or srt
+	SourceFilename *string   `json:"source_filename,omitempty"`
+	CueCount       int       `json:"cue_count"`
+	Duration       Offset    `json:"duration"` // end of the last cue
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TranscriptMatch is a caption cue containing a searched phrase
+type TranscriptMatch struct {
+	VideoID    string `json:"video_id"`
+	VideoTitle string `json:"video_title"`
+	Locale     string `json:"locale"`
+	Start      Offset `json:"start"`
+	End        Offset `json:"end"`
+	Text       string `json:"text"`
+	// DeepLink starts playing the video at the cue
+	DeepLink string `json:"deep_link"`
+}
+
+// VideoWithoutCaptions reports a video lacking captions in a language
+type VideoWithoutCaptions struct {
+	VideoID    string `json:"video_id"`
+	VideoTitle string `json:"video_title"`
+	Category   string `json:"category"`
+	Locale     string `json:"locale"`
+}
+
+// Offset is a position in a video. It is encoded in JSON as seconds.
+type Offset time.Duration
+
+// Seconds returns the offset in whole seconds
+func (o Offset) Seconds() int {
+	return int(time.Duration(o) / time.Second)
+}
+
+// String renders the offset as M:SS, or H:MM:SS from one hour
+func (o Offset) String() string {
+	total := o.Seconds()
+	if total >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", total/3600, total/60%60, total%60)
+	}
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
+
+func (o Offset) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(o).Seconds())
+}
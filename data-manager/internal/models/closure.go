@@ -0,0 +1,148 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/clinic"
+)
+
+// Closure kinds
+const (
+	ClosureHoliday = "holiday"
+	ClosureClinic  = "closure"
+	ClosureBlocked = "blocked"
+)
+
+// ClosureKinds lists the closure kinds
+var ClosureKinds = []string{ClosureHoliday, ClosureClinic, ClosureBlocked}
+
+// Closure represents days, or hours on days, when the clinic is closed
+type Closure struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`             // inclusive
+	StartTime string    `json:"start_time,omitempty"` // HH:MM, empty for whole days
+	EndTime   string    `json:"end_time,omitempty"`   // HH:MM, empty for whole days
+	Reason    string    `json:"reason"`
+	ICSUID    string    `json:"ics_uid,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ClosureFormData represents form data for adding a closure
+type ClosureFormData struct {
+	Kind      string    `json:"kind"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	StartTime string    `json:"start_time"`
+	EndTime   string    `json:"end_time"`
+	Reason    string    `json:"reason"`
+	ICSUID    string    `json:"ics_uid"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// ClosureCollision is an appointment falling within a closure
+type ClosureCollision struct {
+	Closure     Closure     `json:"closure"`
+	Appointment Appointment `json:"appointment"`
+}
+
+// AllDay reports whether the closure covers whole days
+func (c *Closure) AllDay() bool {
+	return c.StartTime == ""
+}
+
+// Period returns when the closure applies on a date, at the clinic whose
+// time zone is loc, and whether it applies on that date at all
+func (c *Closure) Period(date time.Time, loc *time.Location) (time.Time, time.Time, bool) {
+	day := date.Format("2006-01-02")
+	if day < c.StartDate.Format("2006-01-02") || day > c.EndDate.Format("2006-01-02") {
+		return time.Time{}, time.Time{}, false
+	}
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	if c.AllDay() {
+		return midnight, midnight.AddDate(0, 0, 1), true
+	}
+	start, errStart := clinic.ParseClock(c.StartTime)
+	end, errEnd := clinic.ParseClock(c.EndTime)
+	if errStart != nil || errEnd != nil {
+		return midnight, midnight.AddDate(0, 0, 1), true
+	}
+	// Wall-clock times, so days when the clocks change keep their hours
+	at := func(clock time.Duration) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(),
+			int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, loc)
+	}
+	return at(start), at(end), true
+}
+
+// Collides reports whether an appointment that is not cancelled falls within
+// the closure
+func (c *Closure) Collides(a *Appointment, loc *time.Location) bool {
+	if a.Status == AppointmentCancelled {
+		return false
+	}
+	start, end, ok := c.Period(a.AppointmentDate, loc)
+	if !ok {
+		return false
+	}
+	return a.Start(loc).Before(end) && start.Before(a.End(loc))
+}
+
+// Validate validates the closure form data
+func (c *ClosureFormData) Validate() error {
+	if err := ValidClosureKind(c.Kind); err != nil {
+		return err
+	}
+	if strings.TrimSpace(c.Reason) == "" {
+		return fmt.Errorf("a reason is required")
+	}
+	if c.StartDate.IsZero() {
+		return fmt.Errorf("start date is required")
+	}
+	if c.EndDate.IsZero() {
+		c.EndDate = c.StartDate
+	}
+	if c.EndDate.Before(c.StartDate) {
+		return fmt.Errorf("end date cannot be before start date")
+	}
+
+	if (c.StartTime == "") != (c.EndTime == "") {
+		return fmt.Errorf("blocked hours need both a start and an end time")
+	}
+	if c.StartTime != "" {
+		start, err := parseClock(c.StartTime)
+		if err != nil {
+			return fmt.Errorf("invalid start time '%s' (expected HH:MM)", c.StartTime)
+		}
+		end, err := parseClock(c.EndTime)
+		if err != nil {
+			return fmt.Errorf("invalid end time '%s' (expected HH:MM)", c.EndTime)
+		}
+		if end <= start {
+			return fmt.Errorf("end time must be after start time")
+		}
+		c.StartTime, c.EndTime = start, end
+	}
+	if c.Kind == ClosureBlocked && c.StartTime == "" {
+		return fmt.Errorf("blocked closures need a start and end time")
+	}
+	if c.Kind != ClosureBlocked && c.StartTime != "" {
+		return fmt.Errorf("%s closures cover whole days; use kind %s for hours", c.Kind, ClosureBlocked)
+	}
+	return nil
+}
+
+// ValidClosureKind reports whether the closure kind is known
+func ValidClosureKind(kind string) error {
+	for _, valid := range ClosureKinds {
+		if kind == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown closure kind '%s' (expected one of %s)", kind, strings.Join(ClosureKinds, ", "))
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HEPAssignment represents a video or program assigned to a patient as part
+// of their home exercise program
+type HEPAssignment struct {
+	ID             string     `json:"id"`
+	PatientEmail   string     `json:"patient_email"`
+	PatientName    string     `json:"patient_name,omitempty"`
+	ProgramID      *string    `json:"program_id,omitempty"`
+	VideoID        *string    `json:"video_id,omitempty"`
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        *time.Time `json:"end_date,omitempty"`
+	Dosage                    // for single-video assignments (frequency applies to programs too)
+	ClinicianNotes string     `json:"clinician_notes,omitempty"`
+	AssignedBy     string     `json:"assigned_by,omitempty"`
+	Status         string     `json:"status"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+	EndReason      string     `json:"end_reason,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Joined fields
+	ProgramName *string       `json:"program_name,omitempty"`
+	VideoTitle  *string       `json:"video_title,omitempty"`
+	VideoURL    *string       `json:"video_url,omitempty"`
+	Items       []ProgramItem `json:"items,omitempty"` // program entries, loaded for handouts
+}
+
+// HEPAssignmentFormData represents form data for assigning exercises to a patient
+type HEPAssignmentFormData struct {
+	PatientEmail string     `json:"patient_email"`
+	PatientName  string     `json:"patient_name"`
+	ProgramID    string     `json:"program_id"`
+	VideoID      string     `json:"video_id"`
+	StartDate    time.Time  `json:"start_date"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+	Dosage
+	ClinicianNotes string `json:"clinician_notes"`
+	AssignedBy     string `json:"assigned_by"`
+}
+
+// Title returns the name of the assigned program or video
+func (a *HEPAssignment) Title() string {
+	if a.ProgramName != nil {
+		return *a.ProgramName
+	}
+	if a.VideoTitle != nil {
+		return *a.VideoTitle
+	}
+	return ""
+}
+
+// IsActiveOn reports whether the assignment applies on the given date
+func (a *HEPAssignment) IsActiveOn(date time.Time) bool {
+	if a.Status != "active" {
+		return false
+	}
+	day := date.Format("2006-01-02")
+	if a.StartDate.Format("2006-01-02") > day {
+		return false
+	}
+	return a.EndDate == nil || a.EndDate.Format("2006-01-02") >= day
+}
+
+// Validate validates the assignment form data
+func (h *HEPAssignmentFormData) Validate() error {
+	email := strings.TrimSpace(h.PatientEmail)
+	if email == "" {
+		return fmt.Errorf("patient email is required")
+	}
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("invalid patient email '%s'", h.PatientEmail)
+	}
+	if (h.ProgramID == "") == (h.VideoID == "") {
+		return fmt.Errorf("exactly one of program or video is required")
+	}
+	if h.StartDate.IsZero() {
+		return fmt.Errorf("start date is required")
+	}
+	if h.EndDate != nil && h.EndDate.Before(h.StartDate) {
+		return fmt.Errorf("end date cannot be before start date")
+	}
+	return h.Dosage.Validate()
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Dosage represents how an exercise is prescribed
+type Dosage struct {
+	Sets        *int   `json:"sets,omitempty"`
+	Reps        *int   `json:"reps,omitempty"`
+	HoldSeconds *int   `json:"hold_seconds,omitempty"`
+	RestSeconds *int   `json:"rest_seconds,omitempty"`
+	Frequency   string `json:"frequency,omitempty"`
+}
+
+// ExerciseProgram represents a named, ordered sequence of exercise videos
+type ExerciseProgram struct {
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	DurationWeeks *int          `json:"duration_weeks,omitempty"`
+	Items         []ProgramItem `json:"items"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+
+	// Computed fields
+	ItemCount int `json:"item_count"`
+}
+
+// ProgramItem represents one exercise video of a program
+type ProgramItem struct {
+	ID        string `json:"id"`
+	ProgramID string `json:"program_id"`
+	VideoID   string `json:"video_id"`
+	Position  int    `json:"position"`
+	Dosage
+	Notes     string    `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Joined fields
+	VideoTitle string `json:"video_title"`
+	VideoURL   string `json:"video_url"`
+}
+
+// ProgramFormData represents form data for creating/updating programs
+type ProgramFormData struct {
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	DurationWeeks *int   `json:"duration_weeks,omitempty"`
+}
+
+// ProgramItemFormData represents form data for adding/updating program items
+type ProgramItemFormData struct {
+	VideoID  string `json:"video_id"`
+	Position int    `json:"position"` // 0 appends to the end
+	Dosage
+	Notes string `json:"notes"`
+}
+
+// ToJSON converts the program to JSON string
+func (p *ExerciseProgram) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Validate validates the dosage
+func (d *Dosage) Validate() error {
+	if d.Sets != nil && *d.Sets <= 0 {
+		return fmt.Errorf("sets must be greater than 0")
+	}
+	if d.Reps != nil && *d.Reps <= 0 {
+		return fmt.Errorf("reps must be greater than 0")
+	}
+	if d.HoldSeconds != nil && *d.HoldSeconds < 0 {
+		return fmt.Errorf("hold time cannot be negative")
+	}
+	if d.RestSeconds != nil && *d.RestSeconds < 0 {
+		return fmt.Errorf("rest time cannot be negative")
+	}
+	return nil
+}
+
+// Summary renders the dosage for people, e.g. "3 sets × 10 reps, hold 5s, rest 30s, 2x daily"
+func (d *Dosage) Summary() string {
+	var parts []string
+	switch {
+	case d.Sets != nil && d.Reps != nil:
+		parts = append(parts, fmt.Sprintf("%d sets × %d reps", *d.Sets, *d.Reps))
+	case d.Sets != nil:
+		parts = append(parts, fmt.Sprintf("%d sets", *d.Sets))
+	case d.Reps != nil:
+		parts = append(parts, fmt.Sprintf("%d reps", *d.Reps))
+	}
+	if d.HoldSeconds != nil && *d.HoldSeconds > 0 {
+		parts = append(parts, fmt.Sprintf("hold %ds", *d.HoldSeconds))
+	}
+	if d.RestSeconds != nil && *d.RestSeconds > 0 {
+		parts = append(parts, fmt.Sprintf("rest %ds", *d.RestSeconds))
+	}
+	if d.Frequency != "" {
+		parts = append(parts, d.Frequency)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Validate validates the program form data
+func (p *ProgramFormData) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if p.DurationWeeks != nil && *p.DurationWeeks <= 0 {
+		return fmt.Errorf("duration in weeks must be greater than 0")
+	}
+	return nil
+}
+
+// Validate validates the program item form data
+func (i *ProgramItemFormData) Validate() error {
+	if i.VideoID == "" {
+		return fmt.Errorf("video ID is required")
+	}
+	if i.Position < 0 {
+		return fmt.Errorf("position cannot be negative")
+	}
+	return i.Dosage.Validate()
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Review states of a video. Only published videos are public.
+const (
+	VideoStatusDraft     = "draft"
+	VideoStatusInReview  = "in_review"
+	VideoStatusApproved  = "approved"
+	VideoStatusPublished = "published"
+)
+
+// VideoStatuses lists the review states in workflow order
+var VideoStatuses = []string{VideoStatusDraft, VideoStatusInReview, VideoStatusApproved, VideoStatusPublished}
+
+// Review actions, each moving a video from one state to another
+const (
+	ReviewSubmit    = "submit"
+	ReviewApprove   = "approve"
+	ReviewReject    = "reject"
+	ReviewPublish   = "publish"
+	ReviewUnpublish = "unpublish"
+	ReviewExpire    = "expire" // taken by the scheduler at the expire date
+	ReviewEdit      = "edit"   // taken when the content of an approved or published video changes
+)
+
+// SchedulerActor is the reviewer recorded for the scheduler's steps
+const SchedulerActor = "scheduler"
+
+// ScheduleSkip is the scheduler action for videos due to be published that
+// are not approved yet
+const ScheduleSkip = "skip"
+
+// ReviewTransition describes the states a review action moves a video from
+// and to
+type ReviewTransition struct {
+	From []string
+	To   string
+}
+
+// ReviewTransitions lists the allowed review actions
+var ReviewTransitions = map[string]ReviewTransition{
+	ReviewSubmit:    {From: []string{VideoStatusDraft}, To: VideoStatusInReview},
+	ReviewApprove:   {From: []string{VideoStatusInReview}, To: VideoStatusApproved},
+	ReviewReject:    {From: []string{VideoStatusInReview, VideoStatusApproved}, To: VideoStatusDraft},
+	ReviewPublish:   {From: []string{VideoStatusApproved}, To: VideoStatusPublished},
+	ReviewUnpublish: {From: []string{VideoStatusPublished}, To: VideoStatusApproved},
+	ReviewExpire:    {From: []string{VideoStatusPublished}, To: VideoStatusApproved},
+}
+
+// VideoReview represents one step of a video's review log
+type VideoReview struct {
+	ID         string    `json:"id"`
+	VideoID    string    `json:"video_id"`
+	Action     string    `json:"action"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Actor      string    `json:"actor"`
+	Comment    *string   `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ReviewFormData represents a review action taken on a video
+type ReviewFormData struct {
+	Action  string `json:"action"`
+	Actor   string `json:"actor"`
+	Comment string `json:"comment"`
+}
+
+// ParseVideoStatus checks a review state as typed on the command line
+// ("in-review", "Published", ...)
+func ParseVideoStatus(value string) (string, error) {
+	status := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), "-", "_"))
+	for _, valid := range VideoStatuses {
+		if status == valid {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("unknown status '%s' (expected one of %s)", value, strings.Join(VideoStatuses, ", "))
+}
+
+// Validate validates the review action
+func (r *ReviewFormData) Validate() error {
+	if _, ok := ReviewTransitions[r.Action]; !ok {
+		return fmt.Errorf("unknown review action '%s'", r.Action)
+	}
+	if strings.TrimSpace(r.Actor) == "" {
+		return fmt.Errorf("reviewer is required")
+	}
+	if (r.Action == ReviewReject || r.Action == ReviewUnpublish) && strings.TrimSpace(r.Comment) == "" {
+		return fmt.Errorf("a reason is required to %s a video", r.Action)
+	}
+	return nil
+}
+
+// Check reports whether the action can move a video in the given state,
+// submitted by submittedBy
+func (t ReviewTransition) Check(action, status string, actor string, submittedBy *string) error {
+	allowed := false
+	for _, from := range t.From {
+		if status == from {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("cannot %s a video that is %s (must be %s)", action, status, strings.Join(t.From, " or "))
+	}
+	if action == ReviewApprove && submittedBy != nil && strings.EqualFold(strings.TrimSpace(*submittedBy), strings.TrimSpace(actor)) {
+		return fmt.Errorf("a video must be approved by someone other than its submitter (%s)", *submittedBy)
+	}
+	return nil
+}
+
+// Results of a scheduler change
+const (
+	ScheduleApplied = "applied"
+	ScheduleFailed  = "failed"
+)
+
+// ScheduledChange reports a change made (or due) by the publishing scheduler
+type ScheduledChange struct {
+	VideoID string    `json:"video_id"`
+	Title   string    `json:"title"`
+	Action  string    `json:"action"` // publish, expire or skip
+	DueAt   time.Time `json:"due_at"`
+	Status  string    `json:"status"`           // status of the video before the change
+	Result  string    `json:"result,omitempty"` // applied or failed; empty for skips and dry runs
+	Error   string    `json:"error,omitempty"`  // why the change failed
+}
+
+// scheduleLayouts are the accepted formats of publish and expire times
+var scheduleLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
+// ParseScheduleTime parses a publish or expire time: a date (midnight) or a
+// date and time, in local time unless an offset is given. An empty value is
+// no time.
+func ParseScheduleTime(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range scheduleLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid time '%s' (expected YYYY-MM-DD, YYYY-MM-DD HH:MM or RFC 3339)", value)
+}
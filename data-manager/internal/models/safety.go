@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition is a patient condition that videos can be contraindicated for,
+// or call for precautions with
+type Condition struct {
+	Name    string   `json:"name"`
+	Label   string   `json:"label"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Conditions is the vocabulary of the contraindications and precautions of
+// videos. Keep it in sync with the check constraints on exercise_videos.
+var Conditions = []Condition{
+	{Name: "early_post_op", Label: "Early post-operative (first weeks after surgery)", Aliases: []string{"early post-op", "early postop", "post-op"}},
+	{Name: "pregnancy", Label: "Pregnancy", Aliases: []string{"pregnant"}},
+	{Name: "osteoporosis", Label: "Osteoporosis", Aliases: []string{"osteopenia"}},
+	{Name: "hypertension", Label: "Uncontrolled high blood pressure", Aliases: []string{"high blood pressure"}},
+	{Name: "cardiac_condition", Label: "Heart condition", Aliases: []string{"heart condition", "cardiac"}},
+	{Name: "acute_disc_herniation", Label: "Acute disc herniation", Aliases: []string{"disc herniation", "herniated disc"}},
+	{Name: "hip_replacement", Label: "Hip replacement", Aliases: []string{"total hip replacement", "thr"}},
+	{Name: "knee_replacement", Label: "Knee replacement", Aliases: []string{"total knee replacement", "tkr"}},
+	{Name: "recent_fracture", Label: "Recent fracture", Aliases: []string{"fracture"}},
+	{Name: "acute_inflammation", Label: "Acute inflammation or injury", Aliases: []string{"acute injury"}},
+	{Name: "balance_disorder", Label: "Balance disorder or fall risk", Aliases: []string{"fall risk", "vertigo"}},
+	{Name: "joint_hypermobility", Label: "Joint hypermobility", Aliases: []string{"hypermobility"}},
+}
+
+// ConditionNames returns the names of every condition
+func ConditionNames() []string {
+	names := make([]string, len(Conditions))
+	for i, condition := range Conditions {
+		names[i] = condition.Name
+	}
+	return names
+}
+
+// ParseCondition maps a condition name, label or alias ("Early post-op",
+// "pregnant") to its name
+func ParseCondition(value string) (string, error) {
+	key := conditionKey(value)
+	for _, condition := range Conditions {
+		if key == conditionKey(condition.Name) || key == conditionKey(condition.Label) {
+			return condition.Name, nil
+		}
+		for _, alias := range condition.Aliases {
+			if key == conditionKey(alias) {
+				return condition.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unknown condition '%s' (expected one of %s)", value, strings.Join(ConditionNames(), ", "))
+}
+
+// ParseConditions maps a list of conditions to their names, without blanks or
+// duplicates
+func ParseConditions(values []string) ([]string, error) {
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		name, err := ParseCondition(value)
+		if err != nil {
+			return nil, err
+		}
+		if !containsFold(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// conditionKey folds case, hyphens and underscores so "Early post-op" and
+// "early_post_op" compare equal
+func conditionKey(value string) string {
+	value = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(value))
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// ContraindicationsFor returns the conditions, among the given ones, the
+// video must not be given for
+func (v *ExerciseVideo) ContraindicationsFor(conditions []string) []string {
+	return matchConditions(v.Contraindications, conditions)
+}
+
+// PrecautionsFor returns the conditions, among the given ones, that call for
+// precautions with the video
+func (v *ExerciseVideo) PrecautionsFor(conditions []string) []string {
+	return matchConditions(v.Precautions, conditions)
+}
+
+// TooSoonAfterSurgery reports whether a patient weeks after surgery (nil when
+// there was none) has to wait longer before doing the video
+func (v *ExerciseVideo) TooSoonAfterSurgery(weeksPostSurgery *int) bool {
+	return weeksPostSurgery != nil && v.MinWeeksPostSurgery != nil && *weeksPostSurgery < *v.MinWeeksPostSurgery
+}
+
+func matchConditions(values []string, conditions []string) []string {
+	var matched []string
+	for _, condition := range conditions {
+		if containsFold(values, condition) {
+			matched = append(matched, condition)
+		}
+	}
+	return matched
+}
+
+// validateSafety checks the contraindications, precautions and minimum weeks
+// after surgery of a video
+func validateSafety(contraindications, precautions []string, minWeeksPostSurgery *int) error {
+	contraindicated, err := ParseConditions(contraindications)
+	if err != nil {
+		return fmt.Errorf("invalid contraindication: %w", err)
+	}
+	cautions, err := ParseConditions(precautions)
+	if err != nil {
+		return fmt.Errorf("invalid precaution: %w", err)
+	}
+	for _, condition := range cautions {
+		if containsFold(contraindicated, condition) {
+			return fmt.Errorf("'%s' cannot be both a contraindication and a precaution", condition)
+		}
+	}
+	if minWeeksPostSurgery != nil && (*minWeeksPostSurgery < 0 || *minWeeksPostSurgery > 104) {
+		return fmt.Errorf("minimum weeks post-surgery must be between 0 and 104")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaxonomyKind identifies one of the controlled vocabularies
+type TaxonomyKind string
+
+const (
+	TaxonomyEquipment TaxonomyKind = "equipment"
+	TaxonomyBodyPart  TaxonomyKind = "body_part"
+	TaxonomyTag       TaxonomyKind = "tag"
+)
+
+// TaxonomyKinds lists every vocabulary in display order
+var TaxonomyKinds = []TaxonomyKind{TaxonomyEquipment, TaxonomyBodyPart, TaxonomyTag}
+
+// TaxonomyTerm represents a canonical term and its synonyms
+type TaxonomyTerm struct {
+	ID        string       `json:"id"`
+	Kind      TaxonomyKind `json:"kind"`
+	Name      string       `json:"name"`
+	Synonyms  []string     `json:"synonyms"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// ParseTaxonomyKind parses a kind as typed on the command line
+// ("body-parts", "tags", ...)
+func ParseTaxonomyKind(value string) (TaxonomyKind, error) {
+	switch strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), "-", "_")) {
+	case "equipment":
+		return TaxonomyEquipment, nil
+	case "body_part", "body_parts":
+		return TaxonomyBodyPart, nil
+	case "tag", "tags":
+		return TaxonomyTag, nil
+	}
+	return "", fmt.Errorf("unknown taxonomy kind '%s': must be 'equipment', 'body_part' or 'tag'", value)
+}
+
+// Column returns the exercise_videos array column holding this vocabulary
+func (k TaxonomyKind) Column() string {
+	switch k {
+	case TaxonomyEquipment:
+		return "equipment_required"
+	case TaxonomyBodyPart:
+		return "body_parts"
+	default:
+		return "tags"
+	}
+}
+
+// Values returns the video's values for this vocabulary
+func (k TaxonomyKind) Values(v *ExerciseVideo) []string {
+	switch k {
+	case TaxonomyEquipment:
+		return v.EquipmentRequired
+	case TaxonomyBodyPart:
+		return v.BodyParts
+	default:
+		return v.Tags
+	}
+}
+
+// TermUsage represents how many videos use a term, split by difficulty
+type TermUsage struct {
+	Term         string   `json:"term"`
+	VideoCount   int      `json:"video_count"`
+	Beginner     int      `json:"beginner"`
+	Intermediate int      `json:"intermediate"`
+	Advanced     int      `json:"advanced"`
+	Categories   []string `json:"categories"`
+}
+
+// TermStats represents usage statistics for one vocabulary
+type TermStats struct {
+	Kind            TaxonomyKind `json:"kind"`
+	Terms           []TermUsage  `json:"terms"`
+	SingleUse       []string     `json:"single_use"`
+	EmptyCategories []string     `json:"empty_categories"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is the locale of the texts stored on videos and categories
+const DefaultLocale = "en"
+
+// Locales lists the locales content is translated to, default first
+var Locales = []string{"en", "pt", "es"}
+
+// Translation holds the translated texts of a video (title) or category
+// (name) in one locale. Empty fields fall back to the default locale.
+type Translation struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// VideoTranslation represents a stored translation of a video
+type VideoTranslation struct {
+	VideoID string `json:"video_id"`
+	Locale  string `json:"locale"`
+	Translation
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MissingTranslation reports content lacking a translation in a locale
+type MissingTranslation struct {
+	Kind    string   `json:"kind"` // video or category
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Locale  string   `json:"locale"`
+	Missing []string `json:"missing"` // fields without a translation
+}
+
+// ParseLocale normalizes a language code ("PT", "pt-BR" -> "pt") and checks
+// that it is supported
+func ParseLocale(value string) (string, error) {
+	locale := strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		locale = locale[:i]
+	}
+	for _, supported := range Locales {
+		if locale == supported {
+			return locale, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported language '%s' (expected one of %s)", value, strings.Join(Locales, ", "))
+}
+
+// IsEmpty reports whether the translation has no text
+func (t Translation) IsEmpty() bool {
+	return strings.TrimSpace(t.Title) == "" && strings.TrimSpace(t.Description) == ""
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -22,34 +23,105 @@ type ExerciseVideo struct {
 	ID                string    `json:"id"`
 	Title             string    `json:"title"`
 	Description       string    `json:"description"`
-	YoutubeID         string    `json:"youtube_id"`
-	YoutubeURL        string    `json:"youtube_url"`
+	Provider          string    `json:"provider"`
+	ExternalID        string    `json:"external_id"`
+	VideoURL          string    `json:"video_url"`
+	YoutubeID         string    `json:"youtube_id,omitempty"`  // YouTube videos only
+	YoutubeURL        string    `json:"youtube_url,omitempty"` // YouTube videos only
 	CategoryID        string    `json:"category_id"`
+	CategoryIDs       []string  `json:"category_ids"`
 	Duration          *int      `json:"duration,omitempty"`
 	DifficultyLevel   string    `json:"difficulty_level"`
 	EquipmentRequired []string  `json:"equipment_required"`
 	BodyParts         []string  `json:"body_parts"`
 	Tags              []string  `json:"tags"`
+
+	// Safety information: conditions the video must not be given for, or
+	// calls for precautions with (see Conditions), and how many weeks after
+	// surgery a patient must be before doing it
+	Contraindications   []string `json:"contraindications"`
+	Precautions         []string `json:"precautions"`
+	MinWeeksPostSurgery *int     `json:"min_weeks_post_surgery,omitempty"`
+
+	// Review workflow (see VideoStatuses)
+	Status      string     `json:"status"`
+	SubmittedBy *string    `json:"submitted_by,omitempty"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	ReviewedBy  *string    `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// Publishing window, applied by the scheduler
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	ExpireAt  *time.Time `json:"expire_at,omitempty"`
+
 	ThumbnailURL      *string   `json:"thumbnail_url,omitempty"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 	
+	// Computed fields
+	EmbedURL string `json:"embed_url"`
+
 	// Joined fields
-	CategoryName        *string `json:"category_name,omitempty"`
-	CategoryDescription *string `json:"category_description,omitempty"`
+	CategoryName        *string  `json:"category_name,omitempty"`
+	CategoryDescription *string  `json:"category_description,omitempty"`
+	CategoryNames       []string `json:"category_names,omitempty"`
 }
 
 // VideoFormData represents form data for creating/updating videos
 type VideoFormData struct {
 	Title             string   `json:"title"`
 	Description       string   `json:"description"`
-	YoutubeURL        string   `json:"youtube_url"`
-	CategoryID        string   `json:"category_id"`
+	VideoURL          string   `json:"video_url"`
+	Provider          string   `json:"provider,omitempty"`      // detected from the URL when empty
+	ThumbnailURL      string   `json:"thumbnail_url,omitempty"` // default: the provider's thumbnail
+	CategoryID        string   `json:"category_id"`             // primary category
+	CategoryIDs       []string `json:"category_ids,omitempty"`  // additional categories
 	Duration          *int     `json:"duration,omitempty"`
 	DifficultyLevel   string   `json:"difficulty_level"`
 	EquipmentRequired []string `json:"equipment_required"`
 	BodyParts         []string `json:"body_parts"`
 	Tags              []string `json:"tags"`
+
+	// Safety information, using the Conditions vocabulary
+	Contraindications   []string `json:"contraindications,omitempty"`
+	Precautions         []string `json:"precautions,omitempty"`
+	MinWeeksPostSurgery *int     `json:"min_weeks_post_surgery,omitempty"`
+
+	// Publishing window: approved videos are published at PublishAt and
+	// unpublished at ExpireAt by the scheduler
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	ExpireAt  *time.Time `json:"expire_at,omitempty"`
+
+	// Translations by locale, set along with the video (e.g. from CSV import)
+	Translations map[string]Translation `json:"translations,omitempty"`
+}
+
+// VideoFilter represents the filters available when listing videos
+type VideoFilter struct {
+	CategoryIDs        []string // videos in any of these categories
+	MatchAllCategories bool     // require every category in CategoryIDs instead of any
+	Difficulty         string
+	Tags               []string   // videos with any of these tags (case-insensitive)
+	BodyParts          []string   // videos targeting any of these body parts (case-insensitive)
+	IDs                []string   // only these videos
+	Search             string     // text in the title, description or tags (case-insensitive)
+	SafeFor            []string   // leave out videos contraindicated for any of these conditions
+	WeeksPostSurgery   *int       // leave out videos needing more weeks after surgery
+	Statuses           []string   // videos in any of these review states
+	PublicAt           *time.Time // videos visible to the public at this time (published, inside their window)
+}
+
+// BulkVideoUpdate represents the operations applied by a bulk update
+type BulkVideoUpdate struct {
+	AddTags          []string `json:"add_tags,omitempty"`
+	RemoveTags       []string `json:"remove_tags,omitempty"`
+	AddBodyParts     []string `json:"add_body_parts,omitempty"`
+	RemoveBodyParts  []string `json:"remove_body_parts,omitempty"`
+	AddEquipment     []string `json:"add_equipment,omitempty"`
+	RemoveEquipment  []string `json:"remove_equipment,omitempty"`
+	SetDifficulty    string   `json:"set_difficulty,omitempty"`
+	MoveToCategoryID string   `json:"move_to_category_id,omitempty"`
 }
 
 // CategoryFormData represents form data for creating/updating categories
@@ -83,18 +155,145 @@ func (v *VideoFormData) Validate() error {
 	if v.Title == "" {
 		return fmt.Errorf("title is required")
 	}
-	if v.YoutubeURL == "" {
-		return fmt.Errorf("youtube URL is required")
+	if v.VideoURL == "" {
+		return fmt.Errorf("video URL is required")
 	}
-	if v.CategoryID == "" {
+	if v.PrimaryCategoryID() == "" {
 		return fmt.Errorf("category ID is required")
 	}
 	if v.DifficultyLevel != "" && v.DifficultyLevel != "beginner" && v.DifficultyLevel != "intermediate" && v.DifficultyLevel != "advanced" {
 		return fmt.Errorf("difficulty level must be 'beginner', 'intermediate', or 'advanced'")
 	}
+	if err := validateSafety(v.Contraindications, v.Precautions, v.MinWeeksPostSurgery); err != nil {
+		return err
+	}
+	if v.PublishAt != nil && v.ExpireAt != nil && !v.ExpireAt.After(*v.PublishAt) {
+		return fmt.Errorf("expire date must be after the publish date")
+	}
+	for locale := range v.Translations {
+		if parsed, err := ParseLocale(locale); err != nil || parsed != locale {
+			return fmt.Errorf("invalid translation locale '%s'", locale)
+		}
+		if locale == DefaultLocale {
+			return fmt.Errorf("'%s' is the default locale, set the title and description instead", locale)
+		}
+	}
+	return nil
+}
+
+// PrimaryCategoryID returns the primary category, falling back to the first
+// of the additional categories when no primary was given
+func (v *VideoFormData) PrimaryCategoryID() string {
+	if v.CategoryID != "" {
+		return v.CategoryID
+	}
+	for _, id := range v.CategoryIDs {
+		if id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// AllCategoryIDs returns the primary category followed by the additional
+// categories, without blanks or duplicates
+func (v *VideoFormData) AllCategoryIDs() []string {
+	primary := v.PrimaryCategoryID()
+	ids := []string{primary}
+	seen := map[string]bool{primary: true}
+	for _, id := range v.CategoryIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Validate validates the bulk update operations
+func (u *BulkVideoUpdate) Validate() error {
+	if len(u.AddTags) == 0 && len(u.RemoveTags) == 0 &&
+		len(u.AddBodyParts) == 0 && len(u.RemoveBodyParts) == 0 &&
+		len(u.AddEquipment) == 0 && len(u.RemoveEquipment) == 0 &&
+		u.SetDifficulty == "" && u.MoveToCategoryID == "" {
+		return fmt.Errorf("at least one update operation is required")
+	}
+	if u.SetDifficulty != "" && u.SetDifficulty != "beginner" && u.SetDifficulty != "intermediate" && u.SetDifficulty != "advanced" {
+		return fmt.Errorf("difficulty level must be 'beginner', 'intermediate', or 'advanced'")
+	}
 	return nil
 }
 
+// Apply applies the operations to a video and reports whether it changed.
+// Category moves are not applied here since they live in a separate table.
+func (u *BulkVideoUpdate) Apply(v *ExerciseVideo) bool {
+	changed := false
+	apply := func(values *[]string, add []string, remove []string) {
+		updated := addValues(RemoveValues(*values, remove), add)
+		if !EqualValues(*values, updated) {
+			*values = updated
+			changed = true
+		}
+	}
+
+	apply(&v.Tags, u.AddTags, u.RemoveTags)
+	apply(&v.BodyParts, u.AddBodyParts, u.RemoveBodyParts)
+	apply(&v.EquipmentRequired, u.AddEquipment, u.RemoveEquipment)
+
+	if u.SetDifficulty != "" && v.DifficultyLevel != u.SetDifficulty {
+		v.DifficultyLevel = u.SetDifficulty
+		changed = true
+	}
+
+	return changed
+}
+
+// addValues appends values that are not present yet (case-insensitive)
+func addValues(values []string, add []string) []string {
+	result := append([]string{}, values...)
+	for _, value := range add {
+		if !containsFold(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// RemoveValues drops values matching any of the given ones (case-insensitive)
+func RemoveValues(values []string, remove []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !containsFold(remove, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// EqualValues reports whether two lists hold the same values in the same
+// order
+func EqualValues(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // Validate validates the category form data
 func (c *CategoryFormData) Validate() error {
 	if c.Name == "" {
@@ -0,0 +1,67 @@
+package providers
+
+import (
+	"fmt"
+	neturl "net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+var videoFileExtensions = []string{".mp4", ".m4v", ".webm", ".mov"}
+
+// MP4 handles self-hosted video files. The file URL is the video's ID.
+type MP4 struct{}
+
+func (MP4) Name() string {
+	return "mp4"
+}
+
+func (MP4) Match(url string) bool {
+	parsed, err := neturl.Parse(strings.TrimSpace(url))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(parsed.Path))
+	for _, allowed := range videoFileExtensions {
+		if ext == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (p MP4) ExtractID(url string) (string, error) {
+	url = strings.TrimSpace(url)
+	parsed, err := neturl.Parse(url)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", fmt.Errorf("invalid video file URL '%s': must be an http(s) URL", url)
+	}
+	if !p.Match(url) {
+		return "", fmt.Errorf("invalid video file URL '%s': expected one of %s", url, strings.Join(videoFileExtensions, ", "))
+	}
+	return url, nil
+}
+
+func (MP4) CanonicalURL(id string) string {
+	return id
+}
+
+func (MP4) EmbedURL(id string) string {
+	return id
+}
+
+// ThumbnailURL returns "" as video files have no thumbnail; set one on the
+// video instead
+func (MP4) ThumbnailURL(id string) string {
+	return ""
+}
+
+// DeepLink uses a media fragment (#t=seconds), which browsers honour when
+// playing the file
+func (MP4) DeepLink(id string, offset time.Duration) string {
+	if i := strings.Index(id, "#"); i >= 0 {
+		id = id[:i]
+	}
+	return fmt.Sprintf("%s#t=%d", id, int(offset.Seconds()))
+}
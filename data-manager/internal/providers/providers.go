@@ -0,0 +1,90 @@
+// Package providers knows how to handle the hosts exercise videos can live
+// on: extracting video IDs from URLs and building canonical, embed,
+// thumbnail and deep-link URLs.
+package providers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Provider handles the URLs of one video host
+type Provider interface {
+	// Name is the value stored in exercise_videos.provider
+	Name() string
+	// Match reports whether the URL belongs to this provider
+	Match(url string) bool
+	// ExtractID returns the provider's ID for the video at the URL
+	ExtractID(url string) (string, error)
+	// CanonicalURL returns the URL stored for the video
+	CanonicalURL(id string) string
+	// EmbedURL returns the URL used to embed the video in a page
+	EmbedURL(id string) string
+	// ThumbnailURL returns the video's thumbnail, or "" when the provider
+	// has none
+	ThumbnailURL(id string) string
+	// DeepLink returns a URL that starts playing the video at an offset
+	DeepLink(id string, offset time.Duration) string
+}
+
+// registry lists the providers, in the order they are tried when detecting
+// the provider of a URL
+var registry = []Provider{
+	YouTube{},
+	Vimeo{},
+	MP4{},
+}
+
+// Names returns the names of every provider
+func Names() []string {
+	names := make([]string, len(registry))
+	for i, provider := range registry {
+		names[i] = provider.Name()
+	}
+	return names
+}
+
+// Get returns the provider with the given name
+func Get(name string) (Provider, error) {
+	for _, provider := range registry {
+		if strings.EqualFold(provider.Name(), strings.TrimSpace(name)) {
+			return provider, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown video provider '%s' (expected one of %s)", name, strings.Join(Names(), ", "))
+}
+
+// Detect returns the provider of a URL
+func Detect(url string) (Provider, error) {
+	for _, provider := range registry {
+		if provider.Match(url) {
+			return provider, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported video URL '%s' (expected a YouTube, Vimeo or MP4 URL)", url)
+}
+
+// Resolve returns the provider and video ID for a URL. The provider is
+// detected from the URL unless a name is given.
+func Resolve(name, url string) (Provider, string, error) {
+	url = strings.TrimSpace(url)
+
+	var provider Provider
+	var err error
+	if name != "" {
+		provider, err = Get(name)
+	} else {
+		provider, err = Detect(url)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	id, err := provider.ExtractID(url)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return provider, id, nil
+}
@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var vimeoHostPattern = regexp.MustCompile(`(?i)(^|[/.])vimeo\.com/`)
+
+var vimeoPattern = regexp.MustCompile(`vimeo\.com/(?:.*/)?(\d+)`)
+
+// Vimeo handles vimeo.com and player.vimeo.com videos
+type Vimeo struct{}
+
+func (Vimeo) Name() string {
+	return "vimeo"
+}
+
+func (Vimeo) Match(url string) bool {
+	return vimeoHostPattern.MatchString(url)
+}
+
+func (Vimeo) ExtractID(url string) (string, error) {
+	if matches := vimeoPattern.FindStringSubmatch(url); len(matches) > 1 {
+		return matches[1], nil
+	}
+	return "", fmt.Errorf("invalid Vimeo URL format")
+}
+
+func (Vimeo) CanonicalURL(id string) string {
+	return "https://vimeo.com/" + id
+}
+
+func (Vimeo) EmbedURL(id string) string {
+	return "https://player.vimeo.com/video/" + id
+}
+
+// ThumbnailURL returns "" as Vimeo thumbnails are only available through
+// its API
+func (Vimeo) ThumbnailURL(id string) string {
+	return ""
+}
+
+func (p Vimeo) DeepLink(id string, offset time.Duration) string {
+	return fmt.Sprintf("%s#t=%ds", p.CanonicalURL(id), int(offset.Seconds()))
+}
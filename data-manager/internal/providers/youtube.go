@@ -0,0 +1,52 @@
+package providers
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var youtubeHostPattern = regexp.MustCompile(`(?i)(^|[/.])(youtube\.com|youtu\.be)/`)
+
+var youtubePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`youtube\.com/watch\?(?:.*&)?v=([a-zA-Z0-9_-]+)`),
+	regexp.MustCompile(`youtu\.be/([a-zA-Z0-9_-]+)`),
+	regexp.MustCompile(`youtube\.com/embed/([a-zA-Z0-9_-]+)`),
+	regexp.MustCompile(`youtube\.com/shorts/([a-zA-Z0-9_-]+)`),
+}
+
+// YouTube handles youtube.com and youtu.be videos
+type YouTube struct{}
+
+func (YouTube) Name() string {
+	return "youtube"
+}
+
+func (YouTube) Match(url string) bool {
+	return youtubeHostPattern.MatchString(url)
+}
+
+func (YouTube) ExtractID(url string) (string, error) {
+	for _, pattern := range youtubePatterns {
+		if matches := pattern.FindStringSubmatch(url); len(matches) > 1 {
+			return matches[1], nil
+		}
+	}
+	return "", fmt.Errorf("invalid YouTube URL format")
+}
+
+func (YouTube) CanonicalURL(id string) string {
+	return "https://www.youtube.com/watch?v=" + id
+}
+
+func (YouTube) EmbedURL(id string) string {
+	return "https://www.youtube.com/embed/" + id
+}
+
+func (YouTube) ThumbnailURL(id string) string {
+	return fmt.Sprintf("https://img.youtube.com/vi/%s/maxresdefault.jpg", id)
+}
+
+func (p YouTube) DeepLink(id string, offset time.Duration) string {
+	return fmt.Sprintf("%s&t=%ds", p.CanonicalURL(id), int(offset.Seconds()))
+}
@@ -0,0 +1,320 @@
+// Package recommend ranks exercise videos for a symptom assessment.
+//
+// Scoring is a pure function of the assessment, the catalog and a set of
+// rules, so the same inputs always produce the same shortlist.
+package recommend
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"fisio-data-manager/internal/models"
+)
+
+// Difficulty levels from easiest to hardest
+var difficultyLevels = []string{"beginner", "intermediate", "advanced"}
+
+// Rules configures how videos are scored
+type Rules struct {
+	// Points per video body part matching a pain location
+	BodyPartWeight float64 `json:"body_part_weight"`
+	// Points per video tag matching a word of the primary symptom
+	PrimarySymptomWeight float64 `json:"primary_symptom_weight"`
+	// Points per video tag matching a word of a secondary symptom
+	SecondarySymptomWeight float64 `json:"secondary_symptom_weight"`
+	// Points for each level a video sits below the difficulty cap
+	GentlenessWeight float64 `json:"gentleness_weight"`
+	// Videos scoring below this are left out
+	MinScore float64 `json:"min_score"`
+
+	// Difficulty caps by minimum pain level; the highest matching entry applies
+	PainCaps []PainCap `json:"pain_caps"`
+	// Difficulty caps by daily impact (minimal, moderate, significant, severe)
+	ImpactCaps map[string]string `json:"impact_caps"`
+	// Maps pain locations from the assessment form to video body parts
+	BodyPartAliases map[string][]string `json:"body_part_aliases"`
+	// Words of the symptom descriptions that never match tags
+	StopWords []string `json:"stop_words"`
+}
+
+// PainCap caps the difficulty for pain levels at or above MinPain
+type PainCap struct {
+	MinPain       int    `json:"min_pain"`
+	MaxDifficulty string `json:"max_difficulty"`
+}
+
+// Recommendation is a ranked video with the reasons it was picked
+type Recommendation struct {
+	Video   models.ExerciseVideo `json:"video"`
+	Score   float64              `json:"score"`
+	Reasons []string             `json:"reasons"`
+	// Precautions lists the patient's conditions calling for care with the
+	// video
+	Precautions []string `json:"precautions,omitempty"`
+}
+
+// DefaultRules returns the rules used when none are configured
+func DefaultRules() Rules {
+	return Rules{
+		BodyPartWeight:         3,
+		PrimarySymptomWeight:   2,
+		SecondarySymptomWeight: 1,
+		GentlenessWeight:       0.5,
+		MinScore:               1,
+		PainCaps: []PainCap{
+			{MinPain: 7, MaxDifficulty: "beginner"},
+			{MinPain: 4, MaxDifficulty: "intermediate"},
+		},
+		ImpactCaps: map[string]string{
+			"severe":      "beginner",
+			"significant": "intermediate",
+		},
+		BodyPartAliases: map[string][]string{
+			"head/neck":    {"neck"},
+			"upper back":   {"back"},
+			"lower back":   {"back", "core"},
+			"elbows":       {"arms"},
+			"wrists/hands": {"wrists"},
+			"chest":        {"shoulders"},
+			"abdomen":      {"core"},
+			"hips":         {"hips", "glutes"},
+			"thighs":       {"legs"},
+			"calves":       {"legs"},
+			"ankles/feet":  {"ankles"},
+		},
+		StopWords: []string{"and", "the", "with", "when", "pain", "in", "of", "my", "a", "to", "on", "at"},
+	}
+}
+
+// LoadRules reads rules from a JSON file. Fields missing from the file keep
+// their default values.
+func LoadRules(path string) (Rules, error) {
+	rules := DefaultRules()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return rules, err
+	}
+
+	return rules, nil
+}
+
+// Validate checks that the rules reference known difficulty levels
+func (r *Rules) Validate() error {
+	for _, painCap := range r.PainCaps {
+		if difficultyRank(painCap.MaxDifficulty) < 0 {
+			return fmt.Errorf("invalid difficulty '%s' in pain caps", painCap.MaxDifficulty)
+		}
+	}
+	for impact, difficulty := range r.ImpactCaps {
+		if difficultyRank(difficulty) < 0 {
+			return fmt.Errorf("invalid difficulty '%s' for daily impact '%s'", difficulty, impact)
+		}
+	}
+	return nil
+}
+
+// MaxDifficulty returns the hardest difficulty allowed for the assessment
+// and why it was capped (empty when not capped)
+func (r *Rules) MaxDifficulty(assessment *models.SymptomAssessment) (string, string) {
+	maxRank := len(difficultyLevels) - 1
+	reason := ""
+
+	bestPain := -1
+	for _, painCap := range r.PainCaps {
+		if assessment.PainLevel >= painCap.MinPain && painCap.MinPain > bestPain {
+			bestPain = painCap.MinPain
+			if rank := difficultyRank(painCap.MaxDifficulty); rank >= 0 {
+				maxRank = rank
+				reason = fmt.Sprintf("pain %d/10", assessment.PainLevel)
+			}
+		}
+	}
+
+	impact := strings.ToLower(assessment.Impact())
+	if difficulty, ok := r.ImpactCaps[impact]; ok {
+		if rank := difficultyRank(difficulty); rank >= 0 && rank < maxRank {
+			maxRank = rank
+			reason = fmt.Sprintf("%s daily impact", impact)
+		}
+	}
+
+	return difficultyLevels[maxRank], reason
+}
+
+// Rank scores the videos for the assessment and returns the best matches,
+// highest score first. A limit of 0 returns every match.
+func Rank(assessment *models.SymptomAssessment, videos []models.ExerciseVideo, rules Rules, limit int) []Recommendation {
+	maxDifficulty, capReason := rules.MaxDifficulty(assessment)
+	maxRank := difficultyRank(maxDifficulty)
+
+	bodyParts := rules.targetBodyParts(assessment.Locations())
+	primaryWords := rules.words(assessment.Symptom())
+	var secondaryWords map[string]bool
+	if len(assessment.Symptoms.SecondarySymptoms) > 0 {
+		secondaryWords = rules.words(strings.Join(assessment.Symptoms.SecondarySymptoms, " "))
+	}
+
+	recommendations := []Recommendation{}
+	for _, video := range videos {
+		rank := difficultyRank(video.DifficultyLevel)
+		if rank > maxRank {
+			continue
+		}
+
+		var score float64
+		var reasons []string
+
+		for _, part := range video.BodyParts {
+			if location, ok := bodyParts[strings.ToLower(part)]; ok {
+				score += rules.BodyPartWeight
+				reasons = append(reasons, fmt.Sprintf("targets %s (pain location: %s)", part, location))
+			}
+		}
+
+		for _, tag := range video.Tags {
+			if matchesWords(tag, primaryWords) {
+				score += rules.PrimarySymptomWeight
+				reasons = append(reasons, fmt.Sprintf("tag '%s' matches primary symptom", tag))
+			} else if matchesWords(tag, secondaryWords) {
+				score += rules.SecondarySymptomWeight
+				reasons = append(reasons, fmt.Sprintf("tag '%s' matches secondary symptom", tag))
+			}
+		}
+
+		// Only relevance earns a place; gentleness just breaks ties
+		if score == 0 {
+			continue
+		}
+
+		if rank >= 0 && rank < maxRank {
+			score += rules.GentlenessWeight * float64(maxRank-rank)
+		}
+		if capReason != "" {
+			reasons = append(reasons, fmt.Sprintf("%s is within the %s cap (%s)", levelName(video.DifficultyLevel), maxDifficulty, capReason))
+		}
+
+		if score < rules.MinScore {
+			continue
+		}
+
+		recommendations = append(recommendations, Recommendation{
+			Video:   video,
+			Score:   score,
+			Reasons: reasons,
+		})
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if ra, rb := difficultyRank(a.Video.DifficultyLevel), difficultyRank(b.Video.DifficultyLevel); ra != rb {
+			return ra < rb
+		}
+		if a.Video.Title != b.Video.Title {
+			return a.Video.Title < b.Video.Title
+		}
+		return a.Video.ID < b.Video.ID
+	})
+
+	if limit > 0 && len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return recommendations
+}
+
+// targetBodyParts maps lowercased video body parts to the pain location they
+// came from
+func (r *Rules) targetBodyParts(locations []string) map[string]string {
+	aliases := make(map[string][]string, len(r.BodyPartAliases))
+	for location, parts := range r.BodyPartAliases {
+		aliases[strings.ToLower(location)] = parts
+	}
+
+	targets := make(map[string]string)
+	for _, location := range locations {
+		key := strings.ToLower(strings.TrimSpace(location))
+		if key == "" {
+			continue
+		}
+		parts, ok := aliases[key]
+		if !ok {
+			parts = []string{key}
+		}
+		for _, part := range parts {
+			part = strings.ToLower(part)
+			if _, exists := targets[part]; !exists {
+				targets[part] = location
+			}
+		}
+	}
+
+	return targets
+}
+
+// words splits a free-text description into lowercased words, without stop
+// words
+func (r *Rules) words(text string) map[string]bool {
+	stop := make(map[string]bool, len(r.StopWords))
+	for _, word := range r.StopWords {
+		stop[strings.ToLower(word)] = true
+	}
+
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), isSeparator) {
+		if len(word) > 2 && !stop[word] {
+			words[word] = true
+		}
+	}
+
+	return words
+}
+
+// matchesWords reports whether every word of a tag appears in the words
+func matchesWords(tag string, words map[string]bool) bool {
+	if len(words) == 0 {
+		return false
+	}
+	tagWords := strings.FieldsFunc(strings.ToLower(tag), isSeparator)
+	if len(tagWords) == 0 {
+		return false
+	}
+	for _, word := range tagWords {
+		if !words[word] {
+			return false
+		}
+	}
+	return true
+}
+
+func isSeparator(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+}
+
+func difficultyRank(level string) int {
+	for i, l := range difficultyLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+func levelName(level string) string {
+	if level == "" {
+		return "unrated difficulty"
+	}
+	return strings.ToUpper(level[:1]) + level[1:]
+}
@@ -0,0 +1,284 @@
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"fisio-data-manager/internal/models"
+)
+
+func TestMaxDifficulty(t *testing.T) {
+	rules := DefaultRules()
+
+	tests := []struct {
+		name       string
+		assessment models.SymptomAssessment
+		want       string
+		wantReason string
+	}{
+		{
+			name:       "low pain is not capped",
+			assessment: models.SymptomAssessment{PainLevel: 2},
+			want:       "advanced",
+		},
+		{
+			name:       "moderate pain caps at intermediate",
+			assessment: models.SymptomAssessment{PainLevel: 4},
+			want:       "intermediate",
+			wantReason: "pain 4/10",
+		},
+		{
+			name:       "high pain caps at beginner",
+			assessment: models.SymptomAssessment{PainLevel: 8},
+			want:       "beginner",
+			wantReason: "pain 8/10",
+		},
+		{
+			name:       "severe impact caps at beginner",
+			assessment: models.SymptomAssessment{PainLevel: 2, DailyImpact: "Severe"},
+			want:       "beginner",
+			wantReason: "severe daily impact",
+		},
+		{
+			name:       "impact from the symptoms document",
+			assessment: models.SymptomAssessment{PainLevel: 2, Symptoms: models.SymptomDetails{DailyImpact: "significant"}},
+			want:       "intermediate",
+			wantReason: "significant daily impact",
+		},
+		{
+			name:       "impact does not loosen a stricter pain cap",
+			assessment: models.SymptomAssessment{PainLevel: 9, DailyImpact: "significant"},
+			want:       "beginner",
+			wantReason: "pain 9/10",
+		},
+		{
+			name:       "unknown impact is ignored",
+			assessment: models.SymptomAssessment{PainLevel: 1, DailyImpact: "minimal"},
+			want:       "advanced",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := rules.MaxDifficulty(&tt.assessment)
+			if got != tt.want || reason != tt.wantReason {
+				t.Errorf("MaxDifficulty() = %q, %q; want %q, %q", got, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	videos := []models.ExerciseVideo{
+		{ID: "1", Title: "Neck rolls", DifficultyLevel: "beginner", BodyParts: []string{"neck"}, Tags: []string{"stiffness"}},
+		{ID: "2", Title: "Neck strength", DifficultyLevel: "advanced", BodyParts: []string{"Neck"}},
+		{ID: "3", Title: "Back bridge", DifficultyLevel: "intermediate", BodyParts: []string{"back"}, Tags: []string{"stiffness"}},
+		{ID: "4", Title: "Ankle circles", DifficultyLevel: "beginner", BodyParts: []string{"ankles"}},
+		{ID: "5", Title: "Headache relief", DifficultyLevel: "beginner", Tags: []string{"headache"}},
+		{ID: "6", Title: "A neck stretch", DifficultyLevel: "beginner", BodyParts: []string{"neck"}},
+	}
+
+	tests := []struct {
+		name       string
+		assessment models.SymptomAssessment
+		rules      func(*Rules)
+		limit      int
+		want       []string
+	}{
+		{
+			name: "body parts and symptoms, gentler first on ties",
+			assessment: models.SymptomAssessment{
+				PainLevel:      2,
+				PainLocations:  []string{"Head/Neck"},
+				PrimarySymptom: "Neck stiffness in the morning",
+				Symptoms:       models.SymptomDetails{SecondarySymptoms: []string{"headache"}},
+			},
+			want: []string{"1", "6", "2", "3", "5"},
+		},
+		{
+			name: "pain cap leaves out harder videos",
+			assessment: models.SymptomAssessment{
+				PainLevel:     8,
+				PainLocations: []string{"head/neck"},
+			},
+			want: []string{"6", "1"},
+		},
+		{
+			name: "locations from the symptoms document",
+			assessment: models.SymptomAssessment{
+				PainLevel: 2,
+				Symptoms:  models.SymptomDetails{PainLocation: []string{"Ankles/Feet"}},
+			},
+			want: []string{"4"},
+		},
+		{
+			name: "min score drops weak matches",
+			assessment: models.SymptomAssessment{
+				PainLevel:      2,
+				PainLocations:  []string{"head/neck"},
+				PrimarySymptom: "stiffness",
+			},
+			rules: func(r *Rules) { r.MinScore = 5 },
+			want:  []string{"1"},
+		},
+		{
+			name: "limit",
+			assessment: models.SymptomAssessment{
+				PainLevel:     2,
+				PainLocations: []string{"head/neck"},
+			},
+			limit: 2,
+			want:  []string{"6", "1"},
+		},
+		{
+			name:       "no match",
+			assessment: models.SymptomAssessment{PainLevel: 2, PainLocations: []string{"elbows"}},
+			want:       []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules()
+			if tt.rules != nil {
+				tt.rules(&rules)
+			}
+			got := []string{}
+			for _, rec := range Rank(&tt.assessment, videos, rules, tt.limit) {
+				got = append(got, rec.Video.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankReasons(t *testing.T) {
+	assessment := models.SymptomAssessment{
+		PainLevel:      5,
+		PainLocations:  []string{"Lower Back"},
+		PrimarySymptom: "stiffness",
+		Symptoms:       models.SymptomDetails{SecondarySymptoms: []string{"sciatica"}},
+	}
+	videos := []models.ExerciseVideo{
+		{ID: "1", Title: "Cat cow", DifficultyLevel: "beginner", BodyParts: []string{"back"}, Tags: []string{"stiffness", "sciatica"}},
+	}
+
+	recs := Rank(&assessment, videos, DefaultRules(), 0)
+	if len(recs) != 1 {
+		t.Fatalf("Rank() returned %d recommendations, want 1", len(recs))
+	}
+
+	// 3 (body part) + 2 (primary) + 1 (secondary) + 0.5 (one level below the cap)
+	if recs[0].Score != 6.5 {
+		t.Errorf("Score = %v, want 6.5", recs[0].Score)
+	}
+	want := []string{
+		"targets back (pain location: Lower Back)",
+		"tag 'stiffness' matches primary symptom",
+		"tag 'sciatica' matches secondary symptom",
+		"Beginner is within the intermediate cap (pain 5/10)",
+	}
+	if !reflect.DeepEqual(recs[0].Reasons, want) {
+		t.Errorf("Reasons = %q, want %q", recs[0].Reasons, want)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		check   func(t *testing.T, r Rules)
+		wantErr string
+	}{
+		{
+			name: "empty file keeps the defaults",
+			file: `{}`,
+			check: func(t *testing.T, r Rules) {
+				if !reflect.DeepEqual(r, DefaultRules()) {
+					t.Errorf("LoadRules() = %+v, want the defaults", r)
+				}
+			},
+		},
+		{
+			name: "given fields replace the defaults, others are kept",
+			file: `{"body_part_weight": 5, "pain_caps": [{"min_pain": 9, "max_difficulty": "beginner"}]}`,
+			check: func(t *testing.T, r Rules) {
+				defaults := DefaultRules()
+				if r.BodyPartWeight != 5 {
+					t.Errorf("BodyPartWeight = %v, want 5", r.BodyPartWeight)
+				}
+				if want := []PainCap{{MinPain: 9, MaxDifficulty: "beginner"}}; !reflect.DeepEqual(r.PainCaps, want) {
+					t.Errorf("PainCaps = %v, want %v", r.PainCaps, want)
+				}
+				if r.PrimarySymptomWeight != defaults.PrimarySymptomWeight || r.MinScore != defaults.MinScore {
+					t.Errorf("weights not given in the file changed: %+v", r)
+				}
+				if !reflect.DeepEqual(r.BodyPartAliases, defaults.BodyPartAliases) {
+					t.Errorf("BodyPartAliases changed without being in the file")
+				}
+			},
+		},
+		{
+			name: "maps are merged with the defaults",
+			file: `{"impact_caps": {"moderate": "intermediate"}}`,
+			check: func(t *testing.T, r Rules) {
+				want := map[string]string{"severe": "beginner", "significant": "intermediate", "moderate": "intermediate"}
+				if !reflect.DeepEqual(r.ImpactCaps, want) {
+					t.Errorf("ImpactCaps = %v, want %v", r.ImpactCaps, want)
+				}
+			},
+		},
+		{
+			name:    "invalid pain cap difficulty",
+			file:    `{"pain_caps": [{"min_pain": 5, "max_difficulty": "expert"}]}`,
+			wantErr: "invalid difficulty 'expert' in pain caps",
+		},
+		{
+			name:    "invalid impact cap difficulty",
+			file:    `{"impact_caps": {"severe": "easy"}}`,
+			wantErr: "invalid difficulty 'easy' for daily impact 'severe'",
+		},
+		{
+			name:    "malformed JSON",
+			file:    `{"min_score": }`,
+			wantErr: "failed to parse rules file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tt.file), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			rules, err := LoadRules(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadRules() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadRules() error = %v", err)
+			}
+			tt.check(t, rules)
+		})
+	}
+
+	if _, err := LoadRules(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadRules() of a missing file returned no error")
+	}
+}
+
+func TestValidateDefaults(t *testing.T) {
+	rules := DefaultRules()
+	if err := rules.Validate(); err != nil {
+		t.Errorf("DefaultRules().Validate() = %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+// Package seo builds the files search engines use to find the videos of the
+// public exercise library: a Google video sitemap and schema.org VideoObject
+// structured data (JSON-LD).
+package seo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/providers"
+)
+
+// LibraryPath is the path of the public exercise library page, which embeds
+// every published video
+const LibraryPath = "/exercises"
+
+// Limits of the Google video sitemap format
+const (
+	maxDescription = 2048
+	maxTags        = 32
+	maxDuration    = 8 * 60 * 60
+)
+
+// PageURL returns the URL of the library page on the site at baseURL
+// (e.g. "https://example.com")
+func PageURL(baseURL string) (string, error) {
+	baseURL = strings.TrimSpace(baseURL)
+	parsed, err := neturl.Parse(baseURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", fmt.Errorf("invalid base URL '%s': must be an http(s) URL such as https://example.com", baseURL)
+	}
+	return strings.TrimRight(baseURL, "/") + LibraryPath, nil
+}
+
+// Eligible reports why a video cannot be listed for search engines, or nil
+// when it can. Google requires a thumbnail for every video.
+func Eligible(video models.ExerciseVideo) error {
+	if video.ThumbnailURL == nil || strings.TrimSpace(*video.ThumbnailURL) == "" {
+		return fmt.Errorf("no thumbnail (set one with videos update --thumbnail)")
+	}
+	return nil
+}
+
+type urlSet struct {
+	XMLName    xml.Name     `xml:"urlset"`
+	Xmlns      string       `xml:"xmlns,attr"`
+	XmlnsVideo string       `xml:"xmlns:video,attr"`
+	URLs       []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc    string         `xml:"loc"`
+	Videos []sitemapVideo `xml:"video:video"`
+}
+
+type sitemapVideo struct {
+	ThumbnailLoc    string   `xml:"video:thumbnail_loc"`
+	Title           string   `xml:"video:title"`
+	Description     string   `xml:"video:description"`
+	ContentLoc      string   `xml:"video:content_loc,omitempty"`
+	PlayerLoc       string   `xml:"video:player_loc,omitempty"`
+	Duration        int      `xml:"video:duration,omitempty"`
+	ExpirationDate  string   `xml:"video:expiration_date,omitempty"`
+	PublicationDate string   `xml:"video:publication_date"`
+	FamilyFriendly  string   `xml:"video:family_friendly"`
+	Tags            []string `xml:"video:tag"`
+}
+
+// WriteSitemap writes a Google video sitemap listing the videos on the
+// library page. Videos that are not Eligible must be left out by the caller.
+func WriteSitemap(w io.Writer, pageURL string, videos []models.ExerciseVideo) error {
+	page := sitemapURL{Loc: pageURL}
+	for _, video := range videos {
+		entry := sitemapVideo{
+			ThumbnailLoc:    strings.TrimSpace(*video.ThumbnailURL),
+			Title:           video.Title,
+			Description:     truncate(description(video), maxDescription),
+			PublicationDate: uploadDate(video).Format(time.RFC3339),
+			FamilyFriendly:  "yes",
+			Tags:            keywords(video),
+		}
+		if len(entry.Tags) > maxTags {
+			entry.Tags = entry.Tags[:maxTags]
+		}
+		entry.ContentLoc, entry.PlayerLoc = locations(video)
+		if seconds := durationSeconds(video); seconds > 0 && seconds <= maxDuration {
+			entry.Duration = seconds
+		}
+		if video.ExpireAt != nil {
+			entry.ExpirationDate = video.ExpireAt.Format(time.RFC3339)
+		}
+		page.Videos = append(page.Videos, entry)
+	}
+
+	set := urlSet{
+		Xmlns:      "http://www.sitemaps.org/schemas/sitemap/0.9",
+		XmlnsVideo: "http://www.google.com/schemas/sitemap-video/1.1",
+		URLs:       []sitemapURL{page},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(set); err != nil {
+		return fmt.Errorf("failed to write sitemap: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// VideoObject is the schema.org structured data describing a video
+type VideoObject struct {
+	Context      string   `json:"@context"`
+	Type         string   `json:"@type"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	ThumbnailURL string   `json:"thumbnailUrl"`
+	UploadDate   string   `json:"uploadDate"`
+	Duration     string   `json:"duration,omitempty"` // ISO 8601, e.g. PT10M
+	ContentURL   string   `json:"contentUrl,omitempty"`
+	EmbedURL     string   `json:"embedUrl,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Genre        []string `json:"genre,omitempty"` // the video's categories
+	Keywords     string   `json:"keywords,omitempty"`
+	Expires      string   `json:"expires,omitempty"`
+}
+
+// NewVideoObject returns the structured data of an Eligible video. pageURL
+// may be empty.
+func NewVideoObject(video models.ExerciseVideo, pageURL string) VideoObject {
+	object := VideoObject{
+		Context:      "https://schema.org",
+		Type:         "VideoObject",
+		Name:         video.Title,
+		Description:  description(video),
+		ThumbnailURL: strings.TrimSpace(*video.ThumbnailURL),
+		UploadDate:   uploadDate(video).Format(time.RFC3339),
+		URL:          pageURL,
+		Genre:        categories(video),
+		Keywords:     strings.Join(video.Tags, ", "),
+	}
+	object.ContentURL, object.EmbedURL = locations(video)
+	if seconds := durationSeconds(video); seconds > 0 {
+		object.Duration = isoDuration(seconds)
+	}
+	if video.ExpireAt != nil {
+		object.Expires = video.ExpireAt.Format(time.RFC3339)
+	}
+	return object
+}
+
+// locations returns the URL of the video file for self-hosted videos, or the
+// URL of the embedded player for the others
+func locations(video models.ExerciseVideo) (content, player string) {
+	if video.Provider == (providers.MP4{}).Name() {
+		return video.VideoURL, ""
+	}
+	return "", video.EmbedURL
+}
+
+// description falls back to the title, as both formats require a description
+func description(video models.ExerciseVideo) string {
+	if text := strings.TrimSpace(video.Description); text != "" {
+		return text
+	}
+	return video.Title
+}
+
+// uploadDate is when the video was published, or added for videos published
+// before the review workflow
+func uploadDate(video models.ExerciseVideo) time.Time {
+	if video.PublishedAt != nil {
+		return video.PublishedAt.UTC()
+	}
+	return video.CreatedAt.UTC()
+}
+
+// categories returns the names of the video's categories, primary first
+func categories(video models.ExerciseVideo) []string {
+	names := []string{}
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if video.CategoryName != nil {
+		add(*video.CategoryName)
+	}
+	for _, name := range video.CategoryNames {
+		add(name)
+	}
+	return names
+}
+
+// keywords returns the video's tags followed by its categories
+func keywords(video models.ExerciseVideo) []string {
+	words := []string{}
+	seen := make(map[string]bool)
+	for _, word := range append(append([]string{}, video.Tags...), categories(video)...) {
+		key := strings.ToLower(strings.TrimSpace(word))
+		if key != "" && !seen[key] {
+			seen[key] = true
+			words = append(words, strings.TrimSpace(word))
+		}
+	}
+	return words
+}
+
+// durationSeconds converts the video's duration, kept in minutes, to seconds
+func durationSeconds(video models.ExerciseVideo) int {
+	if video.Duration == nil {
+		return 0
+	}
+	return *video.Duration * 60
+}
+
+// isoDuration formats seconds as an ISO 8601 duration
+func isoDuration(seconds int) string {
+	hours, minutes, secs := seconds/3600, seconds%3600/60, seconds%60
+	var b strings.Builder
+	b.WriteString("PT")
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if secs > 0 || (hours == 0 && minutes == 0) {
+		fmt.Fprintf(&b, "%dS", secs)
+	}
+	return b.String()
+}
+
+// truncate shortens text to at most max characters, on a word boundary
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	cut := string(runes[:max-1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"github.com/lib/pq"
+)
+
+type AppointmentService struct {
+	db *database.DB
+}
+
+func NewAppointmentService(db *database.DB) *AppointmentService {
+	return &AppointmentService{db: db}
+}
+
+const appointmentColumns = `
+		a.id, a.patient_name, a.patient_email, COALESCE(a.patient_phone, ''),
+		a.appointment_date, to_char(a.appointment_time, 'HH24:MI'), COALESCE(a.duration, 60),
+		COALESCE(a.status, 'scheduled'), COALESCE(a.google_event_id, ''), COALESCE(a.notes, ''),
+		COALESCE(a.cancellation_reason, ''), a.cancelled_at, a.created_at, a.updated_at`
+
+// appointmentSorts maps the sort orders to ORDER BY clauses; the date and
+// time always break ties
+var appointmentSorts = map[string]string{
+	"date":    "a.appointment_date %[1]s, a.appointment_time %[1]s",
+	"patient": "LOWER(a.patient_name) %[1]s, a.appointment_date, a.appointment_time",
+	"status":  "a.status %[1]s, a.appointment_date, a.appointment_time",
+	"created": "a.created_at %[1]s, a.appointment_date, a.appointment_time",
+}
+
+func scanAppointment(row rowScanner) (*models.Appointment, error) {
+	var a models.Appointment
+	err := row.Scan(
+		&a.ID,
+		&a.PatientName,
+		&a.PatientEmail,
+		&a.PatientPhone,
+		&a.AppointmentDate,
+		&a.AppointmentTime,
+		&a.Duration,
+		&a.Status,
+		&a.GoogleEventID,
+		&a.Notes,
+		&a.CancellationReason,
+		&a.CancelledAt,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// GetAppointment retrieves an appointment by ID or by its Google Calendar
+// event ID
+func (s *AppointmentService) GetAppointment(idOrEventID string) (*models.Appointment, error) {
+	query := `SELECT ` + appointmentColumns + ` FROM appointments a WHERE a.id::text = $1 OR a.google_event_id = $1`
+
+	appointment, err := scanAppointment(s.db.QueryRow(query, strings.TrimSpace(idOrEventID)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("appointment not found")
+		}
+		return nil, fmt.Errorf("failed to get appointment: %w", err)
+	}
+
+	return appointment, nil
+}
+
+// GetAppointments retrieves appointments with optional filters
+func (s *AppointmentService) GetAppointments(filter models.AppointmentFilter) ([]models.Appointment, error) {
+	query := `SELECT ` + appointmentColumns + ` FROM appointments a WHERE 1=1`
+
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.From != nil {
+		query += fmt.Sprintf(" AND a.appointment_date >= $%d", argIndex)
+		args = append(args, filter.From.Format("2006-01-02"))
+		argIndex++
+	}
+
+	if filter.To != nil {
+		query += fmt.Sprintf(" AND a.appointment_date <= $%d", argIndex)
+		args = append(args, filter.To.Format("2006-01-02"))
+		argIndex++
+	}
+
+	if len(filter.Statuses) > 0 {
+		query += fmt.Sprintf(" AND a.status = ANY($%d)", argIndex)
+		args = append(args, pq.Array(filter.Statuses))
+		argIndex++
+	}
+
+	if email := strings.TrimSpace(filter.PatientEmail); email != "" {
+		query += fmt.Sprintf(" AND LOWER(a.patient_email) = LOWER($%d)", argIndex)
+		args = append(args, email)
+		argIndex++
+	}
+
+	if filter.TimeFrom != "" {
+		query += fmt.Sprintf(" AND a.appointment_time >= $%d::time", argIndex)
+		args = append(args, filter.TimeFrom)
+		argIndex++
+	}
+
+	if filter.TimeTo != "" {
+		query += fmt.Sprintf(" AND a.appointment_time < $%d::time", argIndex)
+		args = append(args, filter.TimeTo)
+		argIndex++
+	}
+
+	sort := filter.Sort
+	if sort == "" {
+		sort = "date"
+	}
+	if err := models.ValidAppointmentSort(sort); err != nil {
+		return nil, err
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+	query += " ORDER BY " + fmt.Sprintf(appointmentSorts[sort], direction) + ", a.id"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query appointments: %w", err)
+	}
+	defer rows.Close()
+
+	appointments := []models.Appointment{}
+	for rows.Next() {
+		appointment, err := scanAppointment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan appointment: %w", err)
+		}
+		appointments = append(appointments, *appointment)
+	}
+
+	return appointments, nil
+}
+
+// GetAppointmentsForEvents retrieves the appointments linked to any of the
+// Google Calendar events, or with any of the IDs
+func (s *AppointmentService) GetAppointmentsForEvents(eventIDs, ids []string) ([]models.Appointment, error) {
+	query := `SELECT ` + appointmentColumns + ` FROM appointments a
+		WHERE a.google_event_id = ANY($1) OR a.id::text = ANY($2)
+		ORDER BY a.appointment_date, a.appointment_time, a.id`
+
+	rows, err := s.db.Query(query, pq.Array(eventIDs), pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query appointments: %w", err)
+	}
+	defer rows.Close()
+
+	appointments := []models.Appointment{}
+	for rows.Next() {
+		appointment, err := scanAppointment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan appointment: %w", err)
+		}
+		appointments = append(appointments, *appointment)
+	}
+
+	return appointments, nil
+}
+
+// LinkCalendarEvent saves the Google Calendar event ID of an appointment
+func (s *AppointmentService) LinkCalendarEvent(id, eventID string) error {
+	result, err := s.db.Exec(`
+		UPDATE appointments SET google_event_id = $2, updated_at = NOW()
+		WHERE id = $1 AND google_event_id IS NULL
+	`, id, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to link calendar event: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("appointment not found or already linked to an event")
+	}
+
+	return nil
+}
+
+// Reschedule moves a scheduled or confirmed appointment to a new date, time
+// (HH:MM) and duration (in minutes), and logs the move with the reason in the
+// status history. The appointment is locked while it moves.
+func (s *AppointmentService) Reschedule(id string, date time.Time, clock string, duration int, actor, reason string) error {
+	if duration <= 0 || duration > 480 {
+		return fmt.Errorf("duration must be between 1 and 480 minutes")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	var current models.Appointment
+	err = tx.QueryRow(`
+		SELECT COALESCE(status, 'scheduled'), appointment_date, to_char(appointment_time, 'HH24:MI'), COALESCE(duration, 60)
+		FROM appointments
+		WHERE id = $1
+		FOR UPDATE
+	`, id).Scan(&status, &current.AppointmentDate, &current.AppointmentTime, &current.Duration)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("appointment not found")
+		}
+		return fmt.Errorf("failed to get appointment: %w", err)
+	}
+	if status != models.AppointmentScheduled && status != models.AppointmentConfirmed {
+		return fmt.Errorf("cannot reschedule an appointment that is %s (must be %s or %s)",
+			status, models.AppointmentScheduled, models.AppointmentConfirmed)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE appointments SET appointment_date = $2, appointment_time = $3::time, duration = $4, updated_at = NOW()
+		WHERE id = $1
+	`, id, date.Format("2006-01-02"), clock, duration)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule appointment: %w", err)
+	}
+
+	moved := fmt.Sprintf("moved from %s %s (%d min) to %s %s (%d min)",
+		current.AppointmentDate.Format("2006-01-02"), current.AppointmentTime, current.Duration,
+		date.Format("2006-01-02"), clock, duration)
+	if reason = strings.TrimSpace(reason); reason != "" {
+		moved = reason + ": " + moved
+	}
+	_, err = tx.Exec(`
+		INSERT INTO appointment_status_history (appointment_id, action, from_status, to_status, actor, reason)
+		VALUES ($1, $2, $3, $3, $4, $5)
+	`, id, models.AppointmentReschedule, status, nullIfEmpty(strings.TrimSpace(actor)), moved)
+	if err != nil {
+		return fmt.Errorf("failed to log reschedule: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reschedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetAgenda retrieves the appointments between two dates (inclusive), with
+// the pain level and symptom of their latest linked assessment, in order
+func (s *AppointmentService) GetAgenda(from, to time.Time, includeCancelled bool) ([]models.AgendaEntry, error) {
+	query := `
+		SELECT ` + appointmentColumns + `, sa.pain_level, COALESCE(sa.primary_symptom, '')
+		FROM appointments a
+		LEFT JOIN LATERAL (
+			SELECT pain_level, primary_symptom FROM symptom_assessments
+			WHERE appointment_id = a.id
+			ORDER BY created_at DESC
+			LIMIT 1
+		) sa ON true
+		WHERE a.appointment_date BETWEEN $1 AND $2
+			AND ($3 OR a.status IS DISTINCT FROM 'cancelled')
+		ORDER BY a.appointment_date, a.appointment_time, a.id
+	`
+
+	rows, err := s.db.Query(query, from.Format("2006-01-02"), to.Format("2006-01-02"), includeCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agenda: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.AgendaEntry{}
+	for rows.Next() {
+		var e models.AgendaEntry
+		a := &e.Appointment
+		err := rows.Scan(
+			&a.ID, &a.PatientName, &a.PatientEmail, &a.PatientPhone,
+			&a.AppointmentDate, &a.AppointmentTime, &a.Duration,
+			&a.Status, &a.GoogleEventID, &a.Notes,
+			&a.CancellationReason, &a.CancelledAt, &a.CreatedAt, &a.UpdatedAt,
+			&e.PainLevel, &e.PrimarySymptom,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan appointment: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// ChangeStatus moves an appointment (by ID or Google Calendar event ID) to a
+// new status and logs the change. The appointment is locked while it changes
+// so concurrent changes cannot both apply. Cancelling records the reason and
+// time along with the status. The appointment's date and time are wall-clock
+// times in loc, the clinic's time zone.
+func (s *AppointmentService) ChangeStatus(idOrEventID string, data models.AppointmentStatusFormData, loc *time.Location) (*models.Appointment, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	transition := models.AppointmentTransitions[data.Action]
+	reason := strings.TrimSpace(data.Reason)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id, status string
+	var current models.Appointment
+	err = tx.QueryRow(`
+		SELECT id, COALESCE(status, 'scheduled'), appointment_date, to_char(appointment_time, 'HH24:MI')
+		FROM appointments
+		WHERE id::text = $1 OR google_event_id = $1
+		FOR UPDATE
+	`, strings.TrimSpace(idOrEventID)).Scan(&id, &status, &current.AppointmentDate, &current.AppointmentTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("appointment not found")
+		}
+		return nil, fmt.Errorf("failed to get appointment: %w", err)
+	}
+
+	if err := transition.Check(data.Action, status, current.Start(loc), time.Now()); err != nil {
+		return nil, err
+	}
+
+	if data.Action == models.AppointmentCancel {
+		_, err = tx.Exec(`
+			UPDATE appointments SET status = $2, cancellation_reason = $3, cancelled_at = NOW(), updated_at = NOW()
+			WHERE id = $1
+		`, id, transition.To, reason)
+	} else {
+		_, err = tx.Exec(`UPDATE appointments SET status = $2, updated_at = NOW() WHERE id = $1`, id, transition.To)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update appointment status: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO appointment_status_history (appointment_id, action, from_status, to_status, actor, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, id, data.Action, status, transition.To, nullIfEmpty(strings.TrimSpace(data.Actor)), nullIfEmpty(reason))
+	if err != nil {
+		return nil, fmt.Errorf("failed to log status change: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit status change: %w", err)
+	}
+
+	return s.GetAppointment(id)
+}
+
+// GetStatusHistory returns the status history of an appointment, oldest first
+func (s *AppointmentService) GetStatusHistory(appointmentID string) ([]models.AppointmentStatusChange, error) {
+	query := `
+		SELECT id, appointment_id, action, from_status, to_status,
+			COALESCE(actor, ''), COALESCE(reason, ''), created_at
+		FROM appointment_status_history
+		WHERE appointment_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := s.db.Query(query, appointmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.AppointmentStatusChange{}
+	for rows.Next() {
+		var c models.AppointmentStatusChange
+		if err := rows.Scan(&c.ID, &c.AppointmentID, &c.Action, &c.FromStatus, &c.ToStatus, &c.Actor, &c.Reason, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		history = append(history, c)
+	}
+
+	return history, nil
+}
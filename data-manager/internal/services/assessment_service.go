@@ -0,0 +1,101 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"github.com/lib/pq"
+)
+
+type AssessmentService struct {
+	db *database.DB
+}
+
+func NewAssessmentService(db *database.DB) *AssessmentService {
+	return &AssessmentService{db: db}
+}
+
+const assessmentColumns = `
+		id, appointment_id, patient_name, patient_email, pain_level, assessment_date,
+		symptoms, COALESCE(primary_symptom, ''), COALESCE(pain_locations, '{}'),
+		COALESCE(symptom_duration, ''), COALESCE(daily_impact, ''),
+		COALESCE(previous_treatments, ''), COALESCE(current_medications, ''),
+		COALESCE(additional_notes, ''), COALESCE(recommendations, ''),
+		created_at, updated_at`
+
+func scanAssessment(row rowScanner) (*models.SymptomAssessment, error) {
+	var a models.SymptomAssessment
+	var symptoms []byte
+	err := row.Scan(
+		&a.ID,
+		&a.AppointmentID,
+		&a.PatientName,
+		&a.PatientEmail,
+		&a.PainLevel,
+		&a.AssessmentDate,
+		&symptoms,
+		&a.PrimarySymptom,
+		pq.Array(&a.PainLocations),
+		&a.SymptomDuration,
+		&a.DailyImpact,
+		&a.PreviousTreatments,
+		&a.CurrentMedications,
+		&a.AdditionalNotes,
+		&a.Recommendations,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	a.RawSymptoms = symptoms
+	if len(symptoms) > 0 {
+		if err := json.Unmarshal(symptoms, &a.Symptoms); err != nil {
+			return nil, fmt.Errorf("failed to parse symptoms of assessment %s: %w", a.ID, err)
+		}
+	}
+
+	return &a, nil
+}
+
+// GetAssessment retrieves a symptom assessment by ID
+func (s *AssessmentService) GetAssessment(id string) (*models.SymptomAssessment, error) {
+	query := `SELECT ` + assessmentColumns + ` FROM symptom_assessments WHERE id = $1`
+
+	assessment, err := scanAssessment(s.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("assessment not found")
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	return assessment, nil
+}
+
+// GetAssessmentsForAppointment retrieves the assessments linked to an
+// appointment, oldest first
+func (s *AssessmentService) GetAssessmentsForAppointment(appointmentID string) ([]models.SymptomAssessment, error) {
+	query := `SELECT ` + assessmentColumns + ` FROM symptom_assessments WHERE appointment_id = $1 ORDER BY created_at, id`
+
+	rows, err := s.db.Query(query, appointmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assessments: %w", err)
+	}
+	defer rows.Close()
+
+	assessments := []models.SymptomAssessment{}
+	for rows.Next() {
+		assessment, err := scanAssessment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan assessment: %w", err)
+		}
+		assessments = append(assessments, *assessment)
+	}
+
+	return assessments, nil
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/captions"
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/providers"
+	"github.com/lib/pq"
+)
+
+type CaptionService struct {
+	db *database.DB
+}
+
+func NewCaptionService(db *database.DB) *CaptionService {
+	return &CaptionService{db: db}
+}
+
+// captionColumns is the column list shared by the caption queries, in the
+// order expected by scanCaption
+const captionColumns = `
+			c.id, c.video_id, c.locale, c.format, c.source_filename, c.cue_count,
+			COALESCE((SELECT MAX(cc.end_ms) FROM video_caption_cues cc WHERE cc.caption_id = c.id), 0),
+			c.created_at, c.updated_at`
+
+func scanCaption(row rowScanner) (*models.VideoCaption, error) {
+	var caption models.VideoCaption
+	var durationMs int64
+	err := row.Scan(
+		&caption.ID, &caption.VideoID, &caption.Locale, &caption.Format,
+		&caption.SourceFilename, &caption.CueCount, &durationMs,
+		&caption.CreatedAt, &caption.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	caption.Duration = models.Offset(time.Duration(durationMs) * time.Millisecond)
+	return &caption, nil
+}
+
+// AddCaptions stores the cues of a caption file for a video, replacing the
+// video's existing captions in that locale
+func (s *CaptionService) AddCaptions(videoID, locale, format, filename string, cues []captions.Cue) (*models.VideoCaption, error) {
+	if len(cues) == 0 {
+		return nil, fmt.Errorf("caption file has no cues")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM video_captions WHERE video_id = $1 AND locale = $2`, videoID, locale); err != nil {
+		return nil, fmt.Errorf("failed to replace captions: %w", err)
+	}
+
+	var captionID string
+	err = tx.QueryRow(`
+		INSERT INTO video_captions (video_id, locale, format, source_filename, cue_count)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, videoID, locale, format, nullIfEmpty(filename), len(cues)).Scan(&captionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create captions: %w", err)
+	}
+
+	for i, cue := range cues {
+		_, err := tx.Exec(`
+			INSERT INTO video_caption_cues (caption_id, position, start_ms, end_ms, text)
+			VALUES ($1, $2, $3, $4, $5)
+		`, captionID, i+1, cue.Start.Milliseconds(), cue.End.Milliseconds(), cue.Text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save cue %d: %w", i+1, err)
+		}
+	}
+
+	caption, err := scanCaption(tx.QueryRow(`SELECT `+captionColumns+` FROM video_captions c WHERE c.id = $1`, captionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get captions: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return caption, nil
+}
+
+// ListCaptions lists the captions attached to a video
+func (s *CaptionService) ListCaptions(videoID string) ([]models.VideoCaption, error) {
+	rows, err := s.db.Query(`SELECT `+captionColumns+` FROM video_captions c WHERE c.video_id = $1 ORDER BY c.locale`, videoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query captions: %w", err)
+	}
+	defer rows.Close()
+
+	list := []models.VideoCaption{}
+	for rows.Next() {
+		caption, err := scanCaption(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan captions: %w", err)
+		}
+		list = append(list, *caption)
+	}
+
+	return list, nil
+}
+
+// RemoveCaptions deletes a video's captions in a locale
+func (s *CaptionService) RemoveCaptions(videoID, locale string) error {
+	result, err := s.db.Exec(`DELETE FROM video_captions WHERE video_id = $1 AND locale = $2`, videoID, locale)
+	if err != nil {
+		return fmt.Errorf("failed to delete captions: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("captions not found")
+	}
+	return nil
+}
+
+// GetVideosWithoutCaptions lists the videos lacking captions in each locale
+func (s *CaptionService) GetVideosWithoutCaptions(locales []string) ([]models.VideoWithoutCaptions, error) {
+	query := `
+		SELECT ev.id, ev.title, vc.name, l.locale
+		FROM exercise_videos ev
+		JOIN video_categories vc ON ev.category_id = vc.id
+		CROSS JOIN UNNEST($1::text[]) AS l(locale)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM video_captions c
+			WHERE c.video_id = ev.id AND c.locale = l.locale
+		)
+		ORDER BY l.locale, vc.sort_order, ev.title
+	`
+
+	rows, err := s.db.Query(query, pq.Array(locales))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query videos without captions: %w", err)
+	}
+	defer rows.Close()
+
+	missing := []models.VideoWithoutCaptions{}
+	for rows.Next() {
+		var m models.VideoWithoutCaptions
+		if err := rows.Scan(&m.VideoID, &m.VideoTitle, &m.Category, &m.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan video: %w", err)
+		}
+		missing = append(missing, m)
+	}
+
+	return missing, nil
+}
+
+// SearchTranscripts finds the caption cues where a phrase is spoken
+// (case-insensitive), optionally in one locale only. A phrase split across
+// two consecutive cues matches the first of them.
+func (s *CaptionService) SearchTranscripts(phrase, locale string, limit int) ([]models.TranscriptMatch, error) {
+	phrase = strings.Join(strings.Fields(phrase), " ")
+	if phrase == "" {
+		return nil, fmt.Errorf("search phrase is required")
+	}
+
+	query := `
+		SELECT ev.id, ev.title, ev.provider, ev.external_id, c.locale,
+			cue.start_ms, cue.end_ms, cue.text
+		FROM (
+			SELECT cc.caption_id, cc.position, cc.start_ms, cc.end_ms, cc.text,
+				COALESCE(LEAD(cc.text) OVER (PARTITION BY cc.caption_id ORDER BY cc.position), '') AS next_text
+			FROM video_caption_cues cc
+		) cue
+		JOIN video_captions c ON c.id = cue.caption_id
+		JOIN exercise_videos ev ON ev.id = c.video_id
+		WHERE (cue.text ILIKE $1
+				OR (cue.text || ' ' || cue.next_text ILIKE $1 AND cue.next_text NOT ILIKE $1))
+			AND ($2 = '' OR c.locale = $2)
+		ORDER BY ev.title, c.locale, cue.position
+	`
+	args := []interface{}{"%" + escapeLike(phrase) + "%", locale}
+	if limit > 0 {
+		query += " LIMIT $3"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transcripts: %w", err)
+	}
+	defer rows.Close()
+
+	matches := []models.TranscriptMatch{}
+	for rows.Next() {
+		var m models.TranscriptMatch
+		var providerName, externalID string
+		var startMs, endMs int64
+		err := rows.Scan(&m.VideoID, &m.VideoTitle, &providerName, &externalID, &m.Locale, &startMs, &endMs, &m.Text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transcript match: %w", err)
+		}
+		m.Start = models.Offset(time.Duration(startMs) * time.Millisecond)
+		m.End = models.Offset(time.Duration(endMs) * time.Millisecond)
+		if provider, err := providers.Get(providerName); err == nil {
+			m.DeepLink = provider.DeepLink(externalID, time.Duration(m.Start))
+		}
+		matches = append(matches, m)
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+)
+
+type ClosureService struct {
+	db *database.DB
+}
+
+func NewClosureService(db *database.DB) *ClosureService {
+	return &ClosureService{db: db}
+}
+
+const closureColumns = `
+		id, kind, start_date, end_date,
+		COALESCE(to_char(start_time, 'HH24:MI'), ''), COALESCE(to_char(end_time, 'HH24:MI'), ''),
+		reason, COALESCE(ics_uid, ''), COALESCE(created_by, ''), created_at, updated_at`
+
+// scanClosure scans the closureColumns of a row, followed by the extra
+// columns selected after them, if any
+func scanClosure(row rowScanner, extra ...interface{}) (*models.Closure, error) {
+	var c models.Closure
+	dest := []interface{}{
+		&c.ID,
+		&c.Kind,
+		&c.StartDate,
+		&c.EndDate,
+		&c.StartTime,
+		&c.EndTime,
+		&c.Reason,
+		&c.ICSUID,
+		&c.CreatedBy,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// AddClosure adds a closure
+func (s *ClosureService) AddClosure(data models.ClosureFormData) (*models.Closure, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO closures (kind, start_date, end_date, start_time, end_time, reason, ics_uid, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + closureColumns
+
+	closure, err := scanClosure(s.db.QueryRow(
+		query,
+		data.Kind,
+		data.StartDate.Format("2006-01-02"),
+		data.EndDate.Format("2006-01-02"),
+		nullIfEmpty(data.StartTime),
+		nullIfEmpty(data.EndTime),
+		strings.TrimSpace(data.Reason),
+		nullIfEmpty(data.ICSUID),
+		nullIfEmpty(strings.TrimSpace(data.CreatedBy)),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add closure: %w", err)
+	}
+
+	return closure, nil
+}
+
+// ImportClosure adds a closure imported from a calendar event, or updates the
+// one imported earlier from the same event (by its UID). It reports whether
+// the closure is new.
+func (s *ClosureService) ImportClosure(data models.ClosureFormData) (*models.Closure, bool, error) {
+	if err := data.Validate(); err != nil {
+		return nil, false, err
+	}
+	if data.ICSUID == "" {
+		return nil, false, fmt.Errorf("imported closures need the UID of their event")
+	}
+
+	query := `
+		INSERT INTO closures (kind, start_date, end_date, start_time, end_time, reason, ics_uid, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (ics_uid) DO UPDATE SET
+			kind = EXCLUDED.kind,
+			start_date = EXCLUDED.start_date,
+			end_date = EXCLUDED.end_date,
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time,
+			reason = EXCLUDED.reason,
+			updated_at = NOW()
+		RETURNING ` + closureColumns + `, (xmax = 0)`
+
+	var created bool
+	closure, err := scanClosure(s.db.QueryRow(
+		query,
+		data.Kind,
+		data.StartDate.Format("2006-01-02"),
+		data.EndDate.Format("2006-01-02"),
+		nullIfEmpty(data.StartTime),
+		nullIfEmpty(data.EndTime),
+		strings.TrimSpace(data.Reason),
+		data.ICSUID,
+		nullIfEmpty(strings.TrimSpace(data.CreatedBy)),
+	), &created)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to import closure: %w", err)
+	}
+
+	return closure, created, nil
+}
+
+// GetClosure retrieves a closure by ID
+func (s *ClosureService) GetClosure(id string) (*models.Closure, error) {
+	query := `SELECT ` + closureColumns + ` FROM closures WHERE id = $1`
+
+	closure, err := scanClosure(s.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("closure not found")
+		}
+		return nil, fmt.Errorf("failed to get closure: %w", err)
+	}
+
+	return closure, nil
+}
+
+// GetClosures retrieves the closures overlapping a date range; either end
+// may be left open
+func (s *ClosureService) GetClosures(from, to *time.Time) ([]models.Closure, error) {
+	query := `SELECT ` + closureColumns + ` FROM closures WHERE 1=1`
+
+	args := []interface{}{}
+	argIndex := 1
+
+	if from != nil {
+		query += fmt.Sprintf(" AND end_date >= $%d", argIndex)
+		args = append(args, from.Format("2006-01-02"))
+		argIndex++
+	}
+
+	if to != nil {
+		query += fmt.Sprintf(" AND start_date <= $%d", argIndex)
+		args = append(args, to.Format("2006-01-02"))
+	}
+
+	query += " ORDER BY start_date, start_time NULLS FIRST, id"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query closures: %w", err)
+	}
+	defer rows.Close()
+
+	closures := []models.Closure{}
+	for rows.Next() {
+		closure, err := scanClosure(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan closure: %w", err)
+		}
+		closures = append(closures, *closure)
+	}
+
+	return closures, nil
+}
+
+// DeleteClosure deletes a closure
+func (s *ClosureService) DeleteClosure(id string) error {
+	result, err := s.db.Exec(`DELETE FROM closures WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete closure: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("closure not found")
+	}
+
+	return nil
+}
+
+// GetCollisions returns the scheduled and confirmed appointments falling
+// within the closures, in date order. Appointment times are wall-clock times
+// of the clinic, whose time zone is loc.
+func (s *ClosureService) GetCollisions(closures []models.Closure, loc *time.Location) ([]models.ClosureCollision, error) {
+	collisions := []models.ClosureCollision{}
+	if len(closures) == 0 {
+		return collisions, nil
+	}
+
+	from, to := closures[0].StartDate, closures[0].EndDate
+	for _, c := range closures[1:] {
+		if c.StartDate.Before(from) {
+			from = c.StartDate
+		}
+		if c.EndDate.After(to) {
+			to = c.EndDate
+		}
+	}
+
+	appointments, err := NewAppointmentService(s.db).GetAppointments(models.AppointmentFilter{
+		From:     &from,
+		To:       &to,
+		Statuses: []string{models.AppointmentScheduled, models.AppointmentConfirmed},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, appointment := range appointments {
+		for _, closure := range closures {
+			if closure.Collides(&appointment, loc) {
+				collisions = append(collisions, models.ClosureCollision{Closure: closure, Appointment: appointment})
+				break
+			}
+		}
+	}
+
+	return collisions, nil
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+)
+
+type HEPService struct {
+	db *database.DB
+}
+
+func NewHEPService(db *database.DB) *HEPService {
+	return &HEPService{db: db}
+}
+
+const hepColumns = `
+		a.id, a.patient_email, COALESCE(a.patient_name, ''), a.program_id, a.video_id,
+		a.start_date, a.end_date, a.sets, a.reps, a.hold_seconds, a.rest_seconds,
+		COALESCE(a.frequency, ''), COALESCE(a.clinician_notes, ''), COALESCE(a.assigned_by, ''),
+		a.status, a.ended_at, COALESCE(a.end_reason, ''), a.created_at, a.updated_at,
+		p.name, ev.title, ev.video_url`
+
+const hepJoins = `
+		FROM home_exercise_assignments a
+		LEFT JOIN exercise_programs p ON p.id = a.program_id
+		LEFT JOIN exercise_videos ev ON ev.id = a.video_id`
+
+func scanHEPAssignment(row rowScanner) (*models.HEPAssignment, error) {
+	var a models.HEPAssignment
+	err := row.Scan(
+		&a.ID,
+		&a.PatientEmail,
+		&a.PatientName,
+		&a.ProgramID,
+		&a.VideoID,
+		&a.StartDate,
+		&a.EndDate,
+		&a.Sets,
+		&a.Reps,
+		&a.HoldSeconds,
+		&a.RestSeconds,
+		&a.Frequency,
+		&a.ClinicianNotes,
+		&a.AssignedBy,
+		&a.Status,
+		&a.EndedAt,
+		&a.EndReason,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+		&a.ProgramName,
+		&a.VideoTitle,
+		&a.VideoURL,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Assign prescribes a video or program to a patient. When no patient name is
+// given, it is taken from the patient's latest appointment.
+func (s *HEPService) Assign(data models.HEPAssignmentFormData) (*models.HEPAssignment, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Only published videos can be given to patients
+	var unpublished string
+	err := s.db.QueryRow(`
+		SELECT COALESCE(string_agg(ev.title, ', ' ORDER BY ev.title), '')
+		FROM exercise_videos ev
+		WHERE ev.status <> $3
+			AND (ev.id::text = $1 OR ev.id IN (
+				SELECT pi.video_id FROM exercise_program_items pi WHERE pi.program_id::text = $2
+			))
+	`, data.VideoID, data.ProgramID, models.VideoStatusPublished).Scan(&unpublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check videos: %w", err)
+	}
+	if unpublished != "" {
+		return nil, fmt.Errorf("videos not published yet: %s", unpublished)
+	}
+
+	query := `
+		INSERT INTO home_exercise_assignments (
+			patient_email, patient_name, program_id, video_id, start_date, end_date,
+			sets, reps, hold_seconds, rest_seconds, frequency, clinician_notes, assigned_by
+		)
+		VALUES (
+			$1,
+			COALESCE($2, (
+				SELECT patient_name FROM appointments
+				WHERE LOWER(patient_email) = $1
+				ORDER BY appointment_date DESC, appointment_time DESC
+				LIMIT 1
+			)),
+			$3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+		)
+		RETURNING id
+	`
+
+	var id string
+	err = s.db.QueryRow(
+		query,
+		strings.ToLower(strings.TrimSpace(data.PatientEmail)),
+		nullIfEmpty(data.PatientName),
+		nullIfEmpty(data.ProgramID),
+		nullIfEmpty(data.VideoID),
+		data.StartDate,
+		data.EndDate,
+		data.Sets,
+		data.Reps,
+		data.HoldSeconds,
+		data.RestSeconds,
+		nullIfEmpty(data.Frequency),
+		nullIfEmpty(data.ClinicianNotes),
+		nullIfEmpty(data.AssignedBy),
+	).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create assignment: %w", err)
+	}
+
+	return s.GetAssignment(id)
+}
+
+// GetAssignment retrieves an assignment by ID
+func (s *HEPService) GetAssignment(id string) (*models.HEPAssignment, error) {
+	query := `SELECT ` + hepColumns + hepJoins + ` WHERE a.id = $1`
+
+	assignment, err := scanHEPAssignment(s.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("assignment not found")
+		}
+		return nil, fmt.Errorf("failed to get assignment: %w", err)
+	}
+
+	return assignment, nil
+}
+
+// ListAssignments retrieves a patient's assignments, newest first. Ended
+// assignments are only included when includeEnded is set.
+func (s *HEPService) ListAssignments(patientEmail string, includeEnded bool) ([]models.HEPAssignment, error) {
+	query := `SELECT ` + hepColumns + hepJoins + `
+		WHERE LOWER(a.patient_email) = LOWER($1)
+		AND ($2 OR a.status = 'active')
+		ORDER BY a.start_date DESC, a.created_at DESC
+	`
+
+	rows, err := s.db.Query(query, strings.TrimSpace(patientEmail), includeEnded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []models.HEPAssignment{}
+	for rows.Next() {
+		assignment, err := scanHEPAssignment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		assignments = append(assignments, *assignment)
+	}
+
+	return assignments, nil
+}
+
+// EndAssignment stops an active assignment on the given date
+func (s *HEPService) EndAssignment(id string, endDate time.Time, reason string) (*models.HEPAssignment, error) {
+	query := `
+		UPDATE home_exercise_assignments SET
+			status = 'ended',
+			end_date = GREATEST($2::date, start_date),
+			ended_at = NOW(),
+			end_reason = $3,
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'active'
+	`
+
+	result, err := s.db.Exec(query, id, endDate, nullIfEmpty(reason))
+	if err != nil {
+		return nil, fmt.Errorf("failed to end assignment: %w", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		if _, err := s.GetAssignment(id); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("assignment has already ended")
+	}
+
+	return s.GetAssignment(id)
+}
+
+// GetHandout retrieves the assignments active on a date for a patient, with
+// the entries of assigned programs, for printing
+func (s *HEPService) GetHandout(patientEmail string, date time.Time) ([]models.HEPAssignment, error) {
+	assignments, err := s.ListAssignments(patientEmail, false)
+	if err != nil {
+		return nil, err
+	}
+
+	programs := NewProgramService(s.db)
+	active := []models.HEPAssignment{}
+	for _, assignment := range assignments {
+		if !assignment.IsActiveOn(date) {
+			continue
+		}
+		if assignment.ProgramID != nil {
+			assignment.Items, err = programs.getProgramItems(*assignment.ProgramID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		active = append(active, assignment)
+	}
+
+	// Oldest first reads more naturally on a handout
+	for i, j := 0, len(active)-1; i < j; i, j = i+1, j-1 {
+		active[i], active[j] = active[j], active[i]
+	}
+
+	return active, nil
+}
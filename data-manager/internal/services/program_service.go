@@ -0,0 +1,450 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+)
+
+type ProgramService struct {
+	db *database.DB
+}
+
+func NewProgramService(db *database.DB) *ProgramService {
+	return &ProgramService{db: db}
+}
+
+// GetPrograms retrieves all exercise programs (without their items)
+func (s *ProgramService) GetPrograms() ([]models.ExerciseProgram, error) {
+	query := `
+		SELECT p.id, p.name, p.description, p.duration_weeks, p.created_at, p.updated_at,
+			(SELECT COUNT(*) FROM exercise_program_items i WHERE i.program_id = p.id)
+		FROM exercise_programs p
+		ORDER BY p.name
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query programs: %w", err)
+	}
+	defer rows.Close()
+
+	var programs []models.ExerciseProgram
+	for rows.Next() {
+		var program models.ExerciseProgram
+		var description sql.NullString
+		err := rows.Scan(
+			&program.ID,
+			&program.Name,
+			&description,
+			&program.DurationWeeks,
+			&program.CreatedAt,
+			&program.UpdatedAt,
+			&program.ItemCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan program: %w", err)
+		}
+		program.Description = description.String
+		program.Items = []models.ProgramItem{}
+		programs = append(programs, program)
+	}
+
+	return programs, nil
+}
+
+// GetProgram retrieves a program with its items by ID or name (case-insensitive)
+func (s *ProgramService) GetProgram(identifier string) (*models.ExerciseProgram, error) {
+	query := `
+		SELECT id, name, description, duration_weeks, created_at, updated_at
+		FROM exercise_programs
+		WHERE id::text = $1 OR LOWER(name) = LOWER($1)
+	`
+
+	var program models.ExerciseProgram
+	var description sql.NullString
+	err := s.db.QueryRow(query, identifier).Scan(
+		&program.ID,
+		&program.Name,
+		&description,
+		&program.DurationWeeks,
+		&program.CreatedAt,
+		&program.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("program '%s' not found", identifier)
+		}
+		return nil, fmt.Errorf("failed to get program: %w", err)
+	}
+	program.Description = description.String
+
+	program.Items, err = s.getProgramItems(program.ID)
+	if err != nil {
+		return nil, err
+	}
+	program.ItemCount = len(program.Items)
+
+	return &program, nil
+}
+
+// getProgramItems retrieves the items of a program in order
+func (s *ProgramService) getProgramItems(programID string) ([]models.ProgramItem, error) {
+	query := `
+		SELECT i.id, i.program_id, i.video_id, i.position,
+			i.sets, i.reps, i.hold_seconds, i.rest_seconds, COALESCE(i.frequency, ''), COALESCE(i.notes, ''),
+			i.created_at, i.updated_at,
+			ev.title, ev.video_url
+		FROM exercise_program_items i
+		JOIN exercise_videos ev ON ev.id = i.video_id
+		WHERE i.program_id = $1
+		ORDER BY i.position
+	`
+
+	rows, err := s.db.Query(query, programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query program items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.ProgramItem{}
+	for rows.Next() {
+		var item models.ProgramItem
+		err := rows.Scan(
+			&item.ID,
+			&item.ProgramID,
+			&item.VideoID,
+			&item.Position,
+			&item.Sets,
+			&item.Reps,
+			&item.HoldSeconds,
+			&item.RestSeconds,
+			&item.Frequency,
+			&item.Notes,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+			&item.VideoTitle,
+			&item.VideoURL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan program item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// CreateProgram creates a new, empty exercise program
+func (s *ProgramService) CreateProgram(data models.ProgramFormData) (*models.ExerciseProgram, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO exercise_programs (name, description, duration_weeks)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	var id string
+	err := s.db.QueryRow(query, strings.TrimSpace(data.Name), data.Description, data.DurationWeeks).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create program: %w", err)
+	}
+
+	return s.GetProgram(id)
+}
+
+// UpdateProgram updates the name, description and duration of a program
+func (s *ProgramService) UpdateProgram(id string, data models.ProgramFormData) (*models.ExerciseProgram, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE exercise_programs SET
+			name = $2, description = $3, duration_weeks = $4,
+			updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := s.db.Exec(query, id, strings.TrimSpace(data.Name), data.Description, data.DurationWeeks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update program: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil, fmt.Errorf("program not found")
+	}
+
+	return s.GetProgram(id)
+}
+
+// DeleteProgram deletes a program and its items (hard delete)
+func (s *ProgramService) DeleteProgram(id string) error {
+	result, err := s.db.Exec(`DELETE FROM exercise_programs WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete program: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("program not found")
+	}
+
+	return nil
+}
+
+// AddItem adds a video to a program at the given position (or at the end),
+// shifting the following items down
+func (s *ProgramService) AddItem(programID string, data models.ProgramItemFormData) (*models.ProgramItem, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	count, err := lockProgramItems(tx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	position := data.Position
+	if position == 0 || position > count+1 {
+		position = count + 1
+	}
+
+	_, err = tx.Exec(`
+		UPDATE exercise_program_items SET position = position + 1
+		WHERE program_id = $1 AND position >= $2
+	`, programID, position)
+	if err != nil {
+		return nil, fmt.Errorf("failed to shift program items: %w", err)
+	}
+
+	var itemID string
+	err = tx.QueryRow(`
+		INSERT INTO exercise_program_items (
+			program_id, video_id, position, sets, reps, hold_seconds, rest_seconds, frequency, notes
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, programID, data.VideoID, position, data.Sets, data.Reps, data.HoldSeconds, data.RestSeconds,
+		nullIfEmpty(data.Frequency), nullIfEmpty(data.Notes)).Scan(&itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add video to program: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit program item: %w", err)
+	}
+
+	return s.getProgramItem(programID, position)
+}
+
+// UpdateItem updates the prescription of the item at a position
+func (s *ProgramService) UpdateItem(programID string, position int, data models.ProgramItemFormData) (*models.ProgramItem, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE exercise_program_items SET
+			video_id = $3, sets = $4, reps = $5, hold_seconds = $6, rest_seconds = $7,
+			frequency = $8, notes = $9, updated_at = NOW()
+		WHERE program_id = $1 AND position = $2
+	`, programID, position, data.VideoID, data.Sets, data.Reps, data.HoldSeconds, data.RestSeconds,
+		nullIfEmpty(data.Frequency), nullIfEmpty(data.Notes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update program item: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil, fmt.Errorf("program has no item at position %d", position)
+	}
+
+	return s.getProgramItem(programID, position)
+}
+
+// RemoveItem removes the item at a position and closes the gap
+func (s *ProgramService) RemoveItem(programID string, position int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockProgramItems(tx, programID); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`DELETE FROM exercise_program_items WHERE program_id = $1 AND position = $2`, programID, position)
+	if err != nil {
+		return fmt.Errorf("failed to remove program item: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("program has no item at position %d", position)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE exercise_program_items SET position = position - 1
+		WHERE program_id = $1 AND position > $2
+	`, programID, position)
+	if err != nil {
+		return fmt.Errorf("failed to shift program items: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit program item removal: %w", err)
+	}
+
+	return nil
+}
+
+// MoveItem moves the item at one position to another, shifting the items in between
+func (s *ProgramService) MoveItem(programID string, from int, to int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	count, err := lockProgramItems(tx, programID)
+	if err != nil {
+		return err
+	}
+	if from < 1 || from > count {
+		return fmt.Errorf("program has no item at position %d", from)
+	}
+	if to < 1 || to > count {
+		return fmt.Errorf("position must be between 1 and %d", count)
+	}
+	if from == to {
+		return nil
+	}
+
+	// The (program_id, position) constraint is deferred until commit
+	var shift string
+	if from < to {
+		shift = `UPDATE exercise_program_items SET position = position - 1
+			WHERE program_id = $1 AND position > $2 AND position <= $3`
+	} else {
+		shift = `UPDATE exercise_program_items SET position = position + 1
+			WHERE program_id = $1 AND position >= $3 AND position < $2`
+	}
+
+	var itemID string
+	err = tx.QueryRow(`SELECT id FROM exercise_program_items WHERE program_id = $1 AND position = $2`, programID, from).Scan(&itemID)
+	if err != nil {
+		return fmt.Errorf("failed to get program item: %w", err)
+	}
+	if _, err := tx.Exec(shift, programID, from, to); err != nil {
+		return fmt.Errorf("failed to shift program items: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE exercise_program_items SET position = $2 WHERE id = $1`, itemID, to); err != nil {
+		return fmt.Errorf("failed to move program item: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reorder: %w", err)
+	}
+
+	return nil
+}
+
+// DuplicateProgram copies a program and all its items under a new name
+func (s *ProgramService) DuplicateProgram(programID string, newName string) (*models.ExerciseProgram, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var newID string
+	err = tx.QueryRow(`
+		INSERT INTO exercise_programs (name, description, duration_weeks)
+		SELECT $2, description, duration_weeks FROM exercise_programs WHERE id = $1
+		RETURNING id
+	`, programID, newName).Scan(&newID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("program not found")
+		}
+		return nil, fmt.Errorf("failed to duplicate program: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO exercise_program_items (
+			program_id, video_id, position, sets, reps, hold_seconds, rest_seconds, frequency, notes
+		)
+		SELECT $2, video_id, position, sets, reps, hold_seconds, rest_seconds, frequency, notes
+		FROM exercise_program_items
+		WHERE program_id = $1
+	`, programID, newID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to duplicate program items: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit program copy: %w", err)
+	}
+
+	return s.GetProgram(newID)
+}
+
+// getProgramItem retrieves the item at a position
+func (s *ProgramService) getProgramItem(programID string, position int) (*models.ProgramItem, error) {
+	items, err := s.getProgramItems(programID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.Position == position {
+			return &item, nil
+		}
+	}
+	return nil, fmt.Errorf("program has no item at position %d", position)
+}
+
+// lockProgramItems locks a program and its items for reordering and returns
+// the number of items
+func lockProgramItems(tx *sql.Tx, programID string) (int, error) {
+	var id string
+	err := tx.QueryRow(`SELECT id FROM exercise_programs WHERE id = $1 FOR UPDATE`, programID).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("program not found")
+		}
+		return 0, fmt.Errorf("failed to lock program: %w", err)
+	}
+
+	var count int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM exercise_program_items WHERE program_id = $1`, programID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count program items: %w", err)
+	}
+
+	return count, nil
+}
+
+func nullIfEmpty(value string) interface{} {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"github.com/lib/pq"
+)
+
+type ReviewService struct {
+	db *database.DB
+}
+
+func NewReviewService(db *database.DB) *ReviewService {
+	return &ReviewService{db: db}
+}
+
+// Review moves a video through the review workflow and logs the step. The
+// video is locked while it changes so concurrent reviews cannot both apply.
+func (s *ReviewService) Review(videoID string, data models.ReviewFormData) (*models.ExerciseVideo, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	transition := models.ReviewTransitions[data.Action]
+	actor := strings.TrimSpace(data.Actor)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	var submittedBy *string
+	var expireAt *time.Time
+	err = tx.QueryRow(`SELECT status, submitted_by, expire_at FROM exercise_videos WHERE id = $1 FOR UPDATE`, videoID).Scan(&status, &submittedBy, &expireAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("video not found")
+		}
+		return nil, fmt.Errorf("failed to get video: %w", err)
+	}
+
+	if err := transition.Check(data.Action, status, actor, submittedBy); err != nil {
+		return nil, err
+	}
+	if data.Action == models.ReviewPublish && expireAt != nil && !expireAt.After(time.Now()) {
+		return nil, fmt.Errorf("video expired on %s; change its expire date first", expireAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	var query string
+	args := []interface{}{videoID, transition.To}
+	switch data.Action {
+	case models.ReviewSubmit:
+		query = `UPDATE exercise_videos SET status = $2, submitted_by = $3, submitted_at = NOW(),
+			reviewed_by = NULL, reviewed_at = NULL, updated_at = NOW() WHERE id = $1`
+		args = append(args, actor)
+	case models.ReviewApprove, models.ReviewReject:
+		query = `UPDATE exercise_videos SET status = $2, reviewed_by = $3, reviewed_at = NOW(), updated_at = NOW() WHERE id = $1`
+		args = append(args, actor)
+	case models.ReviewPublish:
+		// Publishing by hand replaces a publish date still to come
+		query = `UPDATE exercise_videos SET status = $2, published_at = NOW(),
+			publish_at = CASE WHEN publish_at > NOW() THEN NULL ELSE publish_at END,
+			updated_at = NOW() WHERE id = $1`
+	case models.ReviewUnpublish:
+		// The scheduler must not publish it again
+		query = `UPDATE exercise_videos SET status = $2, published_at = NULL, publish_at = NULL, updated_at = NOW() WHERE id = $1`
+	case models.ReviewExpire:
+		query = `UPDATE exercise_videos SET status = $2, published_at = NULL, updated_at = NOW() WHERE id = $1`
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return nil, fmt.Errorf("failed to update video status: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO video_reviews (video_id, action, from_status, to_status, actor, comment)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, videoID, data.Action, status, transition.To, actor, nullIfEmpty(strings.TrimSpace(data.Comment)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to log review: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit review: %w", err)
+	}
+
+	return NewVideoService(s.db).GetVideoByID(videoID)
+}
+
+// GetReviews returns the review log of a video, oldest first
+func (s *ReviewService) GetReviews(videoID string) ([]models.VideoReview, error) {
+	query := `
+		SELECT id, video_id, action, from_status, to_status, actor, comment, created_at
+		FROM video_reviews
+		WHERE video_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := s.db.Query(query, videoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []models.VideoReview{}
+	for rows.Next() {
+		var r models.VideoReview
+		if err := rows.Scan(&r.ID, &r.VideoID, &r.Action, &r.FromStatus, &r.ToStatus, &r.Actor, &r.Comment, &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, r)
+	}
+
+	return reviews, nil
+}
+
+// GetLatestReviews returns the latest review step of each video, by video ID
+func (s *ReviewService) GetLatestReviews(videoIDs []string) (map[string]models.VideoReview, error) {
+	query := `
+		SELECT DISTINCT ON (video_id) id, video_id, action, from_status, to_status, actor, comment, created_at
+		FROM video_reviews
+		WHERE video_id::text = ANY($1)
+		ORDER BY video_id, created_at DESC, id DESC
+	`
+
+	rows, err := s.db.Query(query, pq.Array(videoIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reviews: %w", err)
+	}
+	defer rows.Close()
+
+	latest := make(map[string]models.VideoReview)
+	for rows.Next() {
+		var r models.VideoReview
+		if err := rows.Scan(&r.ID, &r.VideoID, &r.Action, &r.FromStatus, &r.ToStatus, &r.Actor, &r.Comment, &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		latest[r.VideoID] = r
+	}
+
+	return latest, nil
+}
+
+// RunScheduler applies the publishing windows as of now: approved videos
+// whose publish date has passed are published, and published videos whose
+// expire date has passed are unpublished. Videos due to be published that are
+// not approved yet are reported as skipped. Each change is applied on its
+// own: one failing does not stop the others, and is marked as failed. With
+// dryRun nothing is changed.
+func (s *ReviewService) RunScheduler(now time.Time, dryRun bool) ([]models.ScheduledChange, error) {
+	query := `
+		SELECT id, title, status,
+			CASE WHEN status = $2 THEN expire_at ELSE publish_at END AS due_at
+		FROM exercise_videos
+		WHERE (status = $2 AND expire_at <= $1)
+			OR (status <> $2 AND publish_at <= $1 AND (expire_at IS NULL OR expire_at > $1))
+		ORDER BY due_at, title
+	`
+
+	rows, err := s.db.Query(query, now, models.VideoStatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled videos: %w", err)
+	}
+
+	changes := []models.ScheduledChange{}
+	for rows.Next() {
+		var c models.ScheduledChange
+		if err := rows.Scan(&c.VideoID, &c.Title, &c.Status, &c.DueAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan scheduled video: %w", err)
+		}
+		switch c.Status {
+		case models.VideoStatusPublished:
+			c.Action = models.ReviewExpire
+		case models.VideoStatusApproved:
+			c.Action = models.ReviewPublish
+		default:
+			c.Action = models.ScheduleSkip
+		}
+		changes = append(changes, c)
+	}
+	rows.Close()
+
+	if dryRun {
+		return changes, nil
+	}
+
+	failed := 0
+	for i := range changes {
+		c := &changes[i]
+		if c.Action == models.ScheduleSkip {
+			continue
+		}
+		_, err := s.Review(c.VideoID, models.ReviewFormData{
+			Action:  c.Action,
+			Actor:   models.SchedulerActor,
+			Comment: fmt.Sprintf("scheduled for %s", c.DueAt.Local().Format("2006-01-02 15:04")),
+		})
+		if err != nil {
+			c.Result = models.ScheduleFailed
+			c.Error = err.Error()
+			failed++
+			continue
+		}
+		c.Result = models.ScheduleApplied
+	}
+
+	if failed > 0 {
+		return changes, fmt.Errorf("%d scheduled change(s) failed", failed)
+	}
+	return changes, nil
+}
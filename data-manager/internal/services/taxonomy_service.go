@@ -0,0 +1,622 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"github.com/lib/pq"
+)
+
+type TaxonomyService struct {
+	db *database.DB
+}
+
+func NewTaxonomyService(db *database.DB) *TaxonomyService {
+	return &TaxonomyService{db: db}
+}
+
+// placeholderTerms are values that mean "nothing" and are dropped on normalization
+var placeholderTerms = map[string]bool{
+	"none":         true,
+	"n/a":          true,
+	"-":            true,
+	"no equipment": true,
+}
+
+// Taxonomy is an in-memory lookup of canonical terms and synonyms
+type Taxonomy struct {
+	lookup map[models.TaxonomyKind]map[string]string
+}
+
+// Normalize maps values to their canonical terms, dropping placeholders and
+// duplicates. Values that are not in the vocabulary are kept as given and
+// also returned as unknown.
+func (t *Taxonomy) Normalize(kind models.TaxonomyKind, values []string) ([]string, []string) {
+	normalized := make([]string, 0, len(values))
+	var unknown []string
+	seen := make(map[string]bool, len(values))
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		key := strings.ToLower(value)
+		if value == "" || placeholderTerms[key] {
+			continue
+		}
+
+		canonical, ok := t.lookup[kind][key]
+		if !ok {
+			canonical = value
+			unknown = append(unknown, value)
+		}
+
+		if seen[strings.ToLower(canonical)] {
+			continue
+		}
+		seen[strings.ToLower(canonical)] = true
+		normalized = append(normalized, canonical)
+	}
+
+	return normalized, unknown
+}
+
+// NormalizeFormData normalizes the equipment, body parts and tags of a video.
+// In strict mode values that are not in the vocabulary are rejected.
+func (t *Taxonomy) NormalizeFormData(data *models.VideoFormData, strict bool) error {
+	fields := []struct {
+		kind   models.TaxonomyKind
+		values *[]string
+	}{
+		{models.TaxonomyEquipment, &data.EquipmentRequired},
+		{models.TaxonomyBodyPart, &data.BodyParts},
+		{models.TaxonomyTag, &data.Tags},
+	}
+
+	for _, field := range fields {
+		normalized, unknown := t.Normalize(field.kind, *field.values)
+		if strict && len(unknown) > 0 {
+			return fmt.Errorf("unknown %s: %s (add them with 'taxonomy add' or 'taxonomy alias')",
+				field.kind, strings.Join(unknown, ", "))
+		}
+		*field.values = normalized
+	}
+
+	return nil
+}
+
+// LoadTaxonomy loads every term and synonym into memory
+func (s *TaxonomyService) LoadTaxonomy() (*Taxonomy, error) {
+	query := `
+		SELECT t.kind, t.name, t.name FROM taxonomy_terms t
+		UNION ALL
+		SELECT s.kind, s.synonym, t.name
+		FROM taxonomy_synonyms s
+		JOIN taxonomy_terms t ON t.id = s.term_id
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query taxonomy: %w", err)
+	}
+	defer rows.Close()
+
+	taxonomy := &Taxonomy{lookup: make(map[models.TaxonomyKind]map[string]string)}
+	for _, kind := range models.TaxonomyKinds {
+		taxonomy.lookup[kind] = make(map[string]string)
+	}
+
+	for rows.Next() {
+		var kind, value, canonical string
+		if err := rows.Scan(&kind, &value, &canonical); err != nil {
+			return nil, fmt.Errorf("failed to scan taxonomy term: %w", err)
+		}
+		taxonomy.lookup[models.TaxonomyKind(kind)][strings.ToLower(value)] = canonical
+	}
+
+	return taxonomy, nil
+}
+
+// ListTerms retrieves the terms of a vocabulary (or all when kind is empty)
+func (s *TaxonomyService) ListTerms(kind models.TaxonomyKind) ([]models.TaxonomyTerm, error) {
+	query := `
+		SELECT t.id, t.kind, t.name,
+			ARRAY(SELECT s.synonym FROM taxonomy_synonyms s WHERE s.term_id = t.id ORDER BY LOWER(s.synonym)),
+			t.created_at, t.updated_at
+		FROM taxonomy_terms t
+		WHERE $1 = '' OR t.kind = $1
+		ORDER BY t.kind, LOWER(t.name)
+	`
+
+	rows, err := s.db.Query(query, string(kind))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query taxonomy terms: %w", err)
+	}
+	defer rows.Close()
+
+	var terms []models.TaxonomyTerm
+	for rows.Next() {
+		var term models.TaxonomyTerm
+		term.Synonyms = make([]string, 0)
+		err := rows.Scan(
+			&term.ID,
+			&term.Kind,
+			&term.Name,
+			pq.Array(&term.Synonyms),
+			&term.CreatedAt,
+			&term.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan taxonomy term: %w", err)
+		}
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+// AddTerm adds a canonical term to a vocabulary
+func (s *TaxonomyService) AddTerm(kind models.TaxonomyKind, name string) (*models.TaxonomyTerm, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("term name is required")
+	}
+	if err := s.ensureUnused(s.db, kind, name); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO taxonomy_terms (kind, name)
+		VALUES ($1, $2)
+		RETURNING id, kind, name, created_at, updated_at
+	`
+
+	term := models.TaxonomyTerm{Synonyms: []string{}}
+	err := s.db.QueryRow(query, kind, name).Scan(&term.ID, &term.Kind, &term.Name, &term.CreatedAt, &term.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create taxonomy term: %w", err)
+	}
+
+	return &term, nil
+}
+
+// AddAlias registers a synonym for an existing term
+func (s *TaxonomyService) AddAlias(kind models.TaxonomyKind, synonym string, termName string) error {
+	synonym = strings.TrimSpace(synonym)
+	if synonym == "" {
+		return fmt.Errorf("synonym is required")
+	}
+
+	termID, _, err := s.getTermID(s.db, kind, termName)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureUnused(s.db, kind, synonym); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO taxonomy_synonyms (term_id, kind, synonym) VALUES ($1, $2, $3)`
+	if _, err := s.db.Exec(query, termID, kind, synonym); err != nil {
+		return fmt.Errorf("failed to create synonym: %w", err)
+	}
+
+	return nil
+}
+
+// MergeTerms folds a term into another one. The merged term and its synonyms
+// become synonyms of the target, and videos using it are updated. It returns
+// the number of updated videos and of those sent back to draft because they
+// were approved or published; the edit is logged with the editor.
+func (s *TaxonomyService) MergeTerms(kind models.TaxonomyKind, from string, into string, editor string) (int, int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	fromID, fromName, err := s.getTermID(tx, kind, from)
+	if err != nil {
+		return 0, 0, err
+	}
+	intoID, intoName, err := s.getTermID(tx, kind, into)
+	if err != nil {
+		return 0, 0, err
+	}
+	if fromID == intoID {
+		return 0, 0, fmt.Errorf("cannot merge a term into itself")
+	}
+
+	oldNames := []string{fromName}
+	rows, err := tx.Query(`SELECT synonym FROM taxonomy_synonyms WHERE term_id = $1`, fromID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query synonyms: %w", err)
+	}
+	for rows.Next() {
+		var synonym string
+		if err := rows.Scan(&synonym); err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("failed to scan synonym: %w", err)
+		}
+		oldNames = append(oldNames, synonym)
+	}
+	rows.Close()
+
+	if _, err := tx.Exec(`UPDATE taxonomy_synonyms SET term_id = $2 WHERE term_id = $1`, fromID, intoID); err != nil {
+		return 0, 0, fmt.Errorf("failed to move synonyms: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM taxonomy_terms WHERE id = $1`, fromID); err != nil {
+		return 0, 0, fmt.Errorf("failed to delete merged term: %w", err)
+	}
+	if _, err := tx.Exec(`INSERT INTO taxonomy_synonyms (term_id, kind, synonym) VALUES ($1, $2, $3)`, intoID, kind, fromName); err != nil {
+		return 0, 0, fmt.Errorf("failed to create synonym: %w", err)
+	}
+
+	comment := fmt.Sprintf("%s term '%s' merged into '%s'", kind, fromName, intoName)
+	updated, reopened, err := rewriteVideoTerms(tx, kind, oldNames, intoName, editor, comment)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit merge: %w", err)
+	}
+
+	return updated, reopened, nil
+}
+
+// RenameTerm changes the canonical name of a term. The old name is kept as a
+// synonym and videos using it are updated. It returns the number of updated
+// videos and of those sent back to draft, like MergeTerms.
+func (s *TaxonomyService) RenameTerm(kind models.TaxonomyKind, oldName string, newName string, editor string) (int, int, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return 0, 0, fmt.Errorf("new name is required")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	termID, currentName, err := s.getTermID(tx, kind, oldName)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Renaming to one of the term's own synonyms (or a case change) is allowed
+	if _, err := tx.Exec(`DELETE FROM taxonomy_synonyms WHERE term_id = $1 AND LOWER(synonym) = LOWER($2)`, termID, newName); err != nil {
+		return 0, 0, fmt.Errorf("failed to update synonyms: %w", err)
+	}
+	if !strings.EqualFold(currentName, newName) {
+		if err := s.ensureUnused(tx, kind, newName); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE taxonomy_terms SET name = $2 WHERE id = $1`, termID, newName); err != nil {
+		return 0, 0, fmt.Errorf("failed to rename term: %w", err)
+	}
+	if !strings.EqualFold(currentName, newName) {
+		if _, err := tx.Exec(`INSERT INTO taxonomy_synonyms (term_id, kind, synonym) VALUES ($1, $2, $3)`, termID, kind, currentName); err != nil {
+			return 0, 0, fmt.Errorf("failed to create synonym: %w", err)
+		}
+	}
+
+	comment := fmt.Sprintf("%s term '%s' renamed to '%s'", kind, currentName, newName)
+	updated, reopened, err := rewriteVideoTerms(tx, kind, []string{currentName}, newName, editor, comment)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit rename: %w", err)
+	}
+
+	return updated, reopened, nil
+}
+
+// NormalizeChange describes how normalization changed one video
+type NormalizeChange struct {
+	VideoID string              `json:"video_id"`
+	Title   string              `json:"title"`
+	Kind    models.TaxonomyKind `json:"kind"`
+	Before  []string            `json:"before"`
+	After   []string            `json:"after"`
+	Unknown []string            `json:"unknown,omitempty"`
+}
+
+// NormalizeResult represents the result of a normalization run
+type NormalizeResult struct {
+	VideosScanned  int               `json:"videos_scanned"`
+	VideosChanged  int               `json:"videos_changed"`
+	VideosReopened int               `json:"videos_reopened"` // approved or published videos sent back to draft
+	Changes        []NormalizeChange `json:"changes"`
+	Unknown        []NormalizeChange `json:"unknown,omitempty"`
+}
+
+// NormalizeVideos rewrites the equipment, body parts and tags of every video
+// to canonical terms. Unknown values are reported, and removed when
+// dropUnknown is set. Approved or published videos that change are sent back
+// to draft, logged with the editor.
+func (s *TaxonomyService) NormalizeVideos(dryRun bool, dropUnknown bool, editor string) (*NormalizeResult, error) {
+	taxonomy, err := s.LoadTaxonomy()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT id, title, status, equipment_required, body_parts, tags
+		FROM exercise_videos
+		ORDER BY title
+		FOR UPDATE
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query videos: %w", err)
+	}
+
+	var videos []models.ExerciseVideo
+	for rows.Next() {
+		video := models.ExerciseVideo{
+			EquipmentRequired: make([]string, 0),
+			BodyParts:         make([]string, 0),
+			Tags:              make([]string, 0),
+		}
+		err := rows.Scan(&video.ID, &video.Title, &video.Status, pq.Array(&video.EquipmentRequired), pq.Array(&video.BodyParts), pq.Array(&video.Tags))
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan video: %w", err)
+		}
+		videos = append(videos, video)
+	}
+	rows.Close()
+
+	result := &NormalizeResult{
+		VideosScanned: len(videos),
+		Changes:       []NormalizeChange{},
+	}
+
+	for i := range videos {
+		video := &videos[i]
+		changed := false
+		for _, kind := range models.TaxonomyKinds {
+			before := kind.Values(video)
+			after, unknown := taxonomy.Normalize(kind, before)
+			if len(unknown) > 0 {
+				result.Unknown = append(result.Unknown, NormalizeChange{
+					VideoID: video.ID, Title: video.Title, Kind: kind, Before: before, Unknown: unknown,
+				})
+				if dropUnknown {
+					after = models.RemoveValues(after, unknown)
+				}
+			}
+			if models.EqualValues(before, after) {
+				continue
+			}
+
+			changed = true
+			result.Changes = append(result.Changes, NormalizeChange{
+				VideoID: video.ID, Title: video.Title, Kind: kind, Before: before, After: after, Unknown: unknown,
+			})
+			if !dryRun {
+				query := fmt.Sprintf(`UPDATE exercise_videos SET %s = $2, updated_at = NOW() WHERE id = $1`, kind.Column())
+				if _, err := tx.Exec(query, video.ID, pq.Array(after)); err != nil {
+					return nil, fmt.Errorf("failed to update video '%s': %w", video.Title, err)
+				}
+			}
+		}
+		if changed {
+			result.VideosChanged++
+		}
+		if changed && videoReviewed(video.Status) {
+			result.VideosReopened++
+			if !dryRun {
+				if err := reopenVideo(tx, video.ID, video.Status, editor, "Terms normalized after review"); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit normalization: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetTermStats computes how many videos use each term of a vocabulary, in
+// which categories, and which categories have no videos at all
+func (s *TaxonomyService) GetTermStats(kind models.TaxonomyKind) (*models.TermStats, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			t.term,
+			COUNT(DISTINCT ev.id),
+			COUNT(DISTINCT ev.id) FILTER (WHERE ev.difficulty_level = 'beginner'),
+			COUNT(DISTINCT ev.id) FILTER (WHERE ev.difficulty_level = 'intermediate'),
+			COUNT(DISTINCT ev.id) FILTER (WHERE ev.difficulty_level = 'advanced'),
+			COALESCE(ARRAY_AGG(DISTINCT vc.name) FILTER (WHERE vc.name IS NOT NULL), '{}')
+		FROM exercise_videos ev
+		CROSS JOIN LATERAL unnest(ev.%s) AS t(term)
+		LEFT JOIN exercise_video_categories evc ON evc.video_id = ev.id
+		LEFT JOIN video_categories vc ON vc.id = evc.category_id
+		GROUP BY t.term
+		ORDER BY 2 DESC, LOWER(t.term)
+	`, kind.Column())
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s usage: %w", kind, err)
+	}
+	defer rows.Close()
+
+	stats := &models.TermStats{
+		Kind:            kind,
+		Terms:           []models.TermUsage{},
+		SingleUse:       []string{},
+		EmptyCategories: []string{},
+	}
+
+	for rows.Next() {
+		var usage models.TermUsage
+		usage.Categories = make([]string, 0)
+		err := rows.Scan(
+			&usage.Term,
+			&usage.VideoCount,
+			&usage.Beginner,
+			&usage.Intermediate,
+			&usage.Advanced,
+			pq.Array(&usage.Categories),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s usage: %w", kind, err)
+		}
+		stats.Terms = append(stats.Terms, usage)
+		if usage.VideoCount == 1 {
+			stats.SingleUse = append(stats.SingleUse, usage.Term)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s usage: %w", kind, err)
+	}
+
+	emptyRows, err := s.db.Query(`
+		SELECT vc.name FROM video_categories vc
+		WHERE NOT EXISTS (SELECT 1 FROM exercise_video_categories evc WHERE evc.category_id = vc.id)
+		ORDER BY vc.sort_order, vc.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query empty categories: %w", err)
+	}
+	defer emptyRows.Close()
+
+	for emptyRows.Next() {
+		var name string
+		if err := emptyRows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		stats.EmptyCategories = append(stats.EmptyCategories, name)
+	}
+
+	return stats, nil
+}
+
+// queryer is implemented by both *database.DB and *sql.Tx
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// getTermID looks up a term by its canonical name or one of its synonyms
+func (s *TaxonomyService) getTermID(q queryer, kind models.TaxonomyKind, name string) (string, string, error) {
+	query := `
+		SELECT t.id, t.name FROM taxonomy_terms t
+		WHERE t.kind = $1 AND (
+			LOWER(t.name) = LOWER($2)
+			OR EXISTS (SELECT 1 FROM taxonomy_synonyms s WHERE s.term_id = t.id AND LOWER(s.synonym) = LOWER($2))
+		)
+	`
+
+	var id, canonical string
+	err := q.QueryRow(query, kind, strings.TrimSpace(name)).Scan(&id, &canonical)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", "", fmt.Errorf("%s term '%s' not found", kind, name)
+		}
+		return "", "", fmt.Errorf("failed to get taxonomy term: %w", err)
+	}
+
+	return id, canonical, nil
+}
+
+// ensureUnused fails when a name is already a term or synonym of the vocabulary
+func (s *TaxonomyService) ensureUnused(q queryer, kind models.TaxonomyKind, name string) error {
+	if _, canonical, err := s.getTermID(q, kind, name); err == nil {
+		return fmt.Errorf("'%s' is already used by %s term '%s'", name, kind, canonical)
+	}
+	return nil
+}
+
+// rewriteVideoTerms replaces the given names (ignoring case) with a canonical
+// term in every video using them, sending approved or published videos whose
+// values change back to draft with the comment. It returns the number of
+// updated videos and of those sent back to draft.
+func rewriteVideoTerms(tx *sql.Tx, kind models.TaxonomyKind, oldNames []string, newName string, editor string, comment string) (int, int, error) {
+	lowered := make([]string, len(oldNames))
+	for i, name := range oldNames {
+		lowered[i] = strings.ToLower(name)
+	}
+
+	column := kind.Column()
+	rows, err := tx.Query(fmt.Sprintf(`
+		SELECT id, status, %[1]s FROM exercise_videos
+		WHERE EXISTS (SELECT 1 FROM unnest(%[1]s) AS v WHERE LOWER(v) = ANY($1))
+		FOR UPDATE
+	`, column), pq.Array(lowered))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query videos: %w", err)
+	}
+
+	type match struct {
+		id, status string
+		values     []string
+	}
+	var matches []match
+	for rows.Next() {
+		m := match{values: make([]string, 0)}
+		if err := rows.Scan(&m.id, &m.status, pq.Array(&m.values)); err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("failed to scan video: %w", err)
+		}
+		matches = append(matches, m)
+	}
+	rows.Close()
+
+	reopened := 0
+	query := fmt.Sprintf(`UPDATE exercise_videos SET %s = $2, updated_at = NOW() WHERE id = $1`, column)
+	for _, m := range matches {
+		values := replaceValues(m.values, lowered, newName)
+		if _, err := tx.Exec(query, m.id, pq.Array(values)); err != nil {
+			return 0, 0, fmt.Errorf("failed to update video %s: %w", m.id, err)
+		}
+
+		if videoReviewed(m.status) && !models.EqualValues(m.values, values) {
+			if err := reopenVideo(tx, m.id, m.status, editor, comment); err != nil {
+				return 0, 0, err
+			}
+			reopened++
+		}
+	}
+
+	return len(matches), reopened, nil
+}
+
+// replaceValues replaces values matching any of the lowercased names with
+// the replacement, dropping duplicates
+func replaceValues(values []string, lowered []string, replacement string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		for _, name := range lowered {
+			if strings.ToLower(value) == name {
+				value = replacement
+				break
+			}
+		}
+		if seen[strings.ToLower(value)] {
+			continue
+		}
+		seen[strings.ToLower(value)] = true
+		result = append(result, value)
+	}
+	return result
+}
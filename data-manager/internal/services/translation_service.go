@@ -0,0 +1,378 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+
+	"fisio-data-manager/internal/database"
+	"fisio-data-manager/internal/models"
+	"github.com/lib/pq"
+)
+
+type TranslationService struct {
+	db *database.DB
+}
+
+func NewTranslationService(db *database.DB) *TranslationService {
+	return &TranslationService{db: db}
+}
+
+// execer is implemented by both *database.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// SetVideoTranslation creates or updates a video's translation. Empty fields
+// keep their current translation. An approved or published video whose
+// translation changes is sent back to draft, logged with the editor; the
+// returned flag reports whether it was.
+func (s *TranslationService) SetVideoTranslation(videoID, locale string, translation models.Translation, editor string) (bool, error) {
+	if err := checkTranslation(locale, translation); err != nil {
+		return false, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow(`SELECT status FROM exercise_videos WHERE id = $1 FOR UPDATE`, videoID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, fmt.Errorf("video not found")
+		}
+		return false, fmt.Errorf("failed to get video: %w", err)
+	}
+
+	var current models.Translation
+	err = tx.QueryRow(`
+		SELECT COALESCE(title, ''), COALESCE(description, '')
+		FROM exercise_video_translations
+		WHERE video_id = $1 AND locale = $2
+	`, videoID, locale).Scan(&current.Title, &current.Description)
+	if err != nil && err != sql.ErrNoRows {
+		return false, fmt.Errorf("failed to get %s translation: %w", locale, err)
+	}
+	changed := (translation.Title != "" && translation.Title != current.Title) ||
+		(translation.Description != "" && translation.Description != current.Description)
+
+	if err := upsertVideoTranslation(tx, videoID, locale, translation); err != nil {
+		return false, err
+	}
+
+	reopen := changed && videoReviewed(status)
+	if reopen {
+		if err := reopenVideo(tx, videoID, status, editor, fmt.Sprintf("%s translation changed after review", locale)); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit translation: %w", err)
+	}
+
+	return reopen, nil
+}
+
+// SetCategoryTranslation creates or updates a category's translation; the
+// translation title is the category name. Empty fields keep their current
+// translation.
+func (s *TranslationService) SetCategoryTranslation(categoryID, locale string, translation models.Translation) error {
+	if err := checkTranslation(locale, translation); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO video_category_translations (category_id, locale, name, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (category_id, locale) DO UPDATE SET
+			name = COALESCE(EXCLUDED.name, video_category_translations.name),
+			description = COALESCE(EXCLUDED.description, video_category_translations.description),
+			updated_at = NOW()
+	`
+
+	_, err := s.db.Exec(query, categoryID, locale, nullIfEmpty(translation.Title), nullIfEmpty(translation.Description))
+	if err != nil {
+		return fmt.Errorf("failed to save category translation: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveVideoTranslation deletes a video's translation in a locale
+func (s *TranslationService) RemoveVideoTranslation(videoID, locale string) error {
+	result, err := s.db.Exec(`DELETE FROM exercise_video_translations WHERE video_id = $1 AND locale = $2`, videoID, locale)
+	if err != nil {
+		return fmt.Errorf("failed to delete video translation: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("translation not found")
+	}
+	return nil
+}
+
+// RemoveCategoryTranslation deletes a category's translation in a locale
+func (s *TranslationService) RemoveCategoryTranslation(categoryID, locale string) error {
+	result, err := s.db.Exec(`DELETE FROM video_category_translations WHERE category_id = $1 AND locale = $2`, categoryID, locale)
+	if err != nil {
+		return fmt.Errorf("failed to delete category translation: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("translation not found")
+	}
+	return nil
+}
+
+// GetVideoTranslations retrieves every translation of a video
+func (s *TranslationService) GetVideoTranslations(videoID string) ([]models.VideoTranslation, error) {
+	query := `
+		SELECT video_id, locale, COALESCE(title, ''), COALESCE(description, ''), updated_at
+		FROM exercise_video_translations
+		WHERE video_id = $1
+		ORDER BY locale
+	`
+
+	rows, err := s.db.Query(query, videoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query video translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []models.VideoTranslation{}
+	for rows.Next() {
+		var t models.VideoTranslation
+		if err := rows.Scan(&t.VideoID, &t.Locale, &t.Title, &t.Description, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan video translation: %w", err)
+		}
+		translations = append(translations, t)
+	}
+
+	return translations, nil
+}
+
+// LocalizeVideos replaces the titles, descriptions and category names of
+// the videos with their translations in the locale. Texts without a
+// translation keep the default locale.
+func (s *TranslationService) LocalizeVideos(videos []models.ExerciseVideo, locale string) error {
+	if locale == models.DefaultLocale || len(videos) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(videos))
+	for i, video := range videos {
+		ids[i] = video.ID
+	}
+
+	translations, err := s.GetVideoTranslationsIn(locale, ids)
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.categoryTranslations(locale)
+	if err != nil {
+		return err
+	}
+
+	for i := range videos {
+		video := &videos[i]
+		if t, ok := translations[video.ID]; ok {
+			if t.Title != "" {
+				video.Title = t.Title
+			}
+			if t.Description != "" {
+				video.Description = t.Description
+			}
+		}
+
+		if t, ok := categories[video.CategoryID]; ok {
+			if t.Title != "" {
+				name := t.Title
+				video.CategoryName = &name
+			}
+			if t.Description != "" {
+				description := t.Description
+				video.CategoryDescription = &description
+			}
+		}
+		for j, categoryID := range video.CategoryIDs {
+			if t, ok := categories[categoryID]; ok && t.Title != "" && j < len(video.CategoryNames) {
+				video.CategoryNames[j] = t.Title
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetVideoTranslationsIn retrieves the translations of the given videos in a
+// locale, by video ID
+func (s *TranslationService) GetVideoTranslationsIn(locale string, videoIDs []string) (map[string]models.Translation, error) {
+	translations := make(map[string]models.Translation)
+	if locale == models.DefaultLocale || len(videoIDs) == 0 {
+		return translations, nil
+	}
+
+	query := `
+		SELECT video_id, COALESCE(title, ''), COALESCE(description, '')
+		FROM exercise_video_translations
+		WHERE locale = $1 AND video_id::text = ANY($2)
+	`
+
+	rows, err := s.db.Query(query, locale, pq.Array(videoIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query video translations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var videoID string
+		var t models.Translation
+		if err := rows.Scan(&videoID, &t.Title, &t.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan video translation: %w", err)
+		}
+		translations[videoID] = t
+	}
+
+	return translations, nil
+}
+
+// LocalizeCategories replaces the names and descriptions of the categories
+// with their translations in the locale
+func (s *TranslationService) LocalizeCategories(categories []models.VideoCategory, locale string) error {
+	if locale == models.DefaultLocale || len(categories) == 0 {
+		return nil
+	}
+
+	translations, err := s.categoryTranslations(locale)
+	if err != nil {
+		return err
+	}
+
+	for i := range categories {
+		if t, ok := translations[categories[i].ID]; ok {
+			if t.Title != "" {
+				categories[i].Name = t.Title
+			}
+			if t.Description != "" {
+				categories[i].Description = t.Description
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetMissingTranslations lists the videos and categories lacking a
+// translation of their title/name or (non-empty) description in each locale
+func (s *TranslationService) GetMissingTranslations(locales []string) ([]models.MissingTranslation, error) {
+	query := `
+		SELECT kind, id, name, locale, missing_title, missing_description
+		FROM (
+			SELECT 'category' AS kind, c.id::text AS id, c.name, l.locale,
+				t.name IS NULL AS missing_title,
+				COALESCE(c.description, '') <> '' AND t.description IS NULL AS missing_description,
+				0 AS kind_order, c.sort_order, c.name AS sort_name
+			FROM video_categories c
+			CROSS JOIN UNNEST($1::text[]) AS l(locale)
+			LEFT JOIN video_category_translations t ON t.category_id = c.id AND t.locale = l.locale
+			UNION ALL
+			SELECT 'video', ev.id::text, ev.title, l.locale,
+				t.title IS NULL,
+				COALESCE(ev.description, '') <> '' AND t.description IS NULL,
+				1, 0, ev.title
+			FROM exercise_videos ev
+			CROSS JOIN UNNEST($1::text[]) AS l(locale)
+			LEFT JOIN exercise_video_translations t ON t.video_id = ev.id AND t.locale = l.locale
+		) missing
+		WHERE missing_title OR missing_description
+		ORDER BY locale, kind_order, sort_order, sort_name
+	`
+
+	rows, err := s.db.Query(query, pq.Array(locales))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query missing translations: %w", err)
+	}
+	defer rows.Close()
+
+	missing := []models.MissingTranslation{}
+	for rows.Next() {
+		var m models.MissingTranslation
+		var missingTitle, missingDescription bool
+		if err := rows.Scan(&m.Kind, &m.ID, &m.Name, &m.Locale, &missingTitle, &missingDescription); err != nil {
+			return nil, fmt.Errorf("failed to scan missing translation: %w", err)
+		}
+		if missingTitle {
+			if m.Kind == "category" {
+				m.Missing = append(m.Missing, "name")
+			} else {
+				m.Missing = append(m.Missing, "title")
+			}
+		}
+		if missingDescription {
+			m.Missing = append(m.Missing, "description")
+		}
+		missing = append(missing, m)
+	}
+
+	return missing, nil
+}
+
+// categoryTranslations loads every category translation in a locale, by
+// category ID
+func (s *TranslationService) categoryTranslations(locale string) (map[string]models.Translation, error) {
+	query := `
+		SELECT category_id, COALESCE(name, ''), COALESCE(description, '')
+		FROM video_category_translations
+		WHERE locale = $1
+	`
+
+	rows, err := s.db.Query(query, locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := make(map[string]models.Translation)
+	for rows.Next() {
+		var categoryID string
+		var t models.Translation
+		if err := rows.Scan(&categoryID, &t.Title, &t.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan category translation: %w", err)
+		}
+		translations[categoryID] = t
+	}
+
+	return translations, nil
+}
+
+// upsertVideoTranslation creates or updates a video translation, keeping the
+// current value of empty fields
+func upsertVideoTranslation(q execer, videoID, locale string, translation models.Translation) error {
+	query := `
+		INSERT INTO exercise_video_translations (video_id, locale, title, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (video_id, locale) DO UPDATE SET
+			title = COALESCE(EXCLUDED.title, exercise_video_translations.title),
+			description = COALESCE(EXCLUDED.description, exercise_video_translations.description),
+			updated_at = NOW()
+	`
+
+	_, err := q.Exec(query, videoID, locale, nullIfEmpty(translation.Title), nullIfEmpty(translation.Description))
+	if err != nil {
+		return fmt.Errorf("failed to save %s translation: %w", locale, err)
+	}
+
+	return nil
+}
+
+func checkTranslation(locale string, translation models.Translation) error {
+	if locale == models.DefaultLocale {
+		return fmt.Errorf("'%s' is the default locale, update the video or category instead", locale)
+	}
+	if translation.IsEmpty() {
+		return fmt.Errorf("a translated title/name or description is required")
+	}
+	return nil
+}
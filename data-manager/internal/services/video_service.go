@@ -5,23 +5,54 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
-	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"fisio-data-manager/internal/database"
 	"fisio-data-manager/internal/models"
+	"fisio-data-manager/internal/providers"
 	"github.com/lib/pq"
 )
 
 type VideoService struct {
-	db *database.DB
+	db             *database.DB
+	taxonomy       *Taxonomy
+	strictTaxonomy bool
 }
 
 func NewVideoService(db *database.DB) *VideoService {
 	return &VideoService{db: db}
 }
 
+// SetStrictTaxonomy makes create, update and import reject equipment, body
+// parts and tags that are not in the taxonomy
+func (s *VideoService) SetStrictTaxonomy(strict bool) {
+	s.strictTaxonomy = strict
+}
+
+// normalizeTerms maps the video's equipment, body parts and tags to their
+// canonical taxonomy terms, and its conditions to their names. The taxonomy
+// is loaded once per service.
+func (s *VideoService) normalizeTerms(data *models.VideoFormData) error {
+	var err error
+	if data.Contraindications, err = models.ParseConditions(data.Contraindications); err != nil {
+		return err
+	}
+	if data.Precautions, err = models.ParseConditions(data.Precautions); err != nil {
+		return err
+	}
+
+	if s.taxonomy == nil {
+		taxonomy, err := NewTaxonomyService(s.db).LoadTaxonomy()
+		if err != nil {
+			return err
+		}
+		s.taxonomy = taxonomy
+	}
+	return s.taxonomy.NormalizeFormData(data, s.strictTaxonomy)
+}
+
 // GetCategories retrieves all video categories
 func (s *VideoService) GetCategories() ([]models.VideoCategory, error) {
 	query := `
@@ -172,32 +203,177 @@ func (s *VideoService) DeleteCategory(id string) error {
 	return nil
 }
 
-// GetVideos retrieves exercise videos with optional filters
-func (s *VideoService) GetVideos(categoryID string, difficulty string) ([]models.ExerciseVideo, error) {
-	query := `
-		SELECT 
-			ev.id, ev.title, ev.description, ev.youtube_id, ev.youtube_url,
+// videoColumns is the column list shared by the video queries, in the order
+// expected by scanVideo
+const videoColumns = `
+			ev.id, ev.title, ev.description, ev.provider, ev.external_id, ev.video_url,
+			COALESCE(ev.youtube_id, ''), COALESCE(ev.youtube_url, ''),
 			ev.category_id, ev.duration, ev.difficulty_level, ev.equipment_required,
-			ev.body_parts, ev.tags, ev.thumbnail_url,
+			ev.body_parts, ev.tags, ev.contraindications, ev.precautions,
+			ev.min_weeks_post_surgery, ev.status, ev.submitted_by, ev.submitted_at,
+			ev.reviewed_by, ev.reviewed_at, ev.published_at, ev.publish_at, ev.expire_at,
+			ev.thumbnail_url,
 			ev.created_at, ev.updated_at,
-			vc.name as category_name, vc.description as category_description
+			vc.name as category_name, vc.description as category_description,
+			ARRAY(
+				SELECT evc.category_id::text
+				FROM exercise_video_categories evc
+				JOIN video_categories c ON c.id = evc.category_id
+				WHERE evc.video_id = ev.id
+				ORDER BY evc.is_primary DESC, c.sort_order, c.name
+			) as category_ids,
+			ARRAY(
+				SELECT c.name
+				FROM exercise_video_categories evc
+				JOIN video_categories c ON c.id = evc.category_id
+				WHERE evc.video_id = ev.id
+				ORDER BY evc.is_primary DESC, c.sort_order, c.name
+			) as category_names`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanVideo scans a row selected with videoColumns
+func scanVideo(row rowScanner) (*models.ExerciseVideo, error) {
+	var video models.ExerciseVideo
+	// Initialize slices to avoid nil pointer issues
+	video.EquipmentRequired = make([]string, 0)
+	video.BodyParts = make([]string, 0)
+	video.Tags = make([]string, 0)
+	video.Contraindications = make([]string, 0)
+	video.Precautions = make([]string, 0)
+	video.CategoryIDs = make([]string, 0)
+	video.CategoryNames = make([]string, 0)
+
+	err := row.Scan(
+		&video.ID,
+		&video.Title,
+		&video.Description,
+		&video.Provider,
+		&video.ExternalID,
+		&video.VideoURL,
+		&video.YoutubeID,
+		&video.YoutubeURL,
+		&video.CategoryID,
+		&video.Duration,
+		&video.DifficultyLevel,
+		pq.Array(&video.EquipmentRequired),
+		pq.Array(&video.BodyParts),
+		pq.Array(&video.Tags),
+		pq.Array(&video.Contraindications),
+		pq.Array(&video.Precautions),
+		&video.MinWeeksPostSurgery,
+		&video.Status,
+		&video.SubmittedBy,
+		&video.SubmittedAt,
+		&video.ReviewedBy,
+		&video.ReviewedAt,
+		&video.PublishedAt,
+		&video.PublishAt,
+		&video.ExpireAt,
+		&video.ThumbnailURL,
+		&video.CreatedAt,
+		&video.UpdatedAt,
+		&video.CategoryName,
+		&video.CategoryDescription,
+		pq.Array(&video.CategoryIDs),
+		pq.Array(&video.CategoryNames),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if provider, err := providers.Get(video.Provider); err == nil {
+		video.EmbedURL = provider.EmbedURL(video.ExternalID)
+	}
+	return &video, nil
+}
+
+// GetVideos retrieves exercise videos with optional filters
+func (s *VideoService) GetVideos(filter models.VideoFilter) ([]models.ExerciseVideo, error) {
+	query := `
+		SELECT ` + videoColumns + `
 		FROM exercise_videos ev
 		JOIN video_categories vc ON ev.category_id = vc.id
 		WHERE 1=1
 	`
-	
+
 	var args []interface{}
 	argIndex := 1
 
-	if categoryID != "" {
-		query += fmt.Sprintf(" AND ev.category_id = $%d", argIndex)
-		args = append(args, categoryID)
-		argIndex++
+	if len(filter.CategoryIDs) > 0 {
+		if filter.MatchAllCategories {
+			query += fmt.Sprintf(` AND (
+				SELECT COUNT(DISTINCT evc.category_id) FROM exercise_video_categories evc
+				WHERE evc.video_id = ev.id AND evc.category_id::text = ANY($%d)
+			) = $%d`, argIndex, argIndex+1)
+			args = append(args, pq.Array(filter.CategoryIDs), len(uniqueStrings(filter.CategoryIDs)))
+			argIndex += 2
+		} else {
+			query += fmt.Sprintf(` AND EXISTS (
+				SELECT 1 FROM exercise_video_categories evc
+				WHERE evc.video_id = ev.id AND evc.category_id::text = ANY($%d)
+			)`, argIndex)
+			args = append(args, pq.Array(filter.CategoryIDs))
+			argIndex++
+		}
 	}
 
-	if difficulty != "" {
+	if filter.Difficulty != "" {
 		query += fmt.Sprintf(" AND ev.difficulty_level = $%d", argIndex)
-		args = append(args, difficulty)
+		args = append(args, filter.Difficulty)
+		argIndex++
+	}
+
+	if len(filter.Tags) > 0 {
+		query += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM unnest(ev.tags) AS v WHERE LOWER(v) = ANY($%d))", argIndex)
+		args = append(args, pq.Array(lowerStrings(filter.Tags)))
+		argIndex++
+	}
+
+	if len(filter.BodyParts) > 0 {
+		query += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM unnest(ev.body_parts) AS v WHERE LOWER(v) = ANY($%d))", argIndex)
+		args = append(args, pq.Array(lowerStrings(filter.BodyParts)))
+		argIndex++
+	}
+
+	if len(filter.IDs) > 0 {
+		query += fmt.Sprintf(" AND ev.id::text = ANY($%d)", argIndex)
+		args = append(args, pq.Array(filter.IDs))
+		argIndex++
+	}
+
+	if len(filter.Statuses) > 0 {
+		query += fmt.Sprintf(" AND ev.status = ANY($%d)", argIndex)
+		args = append(args, pq.Array(filter.Statuses))
+		argIndex++
+	}
+
+	if filter.PublicAt != nil {
+		query += fmt.Sprintf(` AND ev.status = 'published'
+			AND (ev.publish_at IS NULL OR ev.publish_at <= $%d)
+			AND (ev.expire_at IS NULL OR ev.expire_at > $%d)`, argIndex, argIndex)
+		args = append(args, *filter.PublicAt)
+		argIndex++
+	}
+
+	if len(filter.SafeFor) > 0 {
+		query += fmt.Sprintf(" AND NOT ev.contraindications && $%d", argIndex)
+		args = append(args, pq.Array(filter.SafeFor))
+		argIndex++
+	}
+
+	if filter.WeeksPostSurgery != nil {
+		query += fmt.Sprintf(" AND (ev.min_weeks_post_surgery IS NULL OR ev.min_weeks_post_surgery <= $%d)", argIndex)
+		args = append(args, *filter.WeeksPostSurgery)
+		argIndex++
+	}
+
+	if filter.Search != "" {
+		query += fmt.Sprintf(` AND (ev.title ILIKE $%d OR ev.description ILIKE $%d
+			OR EXISTS (SELECT 1 FROM unnest(ev.tags) AS v WHERE v ILIKE $%d))`, argIndex, argIndex, argIndex)
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
 		argIndex++
 	}
 
@@ -211,34 +387,11 @@ func (s *VideoService) GetVideos(categoryID string, difficulty string) ([]models
 
 	var videos []models.ExerciseVideo
 	for rows.Next() {
-		var video models.ExerciseVideo
-		// Initialize slices to avoid nil pointer issues
-		video.EquipmentRequired = make([]string, 0)
-		video.BodyParts = make([]string, 0)
-		video.Tags = make([]string, 0)
-		
-		err := rows.Scan(
-			&video.ID,
-			&video.Title,
-			&video.Description,
-			&video.YoutubeID,
-			&video.YoutubeURL,
-			&video.CategoryID,
-			&video.Duration,
-			&video.DifficultyLevel,
-			pq.Array(&video.EquipmentRequired),
-			pq.Array(&video.BodyParts),
-			pq.Array(&video.Tags),
-			&video.ThumbnailURL,
-			&video.CreatedAt,
-			&video.UpdatedAt,
-			&video.CategoryName,
-			&video.CategoryDescription,
-		)
+		video, err := scanVideo(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan video: %w", err)
 		}
-		videos = append(videos, video)
+		videos = append(videos, *video)
 	}
 
 	return videos, nil
@@ -247,42 +400,13 @@ func (s *VideoService) GetVideos(categoryID string, difficulty string) ([]models
 // GetVideoByID retrieves a video by ID
 func (s *VideoService) GetVideoByID(id string) (*models.ExerciseVideo, error) {
 	query := `
-		SELECT 
-			ev.id, ev.title, ev.description, ev.youtube_id, ev.youtube_url,
-			ev.category_id, ev.duration, ev.difficulty_level, ev.equipment_required,
-			ev.body_parts, ev.tags, ev.thumbnail_url,
-			ev.created_at, ev.updated_at,
-			vc.name as category_name, vc.description as category_description
+		SELECT ` + videoColumns + `
 		FROM exercise_videos ev
 		JOIN video_categories vc ON ev.category_id = vc.id
 		WHERE ev.id = $1
 	`
-	
-	var video models.ExerciseVideo
-	// Initialize slices to avoid nil pointer issues
-	video.EquipmentRequired = make([]string, 0)
-	video.BodyParts = make([]string, 0)
-	video.Tags = make([]string, 0)
-	
-	err := s.db.QueryRow(query, id).Scan(
-		&video.ID,
-		&video.Title,
-		&video.Description,
-		&video.YoutubeID,
-		&video.YoutubeURL,
-		&video.CategoryID,
-		&video.Duration,
-		&video.DifficultyLevel,
-		pq.Array(&video.EquipmentRequired),
-		pq.Array(&video.BodyParts),
-		pq.Array(&video.Tags),
-		&video.ThumbnailURL,
-		&video.CreatedAt,
-		&video.UpdatedAt,
-		&video.CategoryName,
-		&video.CategoryDescription,
-	)
-	
+
+	video, err := scanVideo(s.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("video not found")
@@ -290,7 +414,7 @@ func (s *VideoService) GetVideoByID(id string) (*models.ExerciseVideo, error) {
 		return nil, fmt.Errorf("failed to get video: %w", err)
 	}
 
-	return &video, nil
+	return video, nil
 }
 
 // CreateVideo creates a new exercise video
@@ -298,133 +422,153 @@ func (s *VideoService) CreateVideo(data models.VideoFormData) (*models.ExerciseV
 	if err := data.Validate(); err != nil {
 		return nil, err
 	}
+	if err := s.normalizeTerms(&data); err != nil {
+		return nil, err
+	}
 
-	// Extract YouTube ID from URL
-	youtubeID, err := s.extractYouTubeID(data.YoutubeURL)
+	source, err := resolveVideoSource(data)
 	if err != nil {
 		return nil, err
 	}
 
-	// Generate thumbnail URL
-	thumbnailURL := fmt.Sprintf("https://img.youtube.com/vi/%s/maxresdefault.jpg", youtubeID)
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
 	query := `
 		INSERT INTO exercise_videos (
-			title, description, youtube_url, category_id, duration, difficulty_level,
-			equipment_required, body_parts, tags, thumbnail_url
+			title, description, provider, external_id, video_url, youtube_url,
+			category_id, duration, difficulty_level,
+			equipment_required, body_parts, tags, thumbnail_url,
+			contraindications, precautions, min_weeks_post_surgery,
+			publish_at, expire_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, title, description, youtube_id, youtube_url, category_id, duration,
-		          difficulty_level, equipment_required, body_parts, tags, thumbnail_url,
-		          created_at, updated_at
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING id
 	`
-	
-	var video models.ExerciseVideo
-	// Initialize slices to avoid nil pointer issues
-	video.EquipmentRequired = make([]string, 0)
-	video.BodyParts = make([]string, 0)
-	video.Tags = make([]string, 0)
-	
-	err = s.db.QueryRow(
+
+	var id string
+	err = tx.QueryRow(
 		query,
 		data.Title,
 		data.Description,
-		data.YoutubeURL,
-		data.CategoryID,
+		source.provider,
+		source.externalID,
+		source.videoURL,
+		source.youtubeURL,
+		data.PrimaryCategoryID(),
 		data.Duration,
 		data.DifficultyLevel,
 		pq.Array(data.EquipmentRequired),
 		pq.Array(data.BodyParts),
 		pq.Array(data.Tags),
-		thumbnailURL,
-	).Scan(
-		&video.ID,
-		&video.Title,
-		&video.Description,
-		&video.YoutubeID,
-		&video.YoutubeURL,
-		&video.CategoryID,
-		&video.Duration,
-		&video.DifficultyLevel,
-		pq.Array(&video.EquipmentRequired),
-		pq.Array(&video.BodyParts),
-		pq.Array(&video.Tags),
-		&video.ThumbnailURL,
-		&video.CreatedAt,
-		&video.UpdatedAt,
-	)
-	
+		source.thumbnailURL,
+		pq.Array(data.Contraindications),
+		pq.Array(data.Precautions),
+		data.MinWeeksPostSurgery,
+		data.PublishAt,
+		data.ExpireAt,
+	).Scan(&id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create video: %w", err)
 	}
 
-	return &video, nil
+	if err := setVideoCategories(tx, id, data.AllCategoryIDs()); err != nil {
+		return nil, err
+	}
+
+	for locale, translation := range data.Translations {
+		if translation.IsEmpty() {
+			continue
+		}
+		if err := upsertVideoTranslation(tx, id, locale, translation); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit video: %w", err)
+	}
+
+	return s.GetVideoByID(id)
 }
 
 // UpdateVideo updates an existing exercise video
-func (s *VideoService) UpdateVideo(id string, data models.VideoFormData) (*models.ExerciseVideo, error) {
+func (s *VideoService) UpdateVideo(id string, data models.VideoFormData, editor string) (*models.ExerciseVideo, error) {
 	if err := data.Validate(); err != nil {
 		return nil, err
 	}
+	if err := s.normalizeTerms(&data); err != nil {
+		return nil, err
+	}
 
-	// Generate thumbnail URL if YouTube URL changed
-	thumbnailURL := ""
-	if data.YoutubeURL != "" {
-		youtubeID, err := s.extractYouTubeID(data.YoutubeURL)
-		if err != nil {
-			return nil, err
+	source, err := resolveVideoSource(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := scanVideo(tx.QueryRow(`
+		SELECT `+videoColumns+`
+		FROM exercise_videos ev
+		JOIN video_categories vc ON ev.category_id = vc.id
+		WHERE ev.id = $1
+		FOR UPDATE OF ev
+	`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("video not found")
 		}
-		thumbnailURL = fmt.Sprintf("https://img.youtube.com/vi/%s/maxresdefault.jpg", youtubeID)
+		return nil, fmt.Errorf("failed to get video: %w", err)
+	}
+	// A reviewed video whose content changes needs reviewing again
+	reopen := videoReviewed(existing.Status) && videoContentChanged(existing, data, source)
+	if reopen && strings.TrimSpace(editor) == "" {
+		return nil, errEditorRequired(existing.Status)
 	}
 
 	query := `
 		UPDATE exercise_videos SET
-			title = $2, description = $3, youtube_url = $4, category_id = $5,
-			duration = $6, difficulty_level = $7, equipment_required = $8,
-			body_parts = $9, tags = $10, thumbnail_url = $11,
+			title = $2, description = $3, provider = $4, external_id = $5,
+			video_url = $6, youtube_url = $7, category_id = $8,
+			duration = $9, difficulty_level = $10, equipment_required = $11,
+			body_parts = $12, tags = $13, thumbnail_url = $14,
+			contraindications = $15, precautions = $16, min_weeks_post_surgery = $17,
+			publish_at = $18, expire_at = $19,
 			updated_at = NOW()
 		WHERE id = $1
-		RETURNING id, title, description, youtube_id, youtube_url, category_id, duration,
-		          difficulty_level, equipment_required, body_parts, tags, thumbnail_url,
-		          created_at, updated_at
+		RETURNING id
 	`
-	
-	var video models.ExerciseVideo
-	// Initialize slices to avoid nil pointer issues
-	video.EquipmentRequired = make([]string, 0)
-	video.BodyParts = make([]string, 0)
-	video.Tags = make([]string, 0)
-	
-	err := s.db.QueryRow(
+
+	err = tx.QueryRow(
 		query,
 		id,
 		data.Title,
 		data.Description,
-		data.YoutubeURL,
-		data.CategoryID,
+		source.provider,
+		source.externalID,
+		source.videoURL,
+		source.youtubeURL,
+		data.PrimaryCategoryID(),
 		data.Duration,
 		data.DifficultyLevel,
 		pq.Array(data.EquipmentRequired),
 		pq.Array(data.BodyParts),
 		pq.Array(data.Tags),
-		thumbnailURL,
-	).Scan(
-		&video.ID,
-		&video.Title,
-		&video.Description,
-		&video.YoutubeID,
-		&video.YoutubeURL,
-		&video.CategoryID,
-		&video.Duration,
-		&video.DifficultyLevel,
-		pq.Array(&video.EquipmentRequired),
-		pq.Array(&video.BodyParts),
-		pq.Array(&video.Tags),
-		&video.ThumbnailURL,
-		&video.CreatedAt,
-		&video.UpdatedAt,
-	)
-	
+		source.thumbnailURL,
+		pq.Array(data.Contraindications),
+		pq.Array(data.Precautions),
+		data.MinWeeksPostSurgery,
+		data.PublishAt,
+		data.ExpireAt,
+	).Scan(&id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("video not found")
@@ -432,7 +576,296 @@ func (s *VideoService) UpdateVideo(id string, data models.VideoFormData) (*model
 		return nil, fmt.Errorf("failed to update video: %w", err)
 	}
 
-	return &video, nil
+	if err := setVideoCategories(tx, id, data.AllCategoryIDs()); err != nil {
+		return nil, err
+	}
+
+	for locale, translation := range data.Translations {
+		if translation.IsEmpty() {
+			continue
+		}
+		if err := upsertVideoTranslation(tx, id, locale, translation); err != nil {
+			return nil, err
+		}
+	}
+
+	if reopen {
+		if err := reopenVideo(tx, id, existing.Status, editor, "Content changed after review"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit video: %w", err)
+	}
+
+	return s.GetVideoByID(id)
+}
+
+// videoContentChanged reports whether an update changes what reviewers
+// signed off: anything but the publishing window
+func videoContentChanged(existing *models.ExerciseVideo, data models.VideoFormData, source *videoSource) bool {
+	thumbnail, _ := source.thumbnailURL.(string)
+	existingThumbnail := ""
+	if existing.ThumbnailURL != nil {
+		existingThumbnail = *existing.ThumbnailURL
+	}
+	existingCategories := (&models.VideoFormData{CategoryID: existing.CategoryID, CategoryIDs: existing.CategoryIDs}).AllCategoryIDs()
+	categories := data.AllCategoryIDs()
+	sort.Strings(existingCategories)
+	sort.Strings(categories)
+
+	for _, translation := range data.Translations {
+		if !translation.IsEmpty() {
+			return true
+		}
+	}
+
+	return data.Title != existing.Title ||
+		data.Description != existing.Description ||
+		source.provider != existing.Provider ||
+		source.videoURL != existing.VideoURL ||
+		thumbnail != existingThumbnail ||
+		!models.EqualValues(categories, existingCategories) ||
+		!equalIntPtr(data.Duration, existing.Duration) ||
+		data.DifficultyLevel != existing.DifficultyLevel ||
+		!models.EqualValues(data.EquipmentRequired, existing.EquipmentRequired) ||
+		!models.EqualValues(data.BodyParts, existing.BodyParts) ||
+		!models.EqualValues(data.Tags, existing.Tags) ||
+		!models.EqualValues(data.Contraindications, existing.Contraindications) ||
+		!models.EqualValues(data.Precautions, existing.Precautions) ||
+		!equalIntPtr(data.MinWeeksPostSurgery, existing.MinWeeksPostSurgery)
+}
+
+// videoReviewed reports whether a video in the status was signed off by a
+// reviewer, so changes to its content need reviewing again
+func videoReviewed(status string) bool {
+	return status == models.VideoStatusApproved || status == models.VideoStatusPublished
+}
+
+// errEditorRequired is returned when the content of a reviewed video changes
+// without saying who changed it
+func errEditorRequired(status string) error {
+	return fmt.Errorf("changing the content of a video that is %s sends it back to draft; give who is editing it", status)
+}
+
+// reopenVideo sends a reviewed video whose content changed back to draft and
+// logs the edit, with what changed, in its review log
+func reopenVideo(tx *sql.Tx, id, status, editor, comment string) error {
+	editor = strings.TrimSpace(editor)
+	if editor == "" {
+		return errEditorRequired(status)
+	}
+
+	_, err := tx.Exec(`
+		UPDATE exercise_videos SET status = $2, reviewed_by = NULL, reviewed_at = NULL, published_at = NULL
+		WHERE id = $1
+	`, id, models.VideoStatusDraft)
+	if err != nil {
+		return fmt.Errorf("failed to update video status: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO video_reviews (video_id, action, from_status, to_status, actor, comment)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, id, models.ReviewEdit, status, models.VideoStatusDraft, editor, comment)
+	if err != nil {
+		return fmt.Errorf("failed to log review: %w", err)
+	}
+
+	return nil
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// videoSource holds the provider columns of a video
+type videoSource struct {
+	provider     string
+	externalID   string
+	videoURL     string
+	youtubeURL   interface{} // NULL for other providers
+	thumbnailURL interface{} // NULL when there is none
+}
+
+// resolveVideoSource detects the provider of the video URL (unless one is
+// given) and derives the stored URLs and thumbnail
+func resolveVideoSource(data models.VideoFormData) (*videoSource, error) {
+	provider, externalID, err := providers.Resolve(data.Provider, data.VideoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &videoSource{
+		provider:   provider.Name(),
+		externalID: externalID,
+		videoURL:   provider.CanonicalURL(externalID),
+	}
+	if source.provider == "youtube" {
+		source.youtubeURL = source.videoURL
+	}
+
+	thumbnailURL := strings.TrimSpace(data.ThumbnailURL)
+	if thumbnailURL == "" {
+		thumbnailURL = provider.ThumbnailURL(externalID)
+	}
+	source.thumbnailURL = nullIfEmpty(thumbnailURL)
+
+	return source, nil
+}
+
+// setVideoCategories replaces the category assignments of a video. The first
+// category ID is stored as the primary category.
+func setVideoCategories(tx *sql.Tx, videoID string, categoryIDs []string) error {
+	if _, err := tx.Exec(`DELETE FROM exercise_video_categories WHERE video_id = $1`, videoID); err != nil {
+		return fmt.Errorf("failed to clear video categories: %w", err)
+	}
+
+	for i, categoryID := range categoryIDs {
+		_, err := tx.Exec(
+			`INSERT INTO exercise_video_categories (video_id, category_id, is_primary) VALUES ($1, $2, $3)`,
+			videoID, categoryID, i == 0,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to assign category %s: %w", categoryID, err)
+		}
+	}
+
+	return nil
+}
+
+// BulkUpdateResult represents the result of a bulk update
+type BulkUpdateResult struct {
+	Matched  int `json:"matched"`
+	Updated  int `json:"updated"`
+	Reopened int `json:"reopened"` // approved or published videos sent back to draft
+}
+
+// BulkUpdateVideos applies the same operations to several videos in a single
+// transaction. Added terms are normalized against the taxonomy. Approved or
+// published videos that change are sent back to draft, logged with the
+// editor.
+func (s *VideoService) BulkUpdateVideos(ids []string, update models.BulkVideoUpdate, editor string) (*BulkUpdateResult, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no videos to update")
+	}
+
+	// Normalize the terms being added so they match the vocabulary
+	terms := models.VideoFormData{
+		EquipmentRequired: update.AddEquipment,
+		BodyParts:         update.AddBodyParts,
+		Tags:              update.AddTags,
+	}
+	if err := s.normalizeTerms(&terms); err != nil {
+		return nil, err
+	}
+	update.AddEquipment = terms.EquipmentRequired
+	update.AddBodyParts = terms.BodyParts
+	update.AddTags = terms.Tags
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT id, status, category_id, difficulty_level, equipment_required, body_parts, tags
+		FROM exercise_videos
+		WHERE id::text = ANY($1)
+		FOR UPDATE
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query videos: %w", err)
+	}
+
+	var videos []models.ExerciseVideo
+	for rows.Next() {
+		video := models.ExerciseVideo{
+			EquipmentRequired: make([]string, 0),
+			BodyParts:         make([]string, 0),
+			Tags:              make([]string, 0),
+		}
+		err := rows.Scan(
+			&video.ID,
+			&video.Status,
+			&video.CategoryID,
+			&video.DifficultyLevel,
+			pq.Array(&video.EquipmentRequired),
+			pq.Array(&video.BodyParts),
+			pq.Array(&video.Tags),
+		)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan video: %w", err)
+		}
+		videos = append(videos, video)
+	}
+	rows.Close()
+
+	result := &BulkUpdateResult{Matched: len(videos)}
+	if len(videos) != len(uniqueStrings(ids)) {
+		return nil, fmt.Errorf("%d of %d videos not found", len(uniqueStrings(ids))-len(videos), len(uniqueStrings(ids)))
+	}
+
+	for i := range videos {
+		video := &videos[i]
+		changed := update.Apply(video)
+
+		if changed {
+			_, err := tx.Exec(`
+				UPDATE exercise_videos SET
+					difficulty_level = $2, equipment_required = $3, body_parts = $4, tags = $5,
+					updated_at = NOW()
+				WHERE id = $1
+			`, video.ID, video.DifficultyLevel, pq.Array(video.EquipmentRequired), pq.Array(video.BodyParts), pq.Array(video.Tags))
+			if err != nil {
+				return nil, fmt.Errorf("failed to update video %s: %w", video.ID, err)
+			}
+		}
+
+		if update.MoveToCategoryID != "" {
+			res, err := tx.Exec(`
+				UPDATE exercise_videos SET category_id = $2, updated_at = NOW()
+				WHERE id = $1 AND (
+					category_id <> $2
+					OR (SELECT COUNT(*) FROM exercise_video_categories WHERE video_id = $1) <> 1
+				)
+			`, video.ID, update.MoveToCategoryID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to move video %s: %w", video.ID, err)
+			}
+			if moved, _ := res.RowsAffected(); moved > 0 {
+				if err := setVideoCategories(tx, video.ID, []string{update.MoveToCategoryID}); err != nil {
+					return nil, err
+				}
+				changed = true
+			}
+		}
+
+		if changed {
+			result.Updated++
+		}
+		if changed && videoReviewed(video.Status) {
+			if err := reopenVideo(tx, video.ID, video.Status, editor, "Bulk update after review"); err != nil {
+				return nil, err
+			}
+			result.Reopened++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit bulk update: %w", err)
+	}
+
+	return result, nil
 }
 
 // DeleteVideo deletes a video (hard delete)
@@ -456,11 +889,17 @@ func (s *VideoService) DeleteVideo(id string) error {
 	return nil
 }
 
-// DeleteVideoByURL deletes a video by its YouTube URL (hard delete)
+// DeleteVideoByURL deletes a video by its URL (hard delete). Any URL of the
+// video works, e.g. a youtu.be link for a YouTube video.
 func (s *VideoService) DeleteVideoByURL(url string) error {
-	query := `DELETE FROM exercise_videos WHERE youtube_url = $1`
+	provider, externalID, err := providers.Resolve("", url)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM exercise_videos WHERE provider = $1 AND external_id = $2`
 	
-	result, err := s.db.Exec(query, url)
+	result, err := s.db.Exec(query, provider.Name(), externalID)
 	if err != nil {
 		return fmt.Errorf("failed to delete video by URL: %w", err)
 	}
@@ -477,25 +916,6 @@ func (s *VideoService) DeleteVideoByURL(url string) error {
 	return nil
 }
 
-// extractYouTubeID extracts the YouTube video ID from various URL formats
-func (s *VideoService) extractYouTubeID(url string) (string, error) {
-	patterns := []string{
-		`youtube\.com/watch\?v=([a-zA-Z0-9_-]+)`,
-		`youtu\.be/([a-zA-Z0-9_-]+)`,
-		`youtube\.com/embed/([a-zA-Z0-9_-]+)`,
-	}
-
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindStringSubmatch(url)
-		if len(matches) > 1 {
-			return matches[1], nil
-		}
-	}
-
-	return "", fmt.Errorf("invalid YouTube URL format")
-}
-
 // SeedSampleVideos seeds the database with sample exercise videos
 func (s *VideoService) SeedSampleVideos() error {
 	// Get categories first
@@ -513,7 +933,7 @@ func (s *VideoService) SeedSampleVideos() error {
 		{
 			Title:             "Basic Back Stretch Routine",
 			Description:       "A gentle stretching routine for lower back pain relief",
-			YoutubeURL:        "https://www.youtube.com/watch?v=4vTJHUDB5ak",
+			VideoURL:          "https://www.youtube.com/watch?v=4vTJHUDB5ak",
 			CategoryID:        categories[0].ID, // Back & Spine
 			Duration:          intPtr(10),
 			DifficultyLevel:   "beginner",
@@ -524,7 +944,7 @@ func (s *VideoService) SeedSampleVideos() error {
 		{
 			Title:             "Neck and Shoulder Relief",
 			Description:       "Simple exercises to relieve neck and shoulder tension",
-			YoutubeURL:        "https://www.youtube.com/watch?v=akgQbxhrhOc",
+			VideoURL:          "https://www.youtube.com/watch?v=akgQbxhrhOc",
 			CategoryID:        findCategoryByName(categories, "Neck & Shoulders"),
 			Duration:          intPtr(8),
 			DifficultyLevel:   "beginner",
@@ -535,7 +955,7 @@ func (s *VideoService) SeedSampleVideos() error {
 		{
 			Title:             "Knee Strengthening Exercises",
 			Description:       "Strengthening exercises for knee stability and pain relief",
-			YoutubeURL:        "https://www.youtube.com/watch?v=MEQRHUoLGgI",
+			VideoURL:          "https://www.youtube.com/watch?v=MEQRHUoLGgI",
 			CategoryID:        findCategoryByName(categories, "Knee & Hip"),
 			Duration:          intPtr(15),
 			DifficultyLevel:   "intermediate",
@@ -547,15 +967,21 @@ func (s *VideoService) SeedSampleVideos() error {
 
 	for _, videoData := range sampleVideos {
 		// Check if video already exists
-		existing, _ := s.getVideoByYouTubeURL(videoData.YoutubeURL)
+		existing, _ := s.getVideoByURL(videoData.Provider, videoData.VideoURL)
 		if existing != nil {
 			continue // Skip if already exists
 		}
 
-		_, err := s.CreateVideo(videoData)
+		video, err := s.CreateVideo(videoData)
 		if err != nil {
 			return fmt.Errorf("failed to create sample video '%s': %w", videoData.Title, err)
 		}
+
+		// Sample videos skip the review workflow
+		_, err = s.db.Exec(`UPDATE exercise_videos SET status = $2, published_at = NOW() WHERE id = $1`, video.ID, models.VideoStatusPublished)
+		if err != nil {
+			return fmt.Errorf("failed to publish sample video '%s': %w", videoData.Title, err)
+		}
 	}
 
 	return nil
@@ -566,6 +992,31 @@ func intPtr(i int) *int {
 	return &i
 }
 
+func lowerStrings(values []string) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = strings.ToLower(strings.TrimSpace(v))
+	}
+	return result
+}
+
+// escapeLike escapes the LIKE wildcards in a search term
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.TrimSpace(value))
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 func findCategoryByName(categories []models.VideoCategory, name string) string {
 	for _, cat := range categories {
 		if strings.Contains(cat.Name, name) {
@@ -575,10 +1026,16 @@ func findCategoryByName(categories []models.VideoCategory, name string) string {
 	return categories[0].ID // Fallback to first category
 }
 
-func (s *VideoService) getVideoByYouTubeURL(url string) (*models.ExerciseVideo, error) {
-	query := `SELECT id FROM exercise_videos WHERE youtube_url = $1`
+// getVideoByURL finds a video by the provider and ID of its URL
+func (s *VideoService) getVideoByURL(providerName, url string) (*models.ExerciseVideo, error) {
+	provider, externalID, err := providers.Resolve(providerName, url)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id FROM exercise_videos WHERE provider = $1 AND external_id = $2`
 	var id string
-	err := s.db.QueryRow(query, url).Scan(&id)
+	err = s.db.QueryRow(query, provider.Name(), externalID).Scan(&id)
 	if err != nil {
 		return nil, err
 	}
@@ -605,7 +1062,8 @@ type ImportError struct {
 type CSVVideoData struct {
 	Title        string `csv:"title"`
 	Description  string `csv:"description"`
-	YoutubeURL   string `csv:"youtube_url"`
+	VideoURL     string `csv:"video_url"` // youtube_url is accepted as an alias
+	Provider     string `csv:"provider"`
 	CategoryName string `csv:"category_name"`
 	Difficulty   string `csv:"difficulty"`
 	Duration     string `csv:"duration"`
@@ -657,19 +1115,40 @@ func (s *VideoService) ImportVideosFromCSV(filename string, dryRun bool, skipErr
 		columnMap[strings.ToLower(strings.TrimSpace(col))] = i
 	}
 
+	// youtube_url is the legacy name of the video_url column
+	if idx, exists := columnMap["youtube_url"]; exists {
+		if _, hasVideoURL := columnMap["video_url"]; !hasVideoURL {
+			columnMap["video_url"] = idx
+		}
+	}
+
 	// Validate required columns
-	requiredColumns := []string{"title", "youtube_url", "category_name"}
+	requiredColumns := []string{"title", "video_url", "category_name"}
 	for _, col := range requiredColumns {
 		if _, exists := columnMap[col]; !exists {
 			return nil, fmt.Errorf("required column '%s' not found in CSV", col)
 		}
 	}
 
+	// Check per-locale columns (title_pt, description_es, ...)
+	for col := range columnMap {
+		if _, _, ok, err := translationColumn(col); ok && err != nil {
+			return nil, err
+		}
+	}
+
 	// Process each row
 	for rowIndex, record := range records[1:] {
 		rowNum := rowIndex + 2 // +2 because we skip header and arrays are 0-indexed
 
 		videoData, err := s.parseCSVRow(record, columnMap, categoryMap, rowNum)
+		if err == nil {
+			// Validate here too so dry runs catch invalid rows
+			err = videoData.Validate()
+		}
+		if err == nil {
+			err = s.normalizeTerms(videoData)
+		}
 		if err != nil {
 			result.ErrorCount++
 			result.Errors = append(result.Errors, ImportError{
@@ -683,12 +1162,12 @@ func (s *VideoService) ImportVideosFromCSV(filename string, dryRun bool, skipErr
 		}
 
 		// Check for duplicates
-		existing, _ := s.getVideoByYouTubeURL(videoData.YoutubeURL)
+		existing, _ := s.getVideoByURL(videoData.Provider, videoData.VideoURL)
 		if existing != nil {
 			result.SkippedCount++
 			result.Warnings = append(result.Warnings, ImportError{
 				Row:     rowNum,
-				Message: fmt.Sprintf("Video with URL '%s' already exists, skipping", videoData.YoutubeURL),
+				Message: fmt.Sprintf("Video with URL '%s' already exists, skipping", videoData.VideoURL),
 			})
 			continue
 		}
@@ -730,20 +1209,29 @@ func (s *VideoService) parseCSVRow(record []string, columnMap map[string]int, ca
 		return nil, fmt.Errorf("title is required")
 	}
 
-	youtubeURL := getValue("youtube_url")
-	if youtubeURL == "" {
-		return nil, fmt.Errorf("youtube_url is required")
+	videoURL := getValue("video_url")
+	if videoURL == "" {
+		return nil, fmt.Errorf("video_url is required")
+	}
+
+	provider := getValue("provider")
+	if _, _, err := providers.Resolve(provider, videoURL); err != nil {
+		return nil, err
 	}
 
-	categoryName := getValue("category_name")
-	if categoryName == "" {
+	categoryNames := parseArray(getValue("category_name"))
+	if len(categoryNames) == 0 {
 		return nil, fmt.Errorf("category_name is required")
 	}
 
-	// Find category ID
-	categoryID, exists := categoryMap[strings.ToLower(categoryName)]
-	if !exists {
-		return nil, fmt.Errorf("category '%s' not found", categoryName)
+	// Find category IDs (the first category is the primary one)
+	categoryIDs := make([]string, 0, len(categoryNames))
+	for _, categoryName := range categoryNames {
+		categoryID, exists := categoryMap[strings.ToLower(categoryName)]
+		if !exists {
+			return nil, fmt.Errorf("category '%s' not found", categoryName)
+		}
+		categoryIDs = append(categoryIDs, categoryID)
 	}
 
 	// Optional fields with defaults
@@ -770,39 +1258,113 @@ func (s *VideoService) parseCSVRow(record []string, columnMap map[string]int, ca
 		}
 	}
 
-	// Parse arrays (semicolon-separated)
-	parseArray := func(value string) []string {
-		if value == "" {
-			return []string{}
-		}
-		parts := strings.Split(value, ";")
-		result := make([]string, 0, len(parts))
-		for _, part := range parts {
-			trimmed := strings.TrimSpace(part)
-			if trimmed != "" {
-				result = append(result, trimmed)
-			}
-		}
-		return result
-	}
-
 	equipment := parseArray(getValue("equipment"))
 	bodyParts := parseArray(getValue("body_parts"))
 	tags := parseArray(getValue("tags"))
 
+	// Safety information
+	contraindications, err := models.ParseConditions(parseArray(getValue("contraindications")))
+	if err != nil {
+		return nil, fmt.Errorf("invalid contraindications: %w", err)
+	}
+	precautions, err := models.ParseConditions(parseArray(getValue("precautions")))
+	if err != nil {
+		return nil, fmt.Errorf("invalid precautions: %w", err)
+	}
+
+	var minWeeksPostSurgery *int
+	if value := getValue("min_weeks_post_surgery"); value != "" {
+		weeks, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min_weeks_post_surgery '%s': must be a number", value)
+		}
+		minWeeksPostSurgery = &weeks
+	}
+
+	// Publishing window
+	publishAt, err := models.ParseScheduleTime(getValue("publish_at"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid publish_at: %w", err)
+	}
+	expireAt, err := models.ParseScheduleTime(getValue("expire_at"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid expire_at: %w", err)
+	}
+
+	// Translations from per-locale columns
+	translations := make(map[string]models.Translation)
+	for col := range columnMap {
+		field, locale, ok, _ := translationColumn(col)
+		value := getValue(col)
+		if !ok || value == "" {
+			continue
+		}
+		translation := translations[locale]
+		if field == "title" {
+			translation.Title = value
+		} else {
+			translation.Description = value
+		}
+		translations[locale] = translation
+	}
+
 	return &models.VideoFormData{
-		Title:             title,
-		Description:       description,
-		YoutubeURL:        youtubeURL,
-		CategoryID:        categoryID,
-		Duration:          duration,
-		DifficultyLevel:   difficulty,
-		EquipmentRequired: equipment,
-		BodyParts:         bodyParts,
-		Tags:              tags,
+		Title:               title,
+		Description:         description,
+		VideoURL:            videoURL,
+		Provider:            provider,
+		CategoryID:          categoryIDs[0],
+		CategoryIDs:         categoryIDs[1:],
+		Duration:            duration,
+		DifficultyLevel:     difficulty,
+		EquipmentRequired:   equipment,
+		BodyParts:           bodyParts,
+		Tags:                tags,
+		Contraindications:   contraindications,
+		Precautions:         precautions,
+		MinWeeksPostSurgery: minWeeksPostSurgery,
+		PublishAt:           publishAt,
+		ExpireAt:            expireAt,
+		Translations:        translations,
 	}, nil
 }
 
+// translationColumn recognizes per-locale CSV columns such as title_pt or
+// description_es. ok is false for other columns; err is set for unsupported
+// locales.
+func translationColumn(col string) (field string, locale string, ok bool, err error) {
+	for _, prefix := range []string{"title_", "description_"} {
+		if !strings.HasPrefix(col, prefix) {
+			continue
+		}
+		field = strings.TrimSuffix(prefix, "_")
+		locale, err = models.ParseLocale(strings.TrimPrefix(col, prefix))
+		if err == nil && locale == models.DefaultLocale {
+			err = fmt.Errorf("column '%s': %s is the default locale, use '%s' instead", col, locale, field)
+		} else if err != nil {
+			err = fmt.Errorf("column '%s': %w", col, err)
+		}
+		return field, locale, true, err
+	}
+	return "", "", false, nil
+}
+
+// parseArray splits a semicolon-separated CSV value into trimmed, non-empty items
+func parseArray(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	parts := strings.Split(value, ";")
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		trimmed := strings.TrimSpace(part)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
 // GetCategoryByName retrieves a category by name (case-insensitive)
 func (s *VideoService) GetCategoryByName(name string) (*models.VideoCategory, error) {
 	query := `
@@ -0,0 +1,41 @@
+-- Many-to-many relationship between exercise videos and categories.
+-- exercise_videos.category_id is kept as the video's primary category so
+-- existing queries keep working; the join table holds every assignment.
+CREATE TABLE IF NOT EXISTS exercise_video_categories (
+    video_id UUID NOT NULL REFERENCES exercise_videos(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES video_categories(id) ON DELETE CASCADE,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (video_id, category_id)
+);
+
+-- A video can have at most one primary category
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercise_video_categories_primary
+ON exercise_video_categories(video_id)
+WHERE is_primary;
+
+CREATE INDEX IF NOT EXISTS idx_exercise_video_categories_category
+ON exercise_video_categories(category_id);
+
+-- Preserve existing assignments as primary categories
+INSERT INTO exercise_video_categories (video_id, category_id, is_primary)
+SELECT id, category_id, true
+FROM exercise_videos
+WHERE category_id IS NOT NULL
+ON CONFLICT (video_id, category_id) DO NOTHING;
+
+-- Same access rules as exercise_videos (public read, service role write)
+ALTER TABLE exercise_video_categories ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Exercise video categories are viewable by everyone"
+    ON exercise_video_categories FOR SELECT
+    USING (true);
+
+CREATE POLICY "Only service role can manage exercise video categories"
+    ON exercise_video_categories FOR ALL
+    USING (auth.role() = 'service_role');
+
+-- Add comments for documentation
+COMMENT ON TABLE exercise_video_categories IS 'Category assignments for exercise videos (a video can belong to several categories)';
+COMMENT ON COLUMN exercise_video_categories.is_primary IS 'Primary category, mirrored in exercise_videos.category_id';
+COMMENT ON COLUMN exercise_videos.category_id IS 'Primary category of the video (see exercise_video_categories for all assignments)';
@@ -0,0 +1,103 @@
+-- Controlled vocabularies for exercise_videos.equipment_required,
+-- exercise_videos.body_parts and exercise_videos.tags
+CREATE TABLE IF NOT EXISTS taxonomy_terms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('equipment', 'body_part', 'tag')),
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Synonyms map alternative spellings to a canonical term
+CREATE TABLE IF NOT EXISTS taxonomy_synonyms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    term_id UUID NOT NULL REFERENCES taxonomy_terms(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('equipment', 'body_part', 'tag')),
+    synonym VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Terms and synonyms are unique per kind, ignoring case
+CREATE UNIQUE INDEX IF NOT EXISTS idx_taxonomy_terms_kind_name
+ON taxonomy_terms(kind, LOWER(name));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_taxonomy_synonyms_kind_synonym
+ON taxonomy_synonyms(kind, LOWER(synonym));
+
+CREATE INDEX IF NOT EXISTS idx_taxonomy_synonyms_term
+ON taxonomy_synonyms(term_id);
+
+CREATE TRIGGER update_taxonomy_terms_updated_at
+    BEFORE UPDATE ON taxonomy_terms
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE taxonomy_terms ENABLE ROW LEVEL SECURITY;
+ALTER TABLE taxonomy_synonyms ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Taxonomy terms are viewable by everyone"
+    ON taxonomy_terms FOR SELECT
+    USING (true);
+
+CREATE POLICY "Only service role can manage taxonomy terms"
+    ON taxonomy_terms FOR ALL
+    USING (auth.role() = 'service_role');
+
+CREATE POLICY "Taxonomy synonyms are viewable by everyone"
+    ON taxonomy_synonyms FOR SELECT
+    USING (true);
+
+CREATE POLICY "Only service role can manage taxonomy synonyms"
+    ON taxonomy_synonyms FOR ALL
+    USING (auth.role() = 'service_role');
+
+-- Initial vocabulary
+INSERT INTO taxonomy_terms (kind, name) VALUES
+    ('equipment', 'Yoga Mat'),
+    ('equipment', 'Resistance Bands'),
+    ('equipment', 'Exercise Ball'),
+    ('equipment', 'Foam Roller'),
+    ('equipment', 'Dumbbells'),
+    ('equipment', 'Chair'),
+    ('equipment', 'Towel'),
+    ('body_part', 'Back'),
+    ('body_part', 'Core'),
+    ('body_part', 'Neck'),
+    ('body_part', 'Shoulders'),
+    ('body_part', 'Arms'),
+    ('body_part', 'Wrists'),
+    ('body_part', 'Hips'),
+    ('body_part', 'Glutes'),
+    ('body_part', 'Legs'),
+    ('body_part', 'Knees'),
+    ('body_part', 'Ankles'),
+    ('body_part', 'Full Body')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO taxonomy_synonyms (term_id, kind, synonym)
+SELECT t.id, t.kind, s.synonym
+FROM (VALUES
+    ('equipment', 'Yoga Mat', 'Mat'),
+    ('equipment', 'Yoga Mat', 'Exercise Mat'),
+    ('equipment', 'Resistance Bands', 'Resistance Band'),
+    ('equipment', 'Resistance Bands', 'Elastic Band'),
+    ('equipment', 'Resistance Bands', 'Theraband'),
+    ('equipment', 'Exercise Ball', 'Swiss Ball'),
+    ('equipment', 'Exercise Ball', 'Stability Ball'),
+    ('equipment', 'Dumbbells', 'Dumbbell'),
+    ('body_part', 'Back', 'Spine'),
+    ('body_part', 'Core', 'Abs'),
+    ('body_part', 'Shoulders', 'Shoulder'),
+    ('body_part', 'Arms', 'Arm'),
+    ('body_part', 'Wrists', 'Wrist'),
+    ('body_part', 'Hips', 'Hip'),
+    ('body_part', 'Legs', 'Leg'),
+    ('body_part', 'Knees', 'Knee'),
+    ('body_part', 'Ankles', 'Ankle'),
+    ('body_part', 'Full Body', 'Whole Body')
+) AS s(kind, term, synonym)
+JOIN taxonomy_terms t ON t.kind = s.kind AND LOWER(t.name) = LOWER(s.term)
+ON CONFLICT DO NOTHING;
+
+-- Add comments for documentation
+COMMENT ON TABLE taxonomy_terms IS 'Canonical terms for video equipment, body parts and tags';
+COMMENT ON TABLE taxonomy_synonyms IS 'Alternative spellings that normalize to a canonical taxonomy term';
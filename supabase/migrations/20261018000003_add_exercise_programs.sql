@@ -0,0 +1,53 @@
+-- Exercise programs: named, ordered sequences of exercise videos with a
+-- prescription (sets, reps, hold, rest, frequency) for each entry
+CREATE TABLE IF NOT EXISTS exercise_programs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    duration_weeks INTEGER CHECK (duration_weeks > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS exercise_program_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    program_id UUID NOT NULL REFERENCES exercise_programs(id) ON DELETE CASCADE,
+    video_id UUID NOT NULL REFERENCES exercise_videos(id) ON DELETE RESTRICT,
+    position INTEGER NOT NULL CHECK (position > 0),
+    sets INTEGER CHECK (sets > 0),
+    reps INTEGER CHECK (reps > 0),
+    hold_seconds INTEGER CHECK (hold_seconds >= 0),
+    rest_seconds INTEGER CHECK (rest_seconds >= 0),
+    frequency VARCHAR(100), -- e.g. "2x daily", "3x per week"
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- Deferred so items can be reordered inside a transaction
+    CONSTRAINT exercise_program_items_position_key UNIQUE (program_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_program_items_video ON exercise_program_items(video_id);
+
+CREATE TRIGGER update_exercise_programs_updated_at
+    BEFORE UPDATE ON exercise_programs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_exercise_program_items_updated_at
+    BEFORE UPDATE ON exercise_program_items
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Programs are prescribed by clinicians (service role only)
+ALTER TABLE exercise_programs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE exercise_program_items ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Only service role can manage exercise programs"
+    ON exercise_programs FOR ALL
+    USING (auth.role() = 'service_role');
+
+CREATE POLICY "Only service role can manage exercise program items"
+    ON exercise_program_items FOR ALL
+    USING (auth.role() = 'service_role');
+
+-- Add comments for documentation
+COMMENT ON TABLE exercise_programs IS 'Named exercise programs (e.g. 6-week post-op knee protocol)';
+COMMENT ON TABLE exercise_program_items IS 'Ordered exercise videos of a program with their prescription';
@@ -0,0 +1,47 @@
+-- Home exercise program (HEP) assignments: videos or programs prescribed to
+-- a patient, keyed by patient_email as in appointments
+CREATE TABLE IF NOT EXISTS home_exercise_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    patient_email VARCHAR(255) NOT NULL,
+    patient_name VARCHAR(255),
+    program_id UUID REFERENCES exercise_programs(id) ON DELETE RESTRICT,
+    video_id UUID REFERENCES exercise_videos(id) ON DELETE RESTRICT,
+    start_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    end_date DATE,
+    -- Prescription for single-video assignments; programs carry their own
+    sets INTEGER CHECK (sets > 0),
+    reps INTEGER CHECK (reps > 0),
+    hold_seconds INTEGER CHECK (hold_seconds >= 0),
+    rest_seconds INTEGER CHECK (rest_seconds >= 0),
+    frequency VARCHAR(100),
+    clinician_notes TEXT,
+    assigned_by VARCHAR(255),
+    status VARCHAR(20) CHECK (status IN ('active', 'ended')) DEFAULT 'active',
+    ended_at TIMESTAMP WITH TIME ZONE,
+    end_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- Exactly one of program or video
+    CHECK ((program_id IS NULL) <> (video_id IS NULL)),
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_home_exercise_assignments_patient_email ON home_exercise_assignments(patient_email);
+CREATE INDEX IF NOT EXISTS idx_home_exercise_assignments_status ON home_exercise_assignments(status);
+CREATE INDEX IF NOT EXISTS idx_home_exercise_assignments_program ON home_exercise_assignments(program_id) WHERE program_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_home_exercise_assignments_video ON home_exercise_assignments(video_id) WHERE video_id IS NOT NULL;
+
+CREATE TRIGGER update_home_exercise_assignments_updated_at
+    BEFORE UPDATE ON home_exercise_assignments
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Patient data (service role only)
+ALTER TABLE home_exercise_assignments ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Only service role can manage home exercise assignments"
+    ON home_exercise_assignments FOR ALL
+    USING (auth.role() = 'service_role');
+
+-- Add comments for documentation
+COMMENT ON TABLE home_exercise_assignments IS 'Exercise videos and programs prescribed to patients as home exercise programs';
+COMMENT ON COLUMN home_exercise_assignments.frequency IS 'How often the patient should do the assigned exercises (e.g. "2x daily")';
@@ -0,0 +1,60 @@
+-- Per-locale translations of exercise video and category texts.
+-- The columns on exercise_videos and video_categories hold the default
+-- locale (en); a missing translation, or a NULL field, falls back to them.
+CREATE TABLE IF NOT EXISTS exercise_video_translations (
+    video_id UUID NOT NULL REFERENCES exercise_videos(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL CHECK (locale ~ '^[a-z]{2}$'),
+    title VARCHAR(255),
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (video_id, locale),
+    CHECK (title IS NOT NULL OR description IS NOT NULL)
+);
+
+CREATE TABLE IF NOT EXISTS video_category_translations (
+    category_id UUID NOT NULL REFERENCES video_categories(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL CHECK (locale ~ '^[a-z]{2}$'),
+    name VARCHAR(100),
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (category_id, locale),
+    CHECK (name IS NOT NULL OR description IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_video_translations_locale ON exercise_video_translations(locale);
+CREATE INDEX IF NOT EXISTS idx_video_category_translations_locale ON video_category_translations(locale);
+
+CREATE TRIGGER update_exercise_video_translations_updated_at
+    BEFORE UPDATE ON exercise_video_translations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_video_category_translations_updated_at
+    BEFORE UPDATE ON video_category_translations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Same access rules as the translated tables (public read, service role write)
+ALTER TABLE exercise_video_translations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE video_category_translations ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Exercise video translations are viewable by everyone"
+    ON exercise_video_translations FOR SELECT
+    USING (true);
+
+CREATE POLICY "Only service role can manage exercise video translations"
+    ON exercise_video_translations FOR ALL
+    USING (auth.role() = 'service_role');
+
+CREATE POLICY "Video category translations are viewable by everyone"
+    ON video_category_translations FOR SELECT
+    USING (true);
+
+CREATE POLICY "Only service role can manage video category translations"
+    ON video_category_translations FOR ALL
+    USING (auth.role() = 'service_role');
+
+-- Add comments for documentation
+COMMENT ON TABLE exercise_video_translations IS 'Translated titles and descriptions of exercise videos, one row per locale';
+COMMENT ON TABLE video_category_translations IS 'Translated names and descriptions of video categories, one row per locale';
+COMMENT ON COLUMN exercise_video_translations.locale IS 'ISO 639-1 language code (e.g. pt, es); exercise_videos holds the default locale (en)';
@@ -0,0 +1,61 @@
+-- Video providers beyond YouTube (Vimeo, self-hosted MP4 files).
+-- Every video now has a provider, the provider's ID for it (external_id) and
+-- its canonical URL (video_url). youtube_id and youtube_url are kept, and
+-- filled for YouTube videos only, so existing clients keep working.
+ALTER TABLE exercise_videos
+    ADD COLUMN IF NOT EXISTS provider VARCHAR(20) NOT NULL DEFAULT 'youtube'
+        CHECK (provider IN ('youtube', 'vimeo', 'mp4')),
+    ADD COLUMN IF NOT EXISTS external_id VARCHAR(500),
+    ADD COLUMN IF NOT EXISTS video_url VARCHAR(500);
+
+-- Existing rows are YouTube videos
+UPDATE exercise_videos
+SET external_id = youtube_id,
+    video_url = youtube_url
+WHERE external_id IS NULL;
+
+ALTER TABLE exercise_videos
+    ALTER COLUMN external_id SET NOT NULL,
+    ALTER COLUMN video_url SET NOT NULL,
+    ALTER COLUMN youtube_id DROP NOT NULL,
+    ALTER COLUMN youtube_url DROP NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercise_videos_provider_external_id
+ON exercise_videos(provider, external_id);
+
+-- The YouTube ID trigger only applies to YouTube videos. Writers that still
+-- only set youtube_url are supported: it is copied to video_url.
+CREATE OR REPLACE FUNCTION set_youtube_id()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.provider = 'youtube' THEN
+        IF NEW.video_url IS NULL
+            OR (TG_OP = 'UPDATE'
+                AND NEW.youtube_url IS DISTINCT FROM OLD.youtube_url
+                AND NEW.video_url IS NOT DISTINCT FROM OLD.video_url) THEN
+            NEW.video_url = NEW.youtube_url;
+        END IF;
+        NEW.youtube_url = NEW.video_url;
+        NEW.youtube_id = extract_youtube_id(NEW.video_url);
+        IF NEW.youtube_id IS NULL THEN
+            RAISE EXCEPTION 'Invalid YouTube URL format';
+        END IF;
+        NEW.external_id = NEW.youtube_id;
+    ELSE
+        NEW.youtube_id = NULL;
+        NEW.youtube_url = NULL;
+        IF NEW.external_id IS NULL OR NEW.video_url IS NULL THEN
+            RAISE EXCEPTION 'external_id and video_url are required for % videos', NEW.provider;
+        END IF;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Add comments for documentation
+COMMENT ON TABLE exercise_videos IS 'Exercise videos (YouTube, Vimeo or self-hosted) organized by categories for patient education';
+COMMENT ON COLUMN exercise_videos.provider IS 'Video host: youtube, vimeo or mp4 (self-hosted file)';
+COMMENT ON COLUMN exercise_videos.external_id IS 'Video ID at the provider (the file URL for mp4)';
+COMMENT ON COLUMN exercise_videos.video_url IS 'Canonical URL of the video';
+COMMENT ON COLUMN exercise_videos.youtube_id IS 'YouTube video ID extracted from URL for embedding (YouTube videos only)';
+COMMENT ON COLUMN exercise_videos.youtube_url IS 'Same as video_url for YouTube videos, NULL otherwise';
@@ -0,0 +1,55 @@
+-- Caption/transcript files attached to exercise videos, parsed into timed
+-- cues so transcripts can be searched
+CREATE TABLE IF NOT EXISTS video_captions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    video_id UUID NOT NULL REFERENCES exercise_videos(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL CHECK (locale ~ '^[a-z]{2}$'),
+    format VARCHAR(10) NOT NULL CHECK (format IN ('vtt', 'srt')),
+    source_filename VARCHAR(255),
+    cue_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (video_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS video_caption_cues (
+    id BIGSERIAL PRIMARY KEY,
+    caption_id UUID NOT NULL REFERENCES video_captions(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    start_ms INTEGER NOT NULL CHECK (start_ms >= 0),
+    end_ms INTEGER NOT NULL CHECK (end_ms >= start_ms),
+    text TEXT NOT NULL,
+    UNIQUE (caption_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_video_captions_video ON video_captions(video_id);
+CREATE INDEX IF NOT EXISTS idx_video_caption_cues_text ON video_caption_cues USING GIN (to_tsvector('simple', text));
+
+CREATE TRIGGER update_video_captions_updated_at
+    BEFORE UPDATE ON video_captions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Same access rules as exercise_videos (public read, service role write)
+ALTER TABLE video_captions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE video_caption_cues ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Video captions are viewable by everyone"
+    ON video_captions FOR SELECT
+    USING (true);
+
+CREATE POLICY "Only service role can manage video captions"
+    ON video_captions FOR ALL
+    USING (auth.role() = 'service_role');
+
+CREATE POLICY "Video caption cues are viewable by everyone"
+    ON video_caption_cues FOR SELECT
+    USING (true);
+
+CREATE POLICY "Only service role can manage video caption cues"
+    ON video_caption_cues FOR ALL
+    USING (auth.role() = 'service_role');
+
+-- Add comments for documentation
+COMMENT ON TABLE video_captions IS 'Caption/transcript files (WebVTT or SRT) attached to exercise videos, one per language';
+COMMENT ON TABLE video_caption_cues IS 'Timed caption cues, used for transcript search';
+COMMENT ON COLUMN video_caption_cues.start_ms IS 'Cue start, in milliseconds from the start of the video';
@@ -0,0 +1,28 @@
+-- Safety information on exercise videos: conditions a video must not be
+-- given for (contraindications), conditions calling for precautions, and how
+-- many weeks after surgery a patient must be before doing it.
+-- The condition vocabulary is shared with the data manager (models.Conditions).
+ALTER TABLE exercise_videos
+    ADD COLUMN IF NOT EXISTS contraindications TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS precautions TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS min_weeks_post_surgery INTEGER
+        CHECK (min_weeks_post_surgery BETWEEN 0 AND 104);
+
+ALTER TABLE exercise_videos
+    ADD CONSTRAINT exercise_videos_contraindications_vocabulary CHECK (contraindications <@ ARRAY[
+        'early_post_op', 'pregnancy', 'osteoporosis', 'hypertension', 'cardiac_condition',
+        'acute_disc_herniation', 'hip_replacement', 'knee_replacement', 'recent_fracture',
+        'acute_inflammation', 'balance_disorder', 'joint_hypermobility'
+    ]::TEXT[]),
+    ADD CONSTRAINT exercise_videos_precautions_vocabulary CHECK (precautions <@ ARRAY[
+        'early_post_op', 'pregnancy', 'osteoporosis', 'hypertension', 'cardiac_condition',
+        'acute_disc_herniation', 'hip_replacement', 'knee_replacement', 'recent_fracture',
+        'acute_inflammation', 'balance_disorder', 'joint_hypermobility'
+    ]::TEXT[]);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_videos_contraindications ON exercise_videos USING GIN (contraindications);
+
+-- Add comments for documentation
+COMMENT ON COLUMN exercise_videos.contraindications IS 'Conditions the video must not be given for (e.g. pregnancy, osteoporosis)';
+COMMENT ON COLUMN exercise_videos.precautions IS 'Conditions the video can be given for, with care';
+COMMENT ON COLUMN exercise_videos.min_weeks_post_surgery IS 'Minimum weeks after surgery before a patient can do the video';
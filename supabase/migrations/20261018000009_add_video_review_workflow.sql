@@ -0,0 +1,85 @@
+-- Editorial review workflow: videos move through
+-- draft -> in_review -> approved -> published, and a second physiotherapist
+-- has to approve every video before it is published.
+ALTER TABLE exercise_videos
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'in_review', 'approved', 'published')),
+    ADD COLUMN IF NOT EXISTS submitted_by VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS submitted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS reviewed_by VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMP WITH TIME ZONE;
+
+-- Videos already in the library stay visible
+UPDATE exercise_videos
+SET status = 'published',
+    published_at = created_at;
+
+CREATE INDEX IF NOT EXISTS idx_exercise_videos_status ON exercise_videos(status);
+
+-- Log of every review step, with the reviewer and their comments
+CREATE TABLE IF NOT EXISTS video_reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    video_id UUID NOT NULL REFERENCES exercise_videos(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL
+        CHECK (action IN ('submit', 'approve', 'reject', 'publish', 'unpublish')),
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_video_reviews_video ON video_reviews(video_id, created_at);
+
+-- Only published videos (and what belongs to them) are public
+DROP POLICY IF EXISTS "Exercise videos are viewable by everyone" ON exercise_videos;
+CREATE POLICY "Published exercise videos are viewable by everyone"
+    ON exercise_videos FOR SELECT
+    USING (status = 'published');
+
+DROP POLICY IF EXISTS "Exercise video categories are viewable by everyone" ON exercise_video_categories;
+CREATE POLICY "Categories of published videos are viewable by everyone"
+    ON exercise_video_categories FOR SELECT
+    USING (EXISTS (
+        SELECT 1 FROM exercise_videos ev
+        WHERE ev.id = video_id AND ev.status = 'published'
+    ));
+
+DROP POLICY IF EXISTS "Exercise video translations are viewable by everyone" ON exercise_video_translations;
+CREATE POLICY "Translations of published videos are viewable by everyone"
+    ON exercise_video_translations FOR SELECT
+    USING (EXISTS (
+        SELECT 1 FROM exercise_videos ev
+        WHERE ev.id = video_id AND ev.status = 'published'
+    ));
+
+DROP POLICY IF EXISTS "Video captions are viewable by everyone" ON video_captions;
+CREATE POLICY "Captions of published videos are viewable by everyone"
+    ON video_captions FOR SELECT
+    USING (EXISTS (
+        SELECT 1 FROM exercise_videos ev
+        WHERE ev.id = video_id AND ev.status = 'published'
+    ));
+
+DROP POLICY IF EXISTS "Video caption cues are viewable by everyone" ON video_caption_cues;
+CREATE POLICY "Caption cues of published videos are viewable by everyone"
+    ON video_caption_cues FOR SELECT
+    USING (EXISTS (
+        SELECT 1 FROM video_captions c
+        JOIN exercise_videos ev ON ev.id = c.video_id
+        WHERE c.id = caption_id AND ev.status = 'published'
+    ));
+
+-- Reviews are internal
+ALTER TABLE video_reviews ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Only service role can manage video reviews"
+    ON video_reviews FOR ALL
+    USING (auth.role() = 'service_role');
+
+-- Add comments for documentation
+COMMENT ON COLUMN exercise_videos.status IS 'Review state: draft, in_review, approved or published (only published videos are public)';
+COMMENT ON COLUMN exercise_videos.submitted_by IS 'Physiotherapist who submitted the video for review';
+COMMENT ON COLUMN exercise_videos.reviewed_by IS 'Physiotherapist who approved or rejected the video; must differ from submitted_by to approve';
+COMMENT ON TABLE video_reviews IS 'Review log of exercise videos: who submitted, approved, rejected or published them, and their comments';
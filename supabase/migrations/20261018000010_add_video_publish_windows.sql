@@ -0,0 +1,70 @@
+-- Scheduled publish and expiry windows for exercise videos. The data
+-- manager's "videos scheduler run" (run from cron) publishes approved videos
+-- once publish_at has passed and unpublishes them at expire_at; the public
+-- policies also hide videos outside their window between runs.
+ALTER TABLE exercise_videos
+    ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS expire_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE exercise_videos
+    ADD CONSTRAINT exercise_videos_publish_window CHECK (
+        publish_at IS NULL OR expire_at IS NULL OR expire_at > publish_at
+    );
+
+CREATE INDEX IF NOT EXISTS idx_exercise_videos_publish_at ON exercise_videos(publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_exercise_videos_expire_at ON exercise_videos(expire_at) WHERE expire_at IS NOT NULL;
+
+-- Whether a video is visible to the public: published, and inside its window
+CREATE OR REPLACE FUNCTION is_video_public(video exercise_videos)
+RETURNS BOOLEAN AS $$
+    SELECT video.status = 'published'
+        AND (video.publish_at IS NULL OR video.publish_at <= NOW())
+        AND (video.expire_at IS NULL OR video.expire_at > NOW());
+$$ LANGUAGE sql STABLE;
+
+DROP POLICY IF EXISTS "Published exercise videos are viewable by everyone" ON exercise_videos;
+CREATE POLICY "Published exercise videos are viewable by everyone"
+    ON exercise_videos FOR SELECT
+    USING (is_video_public(exercise_videos));
+
+DROP POLICY IF EXISTS "Categories of published videos are viewable by everyone" ON exercise_video_categories;
+CREATE POLICY "Categories of published videos are viewable by everyone"
+    ON exercise_video_categories FOR SELECT
+    USING (EXISTS (
+        SELECT 1 FROM exercise_videos ev
+        WHERE ev.id = video_id AND is_video_public(ev)
+    ));
+
+DROP POLICY IF EXISTS "Translations of published videos are viewable by everyone" ON exercise_video_translations;
+CREATE POLICY "Translations of published videos are viewable by everyone"
+    ON exercise_video_translations FOR SELECT
+    USING (EXISTS (
+        SELECT 1 FROM exercise_videos ev
+        WHERE ev.id = video_id AND is_video_public(ev)
+    ));
+
+DROP POLICY IF EXISTS "Captions of published videos are viewable by everyone" ON video_captions;
+CREATE POLICY "Captions of published videos are viewable by everyone"
+    ON video_captions FOR SELECT
+    USING (EXISTS (
+        SELECT 1 FROM exercise_videos ev
+        WHERE ev.id = video_id AND is_video_public(ev)
+    ));
+
+DROP POLICY IF EXISTS "Caption cues of published videos are viewable by everyone" ON video_caption_cues;
+CREATE POLICY "Caption cues of published videos are viewable by everyone"
+    ON video_caption_cues FOR SELECT
+    USING (EXISTS (
+        SELECT 1 FROM video_captions c
+        JOIN exercise_videos ev ON ev.id = c.video_id
+        WHERE c.id = caption_id AND is_video_public(ev)
+    ));
+
+-- Scheduler runs are logged with the other review steps
+ALTER TABLE video_reviews DROP CONSTRAINT IF EXISTS video_reviews_action_check;
+ALTER TABLE video_reviews ADD CONSTRAINT video_reviews_action_check
+    CHECK (action IN ('submit', 'approve', 'reject', 'publish', 'unpublish', 'expire'));
+
+-- Add comments for documentation
+COMMENT ON COLUMN exercise_videos.publish_at IS 'When an approved video is published automatically (videos scheduler run)';
+COMMENT ON COLUMN exercise_videos.expire_at IS 'When a published video is taken out of the public library automatically';
@@ -0,0 +1,26 @@
+-- Appointment status changes made through the data manager are validated
+-- (e.g. a cancelled appointment cannot be completed) and logged here
+CREATE TABLE IF NOT EXISTS appointment_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL
+        CHECK (action IN ('confirm', 'complete', 'no_show', 'cancel')),
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(255),
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_appointment_status_history_appointment ON appointment_status_history(appointment_id, created_at);
+
+-- Patient data (service role only)
+ALTER TABLE appointment_status_history ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Only service role can manage appointment status history"
+    ON appointment_status_history FOR ALL
+    USING (auth.role() = 'service_role');
+
+-- Add comments for documentation
+COMMENT ON TABLE appointment_status_history IS 'Log of appointment status changes: who confirmed, completed, cancelled or marked them as no-shows, and why';
+COMMENT ON COLUMN appointment_status_history.reason IS 'Reason given for the change; required to cancel';
@@ -0,0 +1,41 @@
+-- Days and hours the clinic is closed: public holidays, clinic-wide closures
+-- (e.g. a week off) and hours blocked on given days. Closures take time out of
+-- the bookable slots instead of fake events in Google Calendar.
+CREATE TABLE IF NOT EXISTS closures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(20) NOT NULL DEFAULT 'closure'
+        CHECK (kind IN ('holiday', 'closure', 'blocked')),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    start_time TIME,
+    end_time TIME,
+    reason TEXT NOT NULL,
+    ics_uid VARCHAR(255) UNIQUE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT closures_date_range CHECK (end_date >= start_date),
+    CONSTRAINT closures_time_range CHECK (
+        (start_time IS NULL AND end_time IS NULL)
+        OR (start_time IS NOT NULL AND end_time IS NOT NULL AND end_time > start_time)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_closures_dates ON closures(start_date, end_date);
+
+-- Only the data manager (service role) manages closures
+ALTER TABLE closures ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Only service role can manage closures"
+    ON closures FOR ALL
+    USING (auth.role() = 'service_role');
+
+-- Add comments for documentation
+COMMENT ON TABLE closures IS 'Days and hours the clinic is closed: holidays, closures and blocked hours';
+COMMENT ON COLUMN closures.kind IS 'holiday (public holiday), closure (clinic-wide, whole days) or blocked (hours on given days)';
+COMMENT ON COLUMN closures.start_date IS 'First day of the closure';
+COMMENT ON COLUMN closures.end_date IS 'Last day of the closure (inclusive)';
+COMMENT ON COLUMN closures.start_time IS 'Start of the blocked hours on each day, in the clinic time zone; NULL for whole days';
+COMMENT ON COLUMN closures.end_time IS 'End of the blocked hours on each day, in the clinic time zone; NULL for whole days';
+COMMENT ON COLUMN closures.ics_uid IS 'UID of the calendar event a holiday was imported from, so imports can be repeated';
@@ -0,0 +1,7 @@
+-- Editing the content of an approved or published video sends it back to
+-- draft; the edit is logged with the other review steps
+ALTER TABLE video_reviews DROP CONSTRAINT IF EXISTS video_reviews_action_check;
+ALTER TABLE video_reviews ADD CONSTRAINT video_reviews_action_check
+    CHECK (action IN ('submit', 'approve', 'reject', 'publish', 'unpublish', 'expire', 'edit'));
+
+COMMENT ON TABLE video_reviews IS 'Review log of exercise videos: who submitted, approved, rejected, published or edited them, and their comments';
@@ -0,0 +1,7 @@
+-- Appointments moved to the time of their calendar event by
+-- reconcile-calendar --fix are logged with the status changes
+ALTER TABLE appointment_status_history DROP CONSTRAINT IF EXISTS appointment_status_history_action_check;
+ALTER TABLE appointment_status_history ADD CONSTRAINT appointment_status_history_action_check
+    CHECK (action IN ('confirm', 'complete', 'no_show', 'cancel', 'reschedule'));
+
+COMMENT ON TABLE appointment_status_history IS 'Log of appointment status changes: who confirmed, completed, cancelled, rescheduled or marked them as no-shows, and why';